@@ -4,19 +4,151 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
 
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/transmeta"
+	kitexServer "github.com/cloudwego/kitex/server"
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/cart"
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/cart/cartservice"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/handlers"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/middleware"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/registry"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/rpc"
+	cartService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/cart"
+	"github.com/hashicorp/consul/api"
+	consul "github.com/kitex-contrib/registry-consul"
 	"go.uber.org/zap"
 )
 
+type CartServiceImpl struct{}
+
+// 购物车服务业务错误码
+const (
+	bizCodeInvalidParams   int32 = 400
+	bizCodeProductNotFound int32 = 404
+	bizCodeProductInvalid  int32 = 409 // 下架、库存不足或超过限购
+	bizCodeInternal        int32 = 500
+)
+
+// AddItem implements cart.CartService.
+func (s *CartServiceImpl) AddItem(ctx context.Context, req *cart.CartItem) (r *cart.CartResponse, err error) {
+	quantity := int(req.Quantity)
+	if quantity == 0 {
+		quantity = 1 // 未指定数量时默认加1，与HTTP接口一致
+	}
+
+	skuID, err := cartService.ResolveSKU(ctx, uint(req.ProductId), uint(req.GetSkuId()))
+	if err != nil {
+		return nil, toBizError(err)
+	}
+	count, err := cartService.AddItem(ctx, uint(req.UserId), skuID, quantity)
+	if err != nil {
+		return nil, toBizError(err)
+	}
+
+	resolved := int64(skuID)
+	return &cart.CartResponse{
+		Items: []*cart.CartItem{{
+			UserId:    req.UserId,
+			ProductId: req.ProductId,
+			Quantity:  int32(count),
+			SkuId:     &resolved,
+		}},
+		Message: "success",
+	}, nil
+}
+
+// RemoveItem implements cart.CartService.
+func (s *CartServiceImpl) RemoveItem(ctx context.Context, req *cart.CartItem) (r *cart.CartResponse, err error) {
+	skuID, err := cartService.ResolveSKU(ctx, uint(req.ProductId), uint(req.GetSkuId()))
+	if err != nil {
+		return nil, toBizError(err)
+	}
+	if err := cartService.RemoveItem(ctx, uint(req.UserId), skuID); err != nil {
+		return nil, toBizError(err)
+	}
+	return &cart.CartResponse{Items: []*cart.CartItem{}, Message: "success"}, nil
+}
+
+// GetCart implements cart.CartService.
+// 条目的商品ID从商品服务补全，SKU已不存在时为0。
+func (s *CartServiceImpl) GetCart(ctx context.Context, req *cart.CartRequest) (r *cart.CartResponse, err error) {
+	items, err := cartService.GetItems(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, toBizError(err)
+	}
+	skuIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		skuIDs = append(skuIDs, item.SkuID)
+	}
+	skus, err := cartService.LookupSKUs(ctx, skuIDs)
+	if err != nil {
+		return nil, toBizError(err)
+	}
+
+	resp := &cart.CartResponse{Items: make([]*cart.CartItem, 0, len(items)), Message: "success"}
+	for _, item := range items {
+		var productID int64
+		if info, ok := skus[item.SkuID]; ok {
+			productID = info.ProductId
+		}
+		// 指定了SKU或商品时只返回匹配的条目
+		if req.IsSetSkuId() && int64(item.SkuID) != req.GetSkuId() {
+			continue
+		}
+		if req.IsSetProductId() && productID != req.GetProductId() {
+			continue
+		}
+		skuID := int64(item.SkuID)
+		resp.Items = append(resp.Items, &cart.CartItem{
+			UserId:    req.UserId,
+			ProductId: productID,
+			Quantity:  int32(item.Quantity),
+			SkuId:     &skuID,
+		})
+	}
+	return resp, nil
+}
+
+// 领域错误转换为Kitex业务错误
+func toBizError(err error) error {
+	if errors.Is(err, cartService.ErrInvalidParams) {
+		return kerrors.NewBizStatusError(bizCodeInvalidParams, err.Error())
+	}
+
+	var vErr *cartService.ValidationError
+	if errors.As(err, &vErr) {
+		code := bizCodeProductInvalid
+		switch {
+		case errors.Is(err, cartService.ErrProductNotFound):
+			code = bizCodeProductNotFound
+		case errors.Is(err, cartService.ErrSKURequired):
+			code = bizCodeInvalidParams
+		}
+		return kerrors.NewBizStatusErrorWithExtra(code, vErr.Err.Error(), map[string]string{
+			"product_id": strconv.FormatUint(uint64(vErr.ProductID), 10),
+			"sku_id":     strconv.FormatUint(uint64(vErr.SkuID), 10),
+			"requested":  strconv.Itoa(vErr.Requested),
+			"available":  strconv.Itoa(vErr.Available),
+		})
+	}
+
+	zap.L().Error("购物车服务内部错误", zap.Error(err))
+	return kerrors.NewBizStatusError(bizCodeInternal, "系统内部错误")
+}
+
 func main() {
 
 	hlog.Info("=== 初始化开始 ===")
@@ -36,9 +168,68 @@ func main() {
 	}
 	dal.InitDB() // 使用独立数据库配置
 
+	// 购物车详情需要从商品服务补全商品信息
+	if err := rpc.InitProductClient(); err != nil {
+		panic(err)
+	}
+
+	// 创建RPCConsul注册中心
+	consulRegister, err := consul.NewConsulRegister(
+		config.Conf.Consul.Address,
+		consul.WithCheck(&api.AgentServiceCheck{
+			HTTP: fmt.Sprintf("http://%s:%d/health",
+				config.Conf.Service.IP,
+				config.Conf.Service.CartHTTPPort),
+			Interval: "10s",
+			Timeout:  "5s",
+			Status:   api.HealthPassing,
+		}),
+	)
+	if err != nil {
+		panic("Consul注册失败: " + err.Error())
+	}
+	// 启动RPC服务端
+	go func() {
+		// Kitex RPC服务配置
+		rpcAddr, _ := net.ResolveTCPAddr("tcp", ":8882")
+		svr := cartservice.NewServer(
+			new(CartServiceImpl),
+			kitexServer.WithRegistry(consulRegister),
+			kitexServer.WithMetaHandler(transmeta.ServerTTHeaderHandler),
+			kitexServer.WithServerBasicInfo(&rpcinfo.EndpointBasicInfo{
+				ServiceName: "cart.service",
+				Tags: map[string]string{
+					"protocol": "kitex",
+					"env":      "dev",
+				},
+			}),
+			kitexServer.WithServiceAddr(&net.TCPAddr{
+				IP:   net.ParseIP(config.Conf.Service.IP),
+				Port: config.Conf.Service.CartRpcPort,
+			}),
+		)
+
+		zap.L().Info("cart启动RPC服务", zap.String("addr", rpcAddr.String()))
+		if err := svr.Run(); err != nil {
+			panic("cart的RPC服务启动失败: " + err.Error())
+		}
+	}()
+
+	// 订阅下单事件，下单后清理购物车
+	if err := event.Init(); err != nil {
+		panic(err)
+	}
+	consumerCtx, stopConsumers := context.WithCancel(context.Background())
+	if err := cartService.StartConsumers(consumerCtx, event.DefaultBroker); err != nil {
+		panic("事件订阅失败: " + err.Error())
+	}
+
 	h := server.Default(
 		server.WithHostPorts(":8082"), // 不同端口
 	)
+	h.OnShutdown = append(h.OnShutdown, func(ctx context.Context) {
+		stopConsumers()
+	})
 
 	// 注册服务
 	if _, err := registry.RegisterService("cart-service", 8082); err != nil {
@@ -48,6 +239,11 @@ func main() {
 	// 购物车服务路由
 	h.POST("/cart/add", middleware.JWTAuth(), handlers.AddToCart)
 	h.DELETE("/cart/delete", middleware.JWTAuth(), handlers.ClearCart)
+	h.GET("/cart", middleware.JWTAuth(), handlers.GetCart)
+	h.PUT("/cart/items/:product_id", middleware.JWTAuth(), handlers.UpdateCartItem) // 按商品，多规格商品带sku_id查询参数
+	h.DELETE("/cart/items/:product_id", middleware.JWTAuth(), handlers.RemoveCartItem)
+	h.PUT("/cart/skus/:sku_id", middleware.JWTAuth(), handlers.UpdateCartItem)
+	h.DELETE("/cart/skus/:sku_id", middleware.JWTAuth(), handlers.RemoveCartItem)
 	//测试
 	h.POST("/cart/redis-test", middleware.JWTAuth(), handlers.TestRedis)
 
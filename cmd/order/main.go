@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"time"
@@ -11,7 +12,9 @@ import (
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/kitex/pkg/kerrors"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/transmeta"
 	"github.com/hashicorp/consul/api"
 
 	// "github.com/cloudwego/kitex/server"
@@ -20,32 +23,71 @@ import (
 	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/order/orderservice"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/handlers"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/middleware"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/registry"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/rpc"
+	checkoutService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/checkout"
+	orderService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/order"
+	paymentService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/payment"
 	consul "github.com/kitex-contrib/registry-consul"
 	"go.uber.org/zap"
 )
 
 type OrderServiceImpl struct{}
 
+// 订单服务业务错误码
+const (
+	bizCodeInvalidParams int32 = 400
+	bizCodeOrderNotFound int32 = 404
+	bizCodeStatusInvalid int32 = 409 // 非法状态流转或并发冲突
+	bizCodeInternal      int32 = 500
+)
+
+// HealthCheck implements order.OrderService.
+func (s *OrderServiceImpl) HealthCheck(ctx context.Context) (bool, error) {
+	return dal.DB.WithContext(ctx).Exec("SELECT 1").Error == nil, nil
+}
+
 func (s *OrderServiceImpl) UpdateStatus(ctx context.Context, req *order.UpdateReq) (bool, error) {
 	zap.L().Info("收到RPC订单状态更新请求",
 		zap.String("order_id", req.OrderID),
 		zap.String("status", req.Status))
 
-	result := dal.DB.Model(&dal.Order{}).
-		Where("order_id = ?", req.OrderID).
-		Update("status", req.Status)
+	status, err := orderService.ParseStatus(req.Status)
+	if err != nil {
+		return false, toBizError(err)
+	}
 
-	if result.Error != nil {
-		zap.L().Error("数据库更新失败",
+	actor := req.GetActor()
+	if actor == "" {
+		actor = "rpc"
+	}
+	if _, err := orderService.Transition(ctx, dal.DB, req.OrderID, status, actor, req.GetReason()); err != nil {
+		zap.L().Error("订单状态更新失败",
 			zap.String("order_id", req.OrderID),
-			zap.Error(result.Error))
+			zap.Error(err))
+		return false, toBizError(err)
+	}
+	return true, nil
+}
+
+// 领域错误转换为Kitex业务错误
+func toBizError(err error) error {
+	switch {
+	case errors.Is(err, orderService.ErrInvalidStatus):
+		return kerrors.NewBizStatusError(bizCodeInvalidParams, err.Error())
+	case errors.Is(err, orderService.ErrOrderNotFound):
+		return kerrors.NewBizStatusError(bizCodeOrderNotFound, err.Error())
+	case errors.Is(err, orderService.ErrInvalidTransition),
+		errors.Is(err, orderService.ErrStatusConflict):
+		return kerrors.NewBizStatusError(bizCodeStatusInvalid, err.Error())
 	}
 
-	return result.RowsAffected > 0, result.Error
+	zap.L().Error("订单服务内部错误", zap.Error(err))
+	return kerrors.NewBizStatusError(bizCodeInternal, "系统内部错误")
 }
 
 func main() {
@@ -64,6 +106,18 @@ func main() {
 	// 初始化认证中间件
 	middleware.InitAuthMiddleware("config/auth.yaml")
 
+	// 订单定价依赖商品服务，结算流程依赖购物车服务
+	if err := rpc.InitProductClient(); err != nil {
+		panic(err)
+	}
+	if err := rpc.InitCartClient(); err != nil {
+		panic(err)
+	}
+	// 结算流程需要在支付渠道发起支付
+	if err := paymentService.InitProvider(); err != nil {
+		panic(err)
+	}
+
 	// 创建Consul注册中心
 	consulRegister, err := consul.NewConsulRegister(
 		config.Conf.Consul.Address,
@@ -92,6 +146,7 @@ func main() {
 		svr := orderservice.NewServer(
 			new(OrderServiceImpl),
 			kitexServer.WithRegistry(consulRegister),
+			kitexServer.WithMetaHandler(transmeta.ServerTTHeaderHandler),
 			kitexServer.WithServerBasicInfo(&rpcinfo.EndpointBasicInfo{
 				ServiceName: "order.service",
 				Tags: map[string]string{
@@ -111,19 +166,38 @@ func main() {
 		}
 	}()
 
+	// 启动未支付订单超时取消任务
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	orderService.StartTimeoutWorker(workerCtx)
+	// 回滚崩溃时中断的结算流程
+	checkoutService.StartRecovery(workerCtx)
+	// 发件箱中继：投递订单事件
+	if err := event.Init(); err != nil {
+		panic(err)
+	}
+	event.StartRelay(workerCtx, event.DefaultBroker)
+	// 退款事件：全额退款时变更订单状态
+	if err := orderService.StartConsumers(workerCtx, event.DefaultBroker); err != nil {
+		panic("事件订阅失败: " + err.Error())
+	}
+
 	if _, err := registry.RegisterService("order-service", config.Conf.Service.OrderHTTPPort); err != nil {
 		panic("服务注册失败: " + err.Error())
 	}
 	// 初始化HTTP服务器
-	orderHandler := handlers.NewOrderHandler(dal.DB, redis.Client)
+	orderHandler := handlers.NewOrderHandler(dal.DB)
 	h := server.Default(
 		server.WithHostPorts(":8083"),
 		server.WithExitWaitTime(5*time.Second),
 	)
 
 	// 注册HTTP路由
-	h.POST("/orders", middleware.JWTAuth(), orderHandler.CreateOrder)
-	h.PUT("/order/status", updateOrderStatusHTTP)
+	h.POST("/orders", middleware.JWTAuth(), middleware.Idempotency("order:create", 24*time.Hour), orderHandler.CreateOrder)
+	h.GET("/orders", middleware.JWTAuth(), orderHandler.ListOrders)
+	h.GET("/orders/:order_no", middleware.JWTAuth(), orderHandler.GetOrder)
+	h.POST("/orders/:order_no/cancel", middleware.JWTAuth(), orderHandler.CancelOrder)
+	h.PUT("/order/status", middleware.JWTAuth(), middleware.RequireRole(dal.RoleAdmin, dal.RoleMerchant), updateOrderStatusHTTP)
+	h.POST("/checkout", middleware.JWTAuth(), middleware.Idempotency("checkout", 24*time.Hour), handlers.Checkout)
 
 	// 健康检查
 	h.GET("/health", func(c context.Context, ctx *app.RequestContext) {
@@ -137,6 +211,7 @@ func main() {
 	// 优雅关闭
 	h.OnShutdown = append(h.OnShutdown, func(ctx context.Context) {
 		zap.L().Info("HTTP服务关闭中...")
+		stopWorker()
 		redis.Client.Close()
 	})
 
@@ -144,11 +219,20 @@ func main() {
 	h.Spin()
 }
 
-// HTTP接口的订单状态更新
+// 只能由支付服务通过RPC变更的状态：支付成功与退款需与支付记录保持一致
+var paymentOnlyStatuses = map[dal.OrderStatus]bool{
+	dal.OrderStatusPaid:      true,
+	dal.OrderStatusRefunding: true,
+	dal.OrderStatusRefunded:  true,
+}
+
+// HTTP接口的订单状态更新（管理员/商家），用于发货、签收等履约操作
+// 商家只能变更全部商品都属于自己的订单；取消订单走与用户取消相同的流程以归还库存。
 func updateOrderStatusHTTP(c context.Context, ctx *app.RequestContext) {
 	var req struct {
-		OrderID string `json:"order_id"`
+		OrderID string `json:"order_id"` // 订单号（order_no）
 		Status  string `json:"status"`
+		Reason  string `json:"reason"`
 	}
 
 	if err := ctx.BindJSON(&req); err != nil {
@@ -156,15 +240,52 @@ func updateOrderStatusHTTP(c context.Context, ctx *app.RequestContext) {
 		return
 	}
 
-	result := dal.DB.Model(&dal.Order{}).
-		Where("order_id = ?", req.OrderID).
-		Update("status", req.Status)
+	status, err := orderService.ParseStatus(req.Status)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": err.Error()})
+		return
+	}
+
+	if paymentOnlyStatuses[status] {
+		ctx.JSON(400, map[string]string{"error": fmt.Sprintf("订单状态%s只能由支付服务变更", status)})
+		return
+	}
+
+	if ctx.GetString("role") == dal.RoleMerchant {
+		if err := orderService.CheckMerchant(c, req.OrderID, ctx.GetUint("userID")); err != nil {
+			switch {
+			case errors.Is(err, orderService.ErrOrderNotFound):
+				ctx.JSON(404, map[string]string{"error": err.Error()})
+			case errors.Is(err, orderService.ErrForbidden):
+				ctx.JSON(403, map[string]string{"error": err.Error()})
+			default:
+				zap.L().Error("订单归属校验失败", zap.String("order_id", req.OrderID), zap.Error(err))
+				ctx.JSON(500, map[string]string{"error": "update failed"})
+			}
+			return
+		}
+	}
 
-	if result.Error != nil {
+	actor := fmt.Sprintf("user:%d", ctx.GetUint("userID"))
+	if status == dal.OrderStatusCanceled {
+		_, err = orderService.CancelByOperator(c, req.OrderID, actor, req.Reason)
+	} else {
+		_, err = orderService.Transition(c, dal.DB, req.OrderID, status, actor, req.Reason)
+	}
+	if err != nil {
 		zap.L().Error("HTTP订单状态更新失败",
 			zap.String("order_id", req.OrderID),
-			zap.Error(result.Error))
-		ctx.JSON(500, map[string]string{"error": "update failed"})
+			zap.Error(err))
+		switch {
+		case errors.Is(err, orderService.ErrOrderNotFound):
+			ctx.JSON(404, map[string]string{"error": err.Error()})
+		case errors.Is(err, orderService.ErrInvalidTransition),
+			errors.Is(err, orderService.ErrStatusConflict),
+			errors.Is(err, orderService.ErrCancelNotAllowed):
+			ctx.JSON(409, map[string]string{"error": err.Error()})
+		default:
+			ctx.JSON(500, map[string]string{"error": "update failed"})
+		}
 		return
 	}
 
@@ -4,60 +4,37 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
+	"net/url"
 	// "net"
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/app"
-	"github.com/cloudwego/hertz/pkg/app/server"
 	hclient "github.com/cloudwego/hertz/pkg/app/client"
+	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/kitex/pkg/kerrors"
 	// "github.com/cloudwego/hertz/pkg/protocol"
-	"github.com/cloudwego/kitex/client"
-	consul "github.com/kitex-contrib/registry-consul"
 	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/order"
-	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/order/orderservice"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/middleware"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/registry"
-	"github.com/google/uuid"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/rpc"
+	paymentService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/payment"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
-type PaymentRecord struct {
-	gorm.Model
-	OrderID   string `gorm:"uniqueIndex"`
-	PaymentID string
-	Amount    float64
-	Status    string // pending/success/failed
-	UserID    uint
-}
-
 var (
-	httpClient  *hclient.Client
-	orderClient orderservice.Client
+	httpClient *hclient.Client
 )
 
-func initOrderClient() {
-	r, err := consul.NewConsulResolver(config.Conf.Consul.Address)
-	if err != nil {
-		panic(fmt.Sprintf("Consul初始化失败: %v", err))
-	}
-
-	orderClient, err = orderservice.NewClient(
-		"order.service",
-		client.WithResolver(r),
-		client.WithRPCTimeout(3*time.Second),
-		client.WithHostPorts("localhost:8080"), // 与order服务端口一致
-	)
-	if err != nil {
-		panic(fmt.Sprintf("订单服务客户端初始化失败: %v", err))
-	}
-}
-
 func main() {
 	// 初始化基础组件
 	hlog.Info("=== 支付服务初始化 ===")
@@ -73,7 +50,28 @@ func main() {
 
 	// 初始化服务客户端
 	middleware.InitAuthMiddleware("config/auth.yaml")
-	initOrderClient()
+	if err := rpc.InitOrderClient(); err != nil {
+		panic(err)
+	}
+	if err := paymentService.InitProvider(); err != nil {
+		panic(err)
+	}
+
+	// 发件箱中继：投递支付与退款事件
+	if err := event.Init(); err != nil {
+		panic(err)
+	}
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	event.StartRelay(workerCtx, event.DefaultBroker)
+	// 已支付订单取消后全额退款
+	if err := paymentService.StartConsumers(workerCtx, event.DefaultBroker); err != nil {
+		panic("事件订阅失败: " + err.Error())
+	}
+	// 异步通知丢失时主动查询渠道，超时未支付的记录关闭
+	paymentService.StartPoller(workerCtx, func(c context.Context, n *paymentService.Notification) error {
+		_, err := processNotification(c, n)
+		return err
+	})
 
 	// 创建HTTP服务器
 	h := server.Default(
@@ -101,6 +99,7 @@ func main() {
 	// 优雅关闭
 	h.OnShutdown = append(h.OnShutdown, func(ctx context.Context) {
 		zap.L().Info("支付服务关闭中...")
+		stopWorkers()
 		redis.Client.Close()
 	})
 
@@ -108,25 +107,45 @@ func main() {
 }
 
 func registerRoutes(h *server.Hertz) {
-	// 支付回调接口
+	// 支付渠道异步通知
 	h.POST("/payment/callback", func(c context.Context, ctx *app.RequestContext) {
-		orderID := ctx.Query("order_id")
-		if err := UpdateOrderStatus(orderID, "paid"); err != nil {
-			zap.L().Error("订单状态更新失败", 
-				zap.String("order_id", orderID),
-				zap.Error(err))
-			ctx.JSON(500, map[string]interface{}{"error": err.Error()})
+		n, err := paymentService.VerifyNotification(c, func(key string) string {
+			return string(ctx.GetHeader(key))
+		}, ctx.Request.Body())
+		if err != nil {
+			zap.L().Warn("支付通知校验失败", zap.Error(err))
+			switch {
+			case errors.Is(err, paymentService.ErrInvalidSignature),
+				errors.Is(err, paymentService.ErrNotificationExpired):
+				ctx.JSON(401, map[string]interface{}{"error": err.Error()})
+			case errors.Is(err, paymentService.ErrNotificationReplayed):
+				ctx.JSON(409, map[string]interface{}{"error": err.Error()})
+			default:
+				ctx.JSON(400, map[string]interface{}{"error": err.Error()})
+			}
+			return
+		}
+
+		if status, err := processNotification(c, n); err != nil {
+			ctx.JSON(status, map[string]interface{}{"error": err.Error()})
 			return
 		}
 		ctx.JSON(200, map[string]interface{}{"status": "success"})
 	})
 
-	// 创建支付记录
-	h.POST("/payment/create", func(c context.Context, ctx *app.RequestContext) {
+	registerRefundRoutes(h)
+
+	// 本地模拟网关的支付页面
+	if mock, ok := paymentService.DefaultProvider.(*paymentService.MockProvider); ok {
+		registerMockGatewayRoutes(h, mock)
+	}
+
+	// 创建支付记录，只能为自己的订单发起支付；幂等键按登录用户隔离
+	h.POST("/payment/create", middleware.JWTAuth(), middleware.Idempotency("payment:create", 24*time.Hour), func(c context.Context, ctx *app.RequestContext) {
 		var req struct {
-			OrderID string  `json:"order_id"`
-			Amount  float64 `json:"amount"`
-			UserID  uint    `json:"user_id"`
+			OrderID string      `json:"order_id"`
+			Amount  money.Money `json:"amount"`
+			UserID  uint        `json:"user_id"` // 兼容旧参数，以登录用户为准
 		}
 
 		if err := ctx.BindJSON(&req); err != nil {
@@ -134,36 +153,323 @@ func registerRoutes(h *server.Hertz) {
 			return
 		}
 
-		// 生成支付记录
-		paymentID := uuid.New().String()
-		record := PaymentRecord{
-			OrderID:   req.OrderID,
-			PaymentID: paymentID,
-			Amount:    req.Amount,
-			Status:    "pending",
-			UserID:    req.UserID,
+		var count int64
+		if err := dal.DB.WithContext(c).Model(&dal.Order{}).
+			Where("order_no = ? AND user_id = ?", req.OrderID, ctx.GetUint("userID")).
+			Count(&count).Error; err != nil {
+			zap.L().Error("订单查询失败", zap.String("order_id", req.OrderID), zap.Error(err))
+			ctx.JSON(500, map[string]interface{}{"error": "支付记录创建失败"})
+			return
+		}
+		if count == 0 {
+			ctx.JSON(404, map[string]interface{}{"error": paymentService.ErrOrderNotFound.Error()})
+			return
 		}
 
-		if err := dal.DB.Create(&record).Error; err != nil {
-			ctx.JSON(500, map[string]interface{}{"error": "支付记录创建失败"})
+		record, err := paymentService.Create(c, req.OrderID, req.Amount)
+		if err != nil {
+			switch {
+			case errors.Is(err, paymentService.ErrOrderNotFound):
+				ctx.JSON(404, map[string]interface{}{"error": err.Error()})
+			case errors.Is(err, paymentService.ErrAmountMismatch):
+				ctx.JSON(400, map[string]interface{}{"error": err.Error()})
+			case errors.Is(err, paymentService.ErrOrderNotPayable):
+				ctx.JSON(409, map[string]interface{}{"error": err.Error()})
+			default:
+				zap.L().Error("支付记录创建失败",
+					zap.String("order_id", req.OrderID),
+					zap.Error(err))
+				ctx.JSON(500, map[string]interface{}{"error": "支付记录创建失败"})
+			}
 			return
 		}
 
-		// 返回模拟支付链接
+		payURL, err := paymentService.PayURL(c, record)
+		if err != nil {
+			zap.L().Error("支付渠道下单失败",
+				zap.String("order_id", req.OrderID),
+				zap.Error(err))
+			ctx.JSON(502, map[string]interface{}{"error": "支付渠道下单失败"})
+			return
+		}
 		ctx.JSON(200, map[string]interface{}{
-			"payment_url": fmt.Sprintf("http://localhost:8084/payment/confirm?payment_id=%s", paymentID),
+			"payment_id":  record.PaymentID,
+			"payment_url": payURL,
 		})
 	})
 }
 
+// registerRefundRoutes 退款：全额或部分退款、查询与重新提交
+// 发起与重新提交退款仅限管理员；退款记录管理员可查询所有订单，其他用户只能查询自己的订单。
+func registerRefundRoutes(h *server.Hertz) {
+	admin := h.Group("/payment", middleware.JWTAuth(), middleware.RequireRole(dal.RoleAdmin))
+	admin.POST("/refund", middleware.Idempotency("payment:refund", 24*time.Hour), func(c context.Context, ctx *app.RequestContext) {
+		var req struct {
+			OrderID string       `json:"order_id"`
+			Amount  *money.Money `json:"amount"` // 为空时全额退款
+			Reason  string       `json:"reason"`
+		}
+		if err := ctx.BindJSON(&req); err != nil || req.OrderID == "" {
+			ctx.JSON(400, map[string]interface{}{"error": "无效请求参数"})
+			return
+		}
+
+		refund, err := paymentService.Refund(c, req.OrderID, req.Amount, req.Reason)
+		respondRefund(ctx, refund, err)
+	})
+
+	admin.POST("/refunds/:refund_id/retry", func(c context.Context, ctx *app.RequestContext) {
+		refund, err := paymentService.RetryRefund(c, ctx.Param("refund_id"))
+		respondRefund(ctx, refund, err)
+	})
+
+	h.GET("/payment/refunds", middleware.JWTAuth(), func(c context.Context, ctx *app.RequestContext) {
+		orderID := ctx.Query("order_id")
+		if orderID == "" {
+			ctx.JSON(400, map[string]interface{}{"error": "缺少订单号"})
+			return
+		}
+		allowed, err := canViewOrder(c, ctx.GetUint("userID"), orderID)
+		if err != nil {
+			zap.L().Error("订单权限校验失败", zap.String("order_id", orderID), zap.Error(err))
+			ctx.JSON(500, map[string]interface{}{"error": "退款记录查询失败"})
+			return
+		}
+		if !allowed {
+			// 不区分订单不存在与无权查看，避免泄露他人订单号
+			ctx.JSON(404, map[string]interface{}{"error": paymentService.ErrOrderNotFound.Error()})
+			return
+		}
+		refunds, err := paymentService.ListRefunds(c, orderID)
+		if err != nil {
+			zap.L().Error("退款记录查询失败", zap.String("order_id", orderID), zap.Error(err))
+			ctx.JSON(500, map[string]interface{}{"error": "退款记录查询失败"})
+			return
+		}
+		views := make([]map[string]interface{}, 0, len(refunds))
+		for i := range refunds {
+			views = append(views, refundView(&refunds[i]))
+		}
+		ctx.JSON(200, map[string]interface{}{"refunds": views})
+	})
+}
+
+// canViewOrder 管理员可以查看所有订单，其他用户只能查看自己的订单
+func canViewOrder(c context.Context, userID uint, orderID string) (bool, error) {
+	var u dal.User
+	if err := dal.DB.WithContext(c).Select("role").First(&u, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if u.Role == dal.RoleAdmin {
+		return true, nil
+	}
+
+	var count int64
+	err := dal.DB.WithContext(c).Model(&dal.Order{}).
+		Where("order_no = ? AND user_id = ?", orderID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func respondRefund(ctx *app.RequestContext, refund *dal.RefundRecord, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, paymentService.ErrPaymentNotFound),
+			errors.Is(err, paymentService.ErrRefundNotFound):
+			ctx.JSON(404, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, paymentService.ErrRefundAmountInvalid):
+			ctx.JSON(400, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, paymentService.ErrRefundExceeded),
+			errors.Is(err, paymentService.ErrPaymentNotRefundable):
+			ctx.JSON(409, map[string]interface{}{"error": err.Error()})
+		case refund != nil:
+			// 已登记退款但渠道结果未知，返回退款单号以便重新提交
+			ctx.JSON(502, map[string]interface{}{
+				"error":     "支付渠道退款失败",
+				"refund_id": refund.RefundID,
+			})
+		default:
+			zap.L().Error("退款失败", zap.Error(err))
+			ctx.JSON(500, map[string]interface{}{"error": "退款失败"})
+		}
+		return
+	}
+	ctx.JSON(200, refundView(refund))
+}
+
+func refundView(refund *dal.RefundRecord) map[string]interface{} {
+	return map[string]interface{}{
+		"refund_id":  refund.RefundID,
+		"payment_id": refund.PaymentID,
+		"order_id":   refund.OrderID,
+		"amount":     refund.Amount,
+		"status":     refund.Status,
+		"reason":     refund.Reason,
+		"trade_no":   refund.TradeNo,
+		"error":      refund.LastError,
+		"created_at": refund.CreatedAt,
+	}
+}
+
+// processNotification 处理支付结果（异步通知与主动查询共用），返回失败时的HTTP状态码
+func processNotification(c context.Context, n *paymentService.Notification) (int, error) {
+	switch n.Status {
+	case paymentService.NotifySuccess:
+		return processPaymentSucceeded(c, n)
+	case paymentService.NotifyFailed:
+		if err := paymentService.MarkFailed(c, n); err != nil {
+			status := notificationErrorStatus(err)
+			if status == 500 {
+				zap.L().Error("支付失败状态更新失败",
+					zap.String("order_id", n.OrderNo),
+					zap.Error(err))
+			}
+			return status, err
+		}
+		return 200, nil
+	default:
+		return 400, fmt.Errorf("未知的支付结果: %s", n.Status)
+	}
+}
+
+// processPaymentSucceeded 支付成功：更新支付记录并把订单置为已支付，返回失败时的HTTP状态码
+// 非200响应会让支付渠道重试通知，两步都是幂等的。
+func processPaymentSucceeded(c context.Context, n *paymentService.Notification) (int, error) {
+	orderID := n.OrderNo
+	if _, err := paymentService.MarkSucceeded(c, dal.DB, n); err != nil {
+		status := notificationErrorStatus(err)
+		if status == 500 {
+			zap.L().Error("支付记录更新失败",
+				zap.String("order_id", orderID),
+				zap.Error(err))
+		}
+		return status, err
+	}
+	if err := UpdateOrderStatus(orderID, "paid"); err != nil {
+		if handled := handleStatusConflict(orderID, err); handled {
+			return 200, nil
+		}
+		zap.L().Error("订单状态更新失败",
+			zap.String("order_id", orderID),
+			zap.Error(err))
+		return 500, err
+	}
+	return 200, nil
+}
+
+// notificationErrorStatus 处理通知时的错误对应的HTTP状态码
+func notificationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, paymentService.ErrPaymentNotFound),
+		errors.Is(err, paymentService.ErrOrderNotFound):
+		return 404
+	case errors.Is(err, paymentService.ErrNotificationMismatch):
+		return 400
+	default:
+		return 500
+	}
+}
+
+// registerMockGatewayRoutes 模拟网关：支付页面与确认接口
+// result可选 success / fail / timeout，timeout 不发送异步通知。
+func registerMockGatewayRoutes(h *server.Hertz, mock *paymentService.MockProvider) {
+	h.GET("/payment/confirm", func(c context.Context, ctx *app.RequestContext) {
+		tx, err := mock.Transaction(c, ctx.Query("payment_id"))
+		if err != nil {
+			if errors.Is(err, paymentService.ErrMockTxNotFound) {
+				ctx.JSON(404, map[string]interface{}{"error": err.Error()})
+				return
+			}
+			ctx.JSON(500, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.Data(200, "text/html; charset=utf-8", []byte(fmt.Sprintf(mockConfirmPage,
+			html.EscapeString(tx.OrderNo), html.EscapeString(tx.Amount.String()), html.EscapeString(tx.Status),
+			url.QueryEscape(tx.PaymentID), url.QueryEscape(tx.PaymentID), url.QueryEscape(tx.PaymentID))))
+	})
+
+	h.POST("/payment/confirm", func(c context.Context, ctx *app.RequestContext) {
+		tx, err := mock.Confirm(c, string(ctx.FormValue("payment_id")), string(ctx.FormValue("result")))
+		if err != nil {
+			switch {
+			case errors.Is(err, paymentService.ErrMockTxNotFound):
+				ctx.JSON(404, map[string]interface{}{"error": err.Error()})
+			case errors.Is(err, paymentService.ErrMockInvalidOutcome):
+				ctx.JSON(400, map[string]interface{}{"error": err.Error()})
+			case errors.Is(err, paymentService.ErrMockTxClosed):
+				ctx.JSON(409, map[string]interface{}{"error": err.Error()})
+			default:
+				ctx.JSON(500, map[string]interface{}{"error": err.Error()})
+			}
+			return
+		}
+		ctx.JSON(200, tx)
+	})
+}
+
+const mockConfirmPage = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>模拟支付</title></head>
+<body>
+<h3>模拟支付网关</h3>
+<p>订单号：%s</p>
+<p>金额：%s</p>
+<p>状态：%s</p>
+<form method="post" action="/payment/confirm?payment_id=%s&result=success"><button>支付成功</button></form>
+<form method="post" action="/payment/confirm?payment_id=%s&result=fail"><button>支付失败</button></form>
+<form method="post" action="/payment/confirm?payment_id=%s&result=timeout"><button>支付超时（不发送通知）</button></form>
+</body>
+</html>`
+
+// handleStatusConflict 处理支付回调与订单取消并发的情况
+// 订单已不是未支付状态时RPC返回409：订单已支付说明是重复回调；
+// 订单已取消说明用户取消先于支付完成，支付记录标记为待退款，由待退款事件的消费者自动全额退款。
+// 返回true表示回调已处理完毕，无需支付网关重试。
+func handleStatusConflict(orderID string, err error) bool {
+	bizErr, ok := kerrors.FromBizStatusError(err)
+	if !ok || bizErr.BizStatusCode() != 409 {
+		return false
+	}
+
+	var o dal.Order
+	if err := dal.DB.Where("order_no = ?", orderID).First(&o).Error; err != nil {
+		return false
+	}
+
+	switch o.Status {
+	case dal.OrderStatusCanceled:
+		zap.L().Warn("订单已取消但支付成功，转为自动退款",
+			zap.String("order_id", orderID))
+		if err := paymentService.MarkRefundPending(context.Background(), dal.DB, orderID); err != nil {
+			zap.L().Error("支付记录状态更新失败",
+				zap.String("order_id", orderID),
+				zap.Error(err))
+			return false
+		}
+		return true
+	case dal.OrderStatusUnpaid:
+		return false // 乐观锁冲突，交给网关重试
+	default:
+		zap.L().Info("重复的支付回调",
+			zap.String("order_id", orderID),
+			zap.String("status", string(o.Status)))
+		return true
+	}
+}
+
 // UpdateOrderStatus 通过RPC更新订单状态
 func UpdateOrderStatus(orderID string, status string) error {
+	actor := "payment-service"
 	req := &order.UpdateReq{
 		OrderID: orderID,
 		Status:  status,
+		Actor:   &actor,
 	}
 
-	resp, err := orderClient.UpdateStatus(context.Background(), req)
+	resp, err := rpc.OrderClient.UpdateStatus(context.Background(), req)
 	if err != nil {
 		return fmt.Errorf("RPC调用失败: %w", err)
 	}
@@ -176,4 +482,4 @@ func UpdateOrderStatus(orderID string, status string) error {
 		zap.String("order_id", orderID),
 		zap.String("status", status))
 	return nil
-}
\ No newline at end of file
+}
@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 
@@ -11,7 +12,9 @@ import (
 	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/cloudwego/hertz/pkg/network/standard"
+	"github.com/cloudwego/kitex/pkg/kerrors"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/transmeta"
 	kitexServer "github.com/cloudwego/kitex/server"
 	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/product"
 	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/product/productservice"
@@ -19,7 +22,9 @@ import (
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/handlers"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/middleware"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/registry"
+	productService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/product"
 	"github.com/hashicorp/consul/api"
 	consul "github.com/kitex-contrib/registry-consul"
 	"go.uber.org/zap"
@@ -27,14 +32,189 @@ import (
 
 type ProductServiceImpl struct{}
 
+// 商品服务业务错误码
+const (
+	bizCodeInvalidParams     int32 = 400
+	bizCodeProductNotFound   int32 = 404
+	bizCodeStockInsufficient int32 = 409
+	bizCodeProductOffShelf   int32 = 410
+	bizCodeRequestConflict   int32 = 422 // 请求ID对应的扣减已归还或参数不一致
+	bizCodeInternal          int32 = 500
+)
+
 // DecreaseStock implements product.ProductService.
 func (p *ProductServiceImpl) DecreaseStock(ctx context.Context, req *product.DecreaseStockReq) (r bool, err error) {
-	panic("unimplemented")
+	err = productService.DecreaseStock(ctx, req.RequestId, uint(req.ProductId), uint(req.GetSkuId()), int(req.Quantity))
+	if err != nil {
+		return false, toBizError(err)
+	}
+	return true, nil
+}
+
+// ReleaseStock implements product.ProductService.
+func (p *ProductServiceImpl) ReleaseStock(ctx context.Context, req *product.ReleaseStockReq) (r bool, err error) {
+	if err := productService.ReleaseStock(ctx, req.RequestId, uint(req.ProductId)); err != nil {
+		return false, toBizError(err)
+	}
+	return true, nil
 }
 
 // GetProduct implements product.ProductService.
 func (p *ProductServiceImpl) GetProduct(ctx context.Context, req *product.GetProductReq) (r *product.ProductInfo, err error) {
-	panic("unimplemented")
+	detail, err := productService.GetDetail(ctx, uint(req.ProductId))
+	if err != nil {
+		return nil, toBizError(err)
+	}
+
+	r = toProductInfo(&detail.Product)
+	r.Skus = make([]*product.SkuInfo, 0, len(detail.SKUs))
+	for i := range detail.SKUs {
+		r.Skus = append(r.Skus, toSkuInfo(&detail.SKUs[i], &detail.Product))
+	}
+	return r, nil
+}
+
+// ListSkus implements product.ProductService.
+func (p *ProductServiceImpl) ListSkus(ctx context.Context, req *product.ListSkusReq) (r []*product.SkuInfo, err error) {
+	ids := make([]uint, 0, len(req.SkuIds))
+	for _, id := range req.SkuIds {
+		ids = append(ids, uint(id))
+	}
+	skus, err := productService.GetSKUs(ctx, ids)
+	if err != nil {
+		return nil, toBizError(err)
+	}
+
+	productIDs := make([]uint, 0, len(skus))
+	for i := range skus {
+		productIDs = append(productIDs, skus[i].ProductID)
+	}
+	products, err := productService.ListByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, toBizError(err)
+	}
+	byID := make(map[uint]*dal.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	r = make([]*product.SkuInfo, 0, len(skus))
+	for i := range skus {
+		// 商品已删除的SKU视为不存在
+		if owner, ok := byID[skus[i].ProductID]; ok {
+			r = append(r, toSkuInfo(&skus[i], owner))
+		}
+	}
+	return r, nil
+}
+
+// ListProducts implements product.ProductService.
+func (p *ProductServiceImpl) ListProducts(ctx context.Context, req *product.ListProductsReq) (r []*product.ProductInfo, err error) {
+	ids := make([]uint, 0, len(req.ProductIds))
+	for _, id := range req.ProductIds {
+		ids = append(ids, uint(id))
+	}
+	products, err := productService.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, toBizError(err)
+	}
+
+	r = make([]*product.ProductInfo, 0, len(products))
+	for i := range products {
+		r = append(r, toProductInfo(&products[i]))
+	}
+	return r, nil
+}
+
+// SearchProducts implements product.ProductService.
+func (p *ProductServiceImpl) SearchProducts(ctx context.Context, req *product.SearchProductsReq) (r *product.SearchProductsResp, err error) {
+	attributes, err := productService.ParseAttributeFilters(req.GetAttributes())
+	if err != nil {
+		return nil, toBizError(err)
+	}
+	result, err := productService.Search(ctx, productService.SearchQuery{
+		Keyword:         req.GetKeyword(),
+		MinPriceCents:   req.MinPriceCents,
+		MaxPriceCents:   req.MaxPriceCents,
+		IncludeOffShelf: req.GetIncludeOffShelf(),
+		CategoryID:      uint(req.GetCategoryId()),
+		Attributes:      attributes,
+		Sort:            req.GetSort(),
+		Cursor:          req.GetCursor(),
+		Limit:           int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, toBizError(err)
+	}
+
+	r = &product.SearchProductsResp{Products: make([]*product.ProductInfo, 0, len(result.Products))}
+	for i := range result.Products {
+		r.Products = append(r.Products, toProductInfo(&result.Products[i]))
+	}
+	if result.NextCursor != "" {
+		r.NextCursor = &result.NextCursor
+	}
+	return r, nil
+}
+
+func toProductInfo(p *dal.Product) *product.ProductInfo {
+	sales := int32(p.Sales)
+	categoryID := int64(p.CategoryID)
+	return &product.ProductInfo{
+		Id:          int64(p.ID),
+		Name:        p.Name,
+		Price:       p.Price.Yuan(),
+		PriceMoney:  p.Price.ToIDL(),
+		Stock:       int32(p.Stock),
+		Status:      int32(p.Status),
+		Description: &p.Description,
+		Sales:       &sales,
+		CategoryId:  &categoryID,
+	}
+}
+
+// toSkuInfo SKU与商品都上架时才可售
+func toSkuInfo(s *dal.SKU, p *dal.Product) *product.SkuInfo {
+	status := int32(dal.ProductStatusOffShelf)
+	if s.Status == dal.ProductStatusOnShelf && p.Status == dal.ProductStatusOnShelf {
+		status = int32(dal.ProductStatusOnShelf)
+	}
+	info := &product.SkuInfo{
+		Id:          int64(s.ID),
+		ProductId:   int64(s.ProductID),
+		ProductName: p.Name,
+		Specs:       productService.SKUSpecs(s),
+		Price:       s.Price.ToIDL(),
+		Stock:       int32(s.Stock),
+		Status:      status,
+	}
+	if s.Barcode != "" {
+		info.Barcode = &s.Barcode
+	}
+	return info
+}
+
+// 领域错误转换为Kitex业务错误
+func toBizError(err error) error {
+	switch {
+	case errors.Is(err, productService.ErrInvalidParams),
+		errors.Is(err, productService.ErrInvalidCursor),
+		errors.Is(err, productService.ErrInvalidSort):
+		return kerrors.NewBizStatusError(bizCodeInvalidParams, err.Error())
+	case errors.Is(err, productService.ErrProductNotFound),
+		errors.Is(err, productService.ErrCategoryNotFound):
+		return kerrors.NewBizStatusError(bizCodeProductNotFound, err.Error())
+	case errors.Is(err, productService.ErrStockInsufficient):
+		return kerrors.NewBizStatusError(bizCodeStockInsufficient, err.Error())
+	case errors.Is(err, productService.ErrProductOffShelf):
+		return kerrors.NewBizStatusError(bizCodeProductOffShelf, err.Error())
+	case errors.Is(err, productService.ErrStockReleased),
+		errors.Is(err, productService.ErrRequestConflict):
+		return kerrors.NewBizStatusError(bizCodeRequestConflict, err.Error())
+	}
+
+	zap.L().Error("商品服务内部错误", zap.Error(err))
+	return kerrors.NewBizStatusError(bizCodeInternal, "系统内部错误")
 }
 
 func main() {
@@ -67,6 +247,15 @@ func main() {
 	if err != nil {
 		panic("Consul注册失败: " + err.Error())
 	}
+	// 商品详情缓存
+	if err := redis.InitRedis(); err != nil {
+		panic("Redis初始化失败: " + err.Error())
+	}
+	dal.InitDB() // 使用独立数据库配置，RPC服务依赖数据库，必须在其启动前完成
+	if err := productService.InitSearch(context.Background()); err != nil {
+		panic(err)
+	}
+
 	// 启动RPC服务端
 	go func() {
 		// Kitex RPC服务配置
@@ -74,6 +263,7 @@ func main() {
 		svr := productservice.NewServer(
 			new(ProductServiceImpl),
 			kitexServer.WithRegistry(consulRegister),
+			kitexServer.WithMetaHandler(transmeta.ServerTTHeaderHandler),
 			kitexServer.WithServerBasicInfo(&rpcinfo.EndpointBasicInfo{
 				ServiceName: "product.service",
 				Tags: map[string]string{
@@ -83,7 +273,7 @@ func main() {
 			}),
 			kitexServer.WithServiceAddr(&net.TCPAddr{
 				IP:   net.ParseIP(config.Conf.Service.IP),
-				Port: config.Conf.Service.PaymentRpcPort,
+				Port: config.Conf.Service.ProductRpcPort,
 			}),
 		)
 
@@ -93,8 +283,6 @@ func main() {
 		}
 	}()
 
-	dal.InitDB() // 使用独立数据库配置
-
 	h := server.Default(
 		server.WithHostPorts(":8081"),                 // 不同端口
 		server.WithTransport(standard.NewTransporter), // 使用标准网络库
@@ -106,8 +294,32 @@ func main() {
 	}
 
 	// 商品服务路由
+	h.GET("/products", handlers.SearchProducts)
 	h.GET("/products/:id", handlers.GetProduct)
-	h.POST("/products", handlers.CreateProduct)
+	h.GET("/products/:id/attributes", handlers.GetProductAttributes)
+	h.GET("/products/:id/skus", handlers.ListProductSKUs)
+	h.GET("/categories", handlers.ListCategories)
+	h.GET("/categories/:id", handlers.GetCategory)
+
+	// 商品管理：仅商家与管理员
+	manage := h.Group("/products", middleware.JWTAuth(), middleware.RequireRole(dal.RoleMerchant, dal.RoleAdmin))
+	manage.POST("", handlers.CreateProduct)
+	manage.PATCH("/:id", handlers.UpdateProduct)
+	manage.DELETE("/:id", handlers.DeleteProduct)
+	manage.GET("/:id/audits", handlers.ListProductAudits)
+	manage.PUT("/:id/category", handlers.AssignProductCategory)
+	manage.POST("/:id/skus", handlers.CreateSKU)
+
+	skus := h.Group("/skus", middleware.JWTAuth(), middleware.RequireRole(dal.RoleMerchant, dal.RoleAdmin))
+	skus.PATCH("/:id", handlers.UpdateSKU)
+	skus.DELETE("/:id", handlers.DeleteSKU)
+
+	// 分类与属性定义管理：仅管理员
+	categories := h.Group("/categories", middleware.JWTAuth(), middleware.RequireRole(dal.RoleAdmin))
+	categories.POST("", handlers.CreateCategory)
+	categories.PATCH("/:id", handlers.UpdateCategory)
+	categories.DELETE("/:id", handlers.DeleteCategory)
+	categories.POST("/:id/attributes", handlers.DefineCategoryAttribute)
 
 	// 健康检查
 	h.GET("/health", func(c context.Context, ctx *app.RequestContext) {
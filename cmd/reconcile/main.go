@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	paymentService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/payment"
+	reconcileService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/reconcile"
+)
+
+// 支付对账：每日由定时任务执行，核对前一天的支付记录
+//
+//	reconcile -date 2024-05-01 -file settlement.csv -repair -out report.csv
+//
+// 未指定结算文件时逐笔查询支付渠道。存在未修复的差异时以状态码2退出，便于告警。
+func main() {
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	date := flag.String("date", yesterday, "对账日期（YYYY-MM-DD，按支付记录创建时间）")
+	file := flag.String("file", "", "渠道结算文件（CSV），为空时查询支付渠道")
+	repair := flag.Bool("repair", false, "自动修复安全的差异")
+	out := flag.String("out", "", "差异明细输出文件（CSV），为空时输出到标准输出")
+	flag.Parse()
+
+	from, err := time.ParseInLocation("2006-01-02", *date, time.Local)
+	if err != nil {
+		fail("无效的对账日期: %v", err)
+	}
+	to := from.AddDate(0, 0, 1)
+
+	if err := config.Init(); err != nil {
+		fail("配置加载失败: %v", err)
+	}
+	dal.InitDB()
+
+	ctx := context.Background()
+	var entries []reconcileService.Entry
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fail("结算文件打开失败: %v", err)
+		}
+		entries, err = reconcileService.ParseStatement(f)
+		f.Close()
+		if err != nil {
+			fail("%v", err)
+		}
+	} else {
+		if err := redis.InitRedis(); err != nil {
+			fail("Redis初始化失败: %v", err)
+		}
+		if err := paymentService.InitProvider(); err != nil {
+			fail("%v", err)
+		}
+		if entries, err = reconcileService.FetchFromProvider(ctx, from, to); err != nil {
+			fail("%v", err)
+		}
+	}
+
+	report, err := reconcileService.Reconcile(ctx, entries, reconcileService.Options{
+		From:   from,
+		To:     to,
+		Repair: *repair,
+	})
+	if err != nil {
+		fail("对账失败: %v", err)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		if w, err = os.Create(*out); err != nil {
+			fail("输出文件创建失败: %v", err)
+		}
+	}
+	if err := report.WriteCSV(w); err != nil {
+		fail("差异明细输出失败: %v", err)
+	}
+	if *out != "" {
+		w.Close()
+	}
+
+	fmt.Fprintf(os.Stderr, "对账日期 %s：核对 %d 笔，差异 %d 笔，未修复 %d 笔\n",
+		*date, report.Checked, len(report.Mismatches), report.Unresolved())
+	if report.Unresolved() > 0 {
+		os.Exit(2)
+	}
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"time"
@@ -11,7 +12,9 @@ import (
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/kitex/pkg/kerrors"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/transmeta"
 	kitexServer "github.com/cloudwego/kitex/server"
 
 	// "github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/user"
@@ -22,6 +25,7 @@ import (
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/handlers"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/middleware"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/registry"
+	userService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/user"
 	"github.com/hashicorp/consul/api"
 	consul "github.com/kitex-contrib/registry-consul"
 	"go.uber.org/zap"
@@ -29,24 +33,81 @@ import (
 
 type UserServiceImpl struct{}
 
+// 用户服务业务错误码
+const (
+	bizCodeInvalidParams int32 = 400
+	bizCodeWrongPassword int32 = 401
+	bizCodeUserNotFound  int32 = 404
+	bizCodeUserExists    int32 = 409
+	bizCodeInternal      int32 = 500
+)
+
 // CheckToken implements user.UserService.
 func (s *UserServiceImpl) CheckToken(ctx context.Context, token string) (r bool, err error) {
-	panic("unimplemented")
+	if _, err := userService.CheckToken(ctx, token); err != nil {
+		// 令牌无效不视为调用失败，只有系统错误才返回error
+		if errors.Is(err, middleware.ErrTokenInvalid) ||
+			errors.Is(err, middleware.ErrTokenClaims) ||
+			errors.Is(err, userService.ErrUserNotFound) {
+			return false, nil
+		}
+		return false, toBizError(err)
+	}
+	return true, nil
 }
 
 // GetUserInfo implements user.UserService.
 func (s *UserServiceImpl) GetUserInfo(ctx context.Context, userId int64) (r *user.UserInfo, err error) {
-	panic("unimplemented")
+	u, err := userService.GetUser(ctx, uint(userId))
+	if err != nil {
+		return nil, toBizError(err)
+	}
+
+	return &user.UserInfo{
+		UserId:    int64(u.ID),
+		Username:  u.Username,
+		CreatedAt: u.CreatedAt.Format(time.RFC3339),
+	}, nil
 }
 
 // Login implements user.UserService.
 func (s *UserServiceImpl) Login(ctx context.Context, req *user.LoginRequest) (r string, err error) {
-	panic("unimplemented")
+	u, err := userService.Login(ctx, req.Username, req.Password)
+	if err != nil {
+		return "", toBizError(err)
+	}
+
+	token, err := middleware.GenerateToken(u.ID)
+	if err != nil {
+		return "", toBizError(err)
+	}
+	return token, nil
 }
 
 // RegisterUser implements user.UserService.
 func (s *UserServiceImpl) RegisterUser(ctx context.Context, req *user.RegisterRequest) (r int64, err error) {
-	panic("unimplemented")
+	u, err := userService.Register(ctx, req.Username, req.Password)
+	if err != nil {
+		return 0, toBizError(err)
+	}
+	return int64(u.ID), nil
+}
+
+// 领域错误转换为Kitex业务错误
+func toBizError(err error) error {
+	switch {
+	case errors.Is(err, userService.ErrInvalidParams):
+		return kerrors.NewBizStatusError(bizCodeInvalidParams, err.Error())
+	case errors.Is(err, userService.ErrWrongPassword):
+		return kerrors.NewBizStatusError(bizCodeWrongPassword, err.Error())
+	case errors.Is(err, userService.ErrUserNotFound):
+		return kerrors.NewBizStatusError(bizCodeUserNotFound, err.Error())
+	case errors.Is(err, userService.ErrUserExists):
+		return kerrors.NewBizStatusError(bizCodeUserExists, err.Error())
+	}
+
+	zap.L().Error("用户服务内部错误", zap.Error(err))
+	return kerrors.NewBizStatusError(bizCodeInternal, "系统内部错误")
 }
 
 func main() {
@@ -84,6 +145,9 @@ func main() {
 	if err != nil {
 		panic("Consul注册失败: " + err.Error())
 	}
+	// 初始化数据库连接（RPC服务依赖数据库，必须在其启动前完成）
+	dal.InitDB()
+
 	// 启动RPC服务端
 	go func() {
 		// Kitex RPC服务配置
@@ -91,6 +155,7 @@ func main() {
 		svr := userservice.NewServer(
 			new(UserServiceImpl),
 			kitexServer.WithRegistry(consulRegister),
+			kitexServer.WithMetaHandler(transmeta.ServerTTHeaderHandler),
 			kitexServer.WithServerBasicInfo(&rpcinfo.EndpointBasicInfo{
 				ServiceName: "user.service",
 				Tags: map[string]string{
@@ -110,9 +175,6 @@ func main() {
 		}
 	}()
 
-	// 初始化数据库连接
-	dal.InitDB()
-
 	// 初始化Hertz（必须显式指定端口,端口8080）
 	h := server.Default( //创建sever default实例
 		server.WithHostPorts(":8080"),
@@ -158,15 +220,3 @@ func main() {
 	hlog.Info("=== 启动服务监听 ===")
 	h.Spin()
 }
-
-// func (s *UserServiceImpl) CheckToken(ctx context.Context, token string) (bool, error) {
-// 	// 调用现有中间件验证逻辑
-// 	valid, err := middleware.ValidateToken(token)
-// 	if err != nil {
-// 		zap.L().Error("令牌验证失败",
-// 			zap.String("token", token),
-// 			zap.Error(err))
-// 		return false, err
-// 	}
-// 	return valid, nil
-// }
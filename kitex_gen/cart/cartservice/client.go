@@ -0,0 +1,61 @@
+// Code generated by Kitex v0.12.3. DO NOT EDIT.
+
+package cartservice
+
+import (
+	"context"
+	client "github.com/cloudwego/kitex/client"
+	callopt "github.com/cloudwego/kitex/client/callopt"
+	cart "github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/cart"
+)
+
+// Client is designed to provide IDL-compatible methods with call-option parameter for kitex framework.
+type Client interface {
+	AddItem(ctx context.Context, request *cart.CartItem, callOptions ...callopt.Option) (r *cart.CartResponse, err error)
+	RemoveItem(ctx context.Context, request *cart.CartItem, callOptions ...callopt.Option) (r *cart.CartResponse, err error)
+	GetCart(ctx context.Context, request *cart.CartRequest, callOptions ...callopt.Option) (r *cart.CartResponse, err error)
+}
+
+// NewClient creates a client for the service defined in IDL.
+func NewClient(destService string, opts ...client.Option) (Client, error) {
+	var options []client.Option
+	options = append(options, client.WithDestService(destService))
+
+	options = append(options, opts...)
+
+	kc, err := client.NewClient(serviceInfoForClient(), options...)
+	if err != nil {
+		return nil, err
+	}
+	return &kCartServiceClient{
+		kClient: newServiceClient(kc),
+	}, nil
+}
+
+// MustNewClient creates a client for the service defined in IDL. It panics if any error occurs.
+func MustNewClient(destService string, opts ...client.Option) Client {
+	kc, err := NewClient(destService, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return kc
+}
+
+type kCartServiceClient struct {
+	*kClient
+}
+
+func (p *kCartServiceClient) AddItem(ctx context.Context, request *cart.CartItem, callOptions ...callopt.Option) (r *cart.CartResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.AddItem(ctx, request)
+}
+
+func (p *kCartServiceClient) RemoveItem(ctx context.Context, request *cart.CartItem, callOptions ...callopt.Option) (r *cart.CartResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.RemoveItem(ctx, request)
+}
+
+func (p *kCartServiceClient) GetCart(ctx context.Context, request *cart.CartRequest, callOptions ...callopt.Option) (r *cart.CartResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetCart(ctx, request)
+}
@@ -10,8 +10,10 @@ import (
 )
 
 type UpdateReq struct {
-	OrderID string `thrift:"orderID,1" frugal:"1,default,string" json:"orderID"`
-	Status  string `thrift:"status,2" frugal:"2,default,string" json:"status"`
+	OrderID string  `thrift:"orderID,1" frugal:"1,default,string" json:"orderID"`
+	Status  string  `thrift:"status,2" frugal:"2,default,string" json:"status"`
+	Actor   *string `thrift:"actor,3,optional" frugal:"3,optional,string" json:"actor,omitempty"`
+	Reason  *string `thrift:"reason,4,optional" frugal:"4,optional,string" json:"reason,omitempty"`
 }
 
 func NewUpdateReq() *UpdateReq {
@@ -28,16 +30,50 @@ func (p *UpdateReq) GetOrderID() (v string) {
 func (p *UpdateReq) GetStatus() (v string) {
 	return p.Status
 }
+
+var UpdateReq_Actor_DEFAULT string
+
+func (p *UpdateReq) GetActor() (v string) {
+	if !p.IsSetActor() {
+		return UpdateReq_Actor_DEFAULT
+	}
+	return *p.Actor
+}
+
+var UpdateReq_Reason_DEFAULT string
+
+func (p *UpdateReq) GetReason() (v string) {
+	if !p.IsSetReason() {
+		return UpdateReq_Reason_DEFAULT
+	}
+	return *p.Reason
+}
 func (p *UpdateReq) SetOrderID(val string) {
 	p.OrderID = val
 }
 func (p *UpdateReq) SetStatus(val string) {
 	p.Status = val
 }
+func (p *UpdateReq) SetActor(val *string) {
+	p.Actor = val
+}
+func (p *UpdateReq) SetReason(val *string) {
+	p.Reason = val
+}
 
 var fieldIDToName_UpdateReq = map[int16]string{
 	1: "orderID",
 	2: "status",
+	3: "actor",
+	4: "reason",
+}
+
+func (p *UpdateReq) IsSetActor() bool {
+	return p.Actor != nil
+}
+
+func (p *UpdateReq) IsSetReason() bool {
+	return p.Reason != nil
 }
 
 func (p *UpdateReq) Read(iprot thrift.TProtocol) (err error) {
@@ -75,6 +111,22 @@ func (p *UpdateReq) Read(iprot thrift.TProtocol) (err error) {
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
+		case 3:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField3(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 4:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField4(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
 		default:
 			if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
@@ -126,6 +178,28 @@ func (p *UpdateReq) ReadField2(iprot thrift.TProtocol) error {
 	p.Status = _field
 	return nil
 }
+func (p *UpdateReq) ReadField3(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Actor = _field
+	return nil
+}
+func (p *UpdateReq) ReadField4(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Reason = _field
+	return nil
+}
 
 func (p *UpdateReq) Write(oprot thrift.TProtocol) (err error) {
 
@@ -142,6 +216,14 @@ func (p *UpdateReq) Write(oprot thrift.TProtocol) (err error) {
 			fieldId = 2
 			goto WriteFieldError
 		}
+		if err = p.writeField3(oprot); err != nil {
+			fieldId = 3
+			goto WriteFieldError
+		}
+		if err = p.writeField4(oprot); err != nil {
+			fieldId = 4
+			goto WriteFieldError
+		}
 	}
 	if err = oprot.WriteFieldStop(); err != nil {
 		goto WriteFieldStopError
@@ -194,6 +276,44 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
 }
 
+func (p *UpdateReq) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetActor() {
+		if err = oprot.WriteFieldBegin("actor", thrift.STRING, 3); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Actor); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
+}
+
+func (p *UpdateReq) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetReason() {
+		if err = oprot.WriteFieldBegin("reason", thrift.STRING, 4); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Reason); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
+}
+
 func (p *UpdateReq) String() string {
 	if p == nil {
 		return "<nil>"
@@ -214,6 +334,12 @@ func (p *UpdateReq) DeepEqual(ano *UpdateReq) bool {
 	if !p.Field2DeepEqual(ano.Status) {
 		return false
 	}
+	if !p.Field3DeepEqual(ano.Actor) {
+		return false
+	}
+	if !p.Field4DeepEqual(ano.Reason) {
+		return false
+	}
 	return true
 }
 
@@ -231,11 +357,310 @@ func (p *UpdateReq) Field2DeepEqual(src string) bool {
 	}
 	return true
 }
+func (p *UpdateReq) Field3DeepEqual(src *string) bool {
+
+	if p.Actor == src {
+		return true
+	} else if p.Actor == nil || src == nil {
+		return false
+	}
+	if strings.Compare(*p.Actor, *src) != 0 {
+		return false
+	}
+	return true
+}
+func (p *UpdateReq) Field4DeepEqual(src *string) bool {
+
+	if p.Reason == src {
+		return true
+	} else if p.Reason == nil || src == nil {
+		return false
+	}
+	if strings.Compare(*p.Reason, *src) != 0 {
+		return false
+	}
+	return true
+}
 
 type OrderService interface {
+	HealthCheck(ctx context.Context) (r bool, err error)
+
 	UpdateStatus(ctx context.Context, req *UpdateReq) (r bool, err error)
 }
 
+type OrderServiceHealthCheckArgs struct {
+}
+
+func NewOrderServiceHealthCheckArgs() *OrderServiceHealthCheckArgs {
+	return &OrderServiceHealthCheckArgs{}
+}
+
+func (p *OrderServiceHealthCheckArgs) InitDefault() {
+}
+
+var fieldIDToName_OrderServiceHealthCheckArgs = map[int16]string{}
+
+func (p *OrderServiceHealthCheckArgs) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err = iprot.Skip(fieldTypeId); err != nil {
+			goto SkipFieldTypeError
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+SkipFieldTypeError:
+	return thrift.PrependError(fmt.Sprintf("%T skip field type %d error", p, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *OrderServiceHealthCheckArgs) Write(oprot thrift.TProtocol) (err error) {
+
+	if err = oprot.WriteStructBegin("HealthCheck_args"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *OrderServiceHealthCheckArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OrderServiceHealthCheckArgs(%+v)", *p)
+
+}
+
+func (p *OrderServiceHealthCheckArgs) DeepEqual(ano *OrderServiceHealthCheckArgs) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	return true
+}
+
+type OrderServiceHealthCheckResult struct {
+	Success *bool `thrift:"success,0,optional" frugal:"0,optional,bool" json:"success,omitempty"`
+}
+
+func NewOrderServiceHealthCheckResult() *OrderServiceHealthCheckResult {
+	return &OrderServiceHealthCheckResult{}
+}
+
+func (p *OrderServiceHealthCheckResult) InitDefault() {
+}
+
+var OrderServiceHealthCheckResult_Success_DEFAULT bool
+
+func (p *OrderServiceHealthCheckResult) GetSuccess() (v bool) {
+	if !p.IsSetSuccess() {
+		return OrderServiceHealthCheckResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+func (p *OrderServiceHealthCheckResult) SetSuccess(x interface{}) {
+	p.Success = x.(*bool)
+}
+
+var fieldIDToName_OrderServiceHealthCheckResult = map[int16]string{
+	0: "success",
+}
+
+func (p *OrderServiceHealthCheckResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *OrderServiceHealthCheckResult) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.BOOL {
+				if err = p.ReadField0(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_OrderServiceHealthCheckResult[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *OrderServiceHealthCheckResult) ReadField0(iprot thrift.TProtocol) error {
+
+	var _field *bool
+	if v, err := iprot.ReadBool(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Success = _field
+	return nil
+}
+
+func (p *OrderServiceHealthCheckResult) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("HealthCheck_result"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField0(oprot); err != nil {
+			fieldId = 0
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *OrderServiceHealthCheckResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err = oprot.WriteFieldBegin("success", thrift.BOOL, 0); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteBool(*p.Success); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 end error: ", p), err)
+}
+
+func (p *OrderServiceHealthCheckResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OrderServiceHealthCheckResult(%+v)", *p)
+
+}
+
+func (p *OrderServiceHealthCheckResult) DeepEqual(ano *OrderServiceHealthCheckResult) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field0DeepEqual(ano.Success) {
+		return false
+	}
+	return true
+}
+
+func (p *OrderServiceHealthCheckResult) Field0DeepEqual(src *bool) bool {
+
+	if p.Success == src {
+		return true
+	} else if p.Success == nil || src == nil {
+		return false
+	}
+	if *p.Success != *src {
+		return false
+	}
+	return true
+}
+
 type OrderServiceUpdateStatusArgs struct {
 	Req *UpdateReq `thrift:"req,1" frugal:"1,default,UpdateReq" json:"req"`
 }
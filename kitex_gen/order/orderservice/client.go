@@ -11,6 +11,7 @@ import (
 
 // Client is designed to provide IDL-compatible methods with call-option parameter for kitex framework.
 type Client interface {
+	HealthCheck(ctx context.Context, callOptions ...callopt.Option) (r bool, err error)
 	UpdateStatus(ctx context.Context, req *order.UpdateReq, callOptions ...callopt.Option) (r bool, err error)
 }
 
@@ -43,6 +44,11 @@ type kOrderServiceClient struct {
 	*kClient
 }
 
+func (p *kOrderServiceClient) HealthCheck(ctx context.Context, callOptions ...callopt.Option) (r bool, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.HealthCheck(ctx)
+}
+
 func (p *kOrderServiceClient) UpdateStatus(ctx context.Context, req *order.UpdateReq, callOptions ...callopt.Option) (r bool, err error) {
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.UpdateStatus(ctx, req)
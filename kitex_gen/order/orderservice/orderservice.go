@@ -13,6 +13,13 @@ import (
 var errInvalidMessageType = errors.New("invalid message type for service method handler")
 
 var serviceMethods = map[string]kitex.MethodInfo{
+	"HealthCheck": kitex.NewMethodInfo(
+		healthCheckHandler,
+		newOrderServiceHealthCheckArgs,
+		newOrderServiceHealthCheckResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 	"UpdateStatus": kitex.NewMethodInfo(
 		updateStatusHandler,
 		newOrderServiceUpdateStatusArgs,
@@ -86,6 +93,24 @@ func newServiceInfo(hasStreaming bool, keepStreamingMethods bool, keepNonStreami
 	return svcInfo
 }
 
+func healthCheckHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	_ = arg.(*order.OrderServiceHealthCheckArgs)
+	realResult := result.(*order.OrderServiceHealthCheckResult)
+	success, err := handler.(order.OrderService).HealthCheck(ctx)
+	if err != nil {
+		return err
+	}
+	realResult.Success = &success
+	return nil
+}
+func newOrderServiceHealthCheckArgs() interface{} {
+	return order.NewOrderServiceHealthCheckArgs()
+}
+
+func newOrderServiceHealthCheckResult() interface{} {
+	return order.NewOrderServiceHealthCheckResult()
+}
+
 func updateStatusHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
 	realArg := arg.(*order.OrderServiceUpdateStatusArgs)
 	realResult := result.(*order.OrderServiceUpdateStatusResult)
@@ -114,6 +139,15 @@ func newServiceClient(c client.Client) *kClient {
 	}
 }
 
+func (p *kClient) HealthCheck(ctx context.Context) (r bool, err error) {
+	var _args order.OrderServiceHealthCheckArgs
+	var _result order.OrderServiceHealthCheckResult
+	if err = p.c.Call(ctx, "HealthCheck", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
 func (p *kClient) UpdateStatus(ctx context.Context, req *order.UpdateReq) (r bool, err error) {
 	var _args order.OrderServiceUpdateStatusArgs
 	_args.Req = req
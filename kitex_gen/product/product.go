@@ -6,64 +6,121 @@ import (
 	"context"
 	"fmt"
 	thrift "github.com/cloudwego/kitex/pkg/protocol/bthrift/apache"
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/base"
 	"strings"
 )
 
-type ProductInfo struct {
-	Id    int64   `thrift:"id,1,required" frugal:"1,required,i64" json:"id"`
-	Name  string  `thrift:"name,2,required" frugal:"2,required,string" json:"name"`
-	Price float64 `thrift:"price,3,required" frugal:"3,required,double" json:"price"`
-	Stock int32   `thrift:"stock,4,required" frugal:"4,required,i32" json:"stock"`
+type SkuInfo struct {
+	Id          int64             `thrift:"id,1,required" frugal:"1,required,i64" json:"id"`
+	ProductId   int64             `thrift:"product_id,2,required" frugal:"2,required,i64" json:"product_id"`
+	ProductName string            `thrift:"product_name,3,required" frugal:"3,required,string" json:"product_name"`
+	Specs       map[string]string `thrift:"specs,4,required" frugal:"4,required,map<string:string>" json:"specs"`
+	Price       *base.Money       `thrift:"price,5,required" frugal:"5,required,base.Money" json:"price"`
+	Stock       int32             `thrift:"stock,6,required" frugal:"6,required,i32" json:"stock"`
+	Status      int32             `thrift:"status,7" frugal:"7,default,i32" json:"status"`
+	Barcode     *string           `thrift:"barcode,8,optional" frugal:"8,optional,string" json:"barcode,omitempty"`
 }
 
-func NewProductInfo() *ProductInfo {
-	return &ProductInfo{}
+func NewSkuInfo() *SkuInfo {
+	return &SkuInfo{}
 }
 
-func (p *ProductInfo) InitDefault() {
+func (p *SkuInfo) InitDefault() {
 }
 
-func (p *ProductInfo) GetId() (v int64) {
+func (p *SkuInfo) GetId() (v int64) {
 	return p.Id
 }
 
-func (p *ProductInfo) GetName() (v string) {
-	return p.Name
+func (p *SkuInfo) GetProductId() (v int64) {
+	return p.ProductId
 }
 
-func (p *ProductInfo) GetPrice() (v float64) {
+func (p *SkuInfo) GetProductName() (v string) {
+	return p.ProductName
+}
+
+func (p *SkuInfo) GetSpecs() (v map[string]string) {
+	return p.Specs
+}
+
+var SkuInfo_Price_DEFAULT *base.Money
+
+func (p *SkuInfo) GetPrice() (v *base.Money) {
+	if !p.IsSetPrice() {
+		return SkuInfo_Price_DEFAULT
+	}
 	return p.Price
 }
 
-func (p *ProductInfo) GetStock() (v int32) {
+func (p *SkuInfo) GetStock() (v int32) {
 	return p.Stock
 }
-func (p *ProductInfo) SetId(val int64) {
+
+func (p *SkuInfo) GetStatus() (v int32) {
+	return p.Status
+}
+
+var SkuInfo_Barcode_DEFAULT string
+
+func (p *SkuInfo) GetBarcode() (v string) {
+	if !p.IsSetBarcode() {
+		return SkuInfo_Barcode_DEFAULT
+	}
+	return *p.Barcode
+}
+func (p *SkuInfo) SetId(val int64) {
 	p.Id = val
 }
-func (p *ProductInfo) SetName(val string) {
-	p.Name = val
+func (p *SkuInfo) SetProductId(val int64) {
+	p.ProductId = val
 }
-func (p *ProductInfo) SetPrice(val float64) {
+func (p *SkuInfo) SetProductName(val string) {
+	p.ProductName = val
+}
+func (p *SkuInfo) SetSpecs(val map[string]string) {
+	p.Specs = val
+}
+func (p *SkuInfo) SetPrice(val *base.Money) {
 	p.Price = val
 }
-func (p *ProductInfo) SetStock(val int32) {
+func (p *SkuInfo) SetStock(val int32) {
 	p.Stock = val
 }
+func (p *SkuInfo) SetStatus(val int32) {
+	p.Status = val
+}
+func (p *SkuInfo) SetBarcode(val *string) {
+	p.Barcode = val
+}
 
-var fieldIDToName_ProductInfo = map[int16]string{
+var fieldIDToName_SkuInfo = map[int16]string{
 	1: "id",
-	2: "name",
-	3: "price",
-	4: "stock",
+	2: "product_id",
+	3: "product_name",
+	4: "specs",
+	5: "price",
+	6: "stock",
+	7: "status",
+	8: "barcode",
 }
 
-func (p *ProductInfo) Read(iprot thrift.TProtocol) (err error) {
+func (p *SkuInfo) IsSetPrice() bool {
+	return p.Price != nil
+}
+
+func (p *SkuInfo) IsSetBarcode() bool {
+	return p.Barcode != nil
+}
+
+func (p *SkuInfo) Read(iprot thrift.TProtocol) (err error) {
 
 	var fieldTypeId thrift.TType
 	var fieldId int16
 	var issetId bool = false
-	var issetName bool = false
+	var issetProductId bool = false
+	var issetProductName bool = false
+	var issetSpecs bool = false
 	var issetPrice bool = false
 	var issetStock bool = false
 
@@ -91,32 +148,66 @@ func (p *ProductInfo) Read(iprot thrift.TProtocol) (err error) {
 				goto SkipFieldError
 			}
 		case 2:
-			if fieldTypeId == thrift.STRING {
+			if fieldTypeId == thrift.I64 {
 				if err = p.ReadField2(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetName = true
+				issetProductId = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
 		case 3:
-			if fieldTypeId == thrift.DOUBLE {
+			if fieldTypeId == thrift.STRING {
 				if err = p.ReadField3(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetPrice = true
+				issetProductName = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
 		case 4:
-			if fieldTypeId == thrift.I32 {
+			if fieldTypeId == thrift.MAP {
 				if err = p.ReadField4(iprot); err != nil {
 					goto ReadFieldError
 				}
+				issetSpecs = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 5:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField5(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetPrice = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 6:
+			if fieldTypeId == thrift.I32 {
+				if err = p.ReadField6(iprot); err != nil {
+					goto ReadFieldError
+				}
 				issetStock = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
+		case 7:
+			if fieldTypeId == thrift.I32 {
+				if err = p.ReadField7(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 8:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField8(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
 		default:
 			if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
@@ -135,27 +226,37 @@ func (p *ProductInfo) Read(iprot thrift.TProtocol) (err error) {
 		goto RequiredFieldNotSetError
 	}
 
-	if !issetName {
+	if !issetProductId {
 		fieldId = 2
 		goto RequiredFieldNotSetError
 	}
 
-	if !issetPrice {
+	if !issetProductName {
 		fieldId = 3
 		goto RequiredFieldNotSetError
 	}
 
-	if !issetStock {
+	if !issetSpecs {
 		fieldId = 4
 		goto RequiredFieldNotSetError
 	}
+
+	if !issetPrice {
+		fieldId = 5
+		goto RequiredFieldNotSetError
+	}
+
+	if !issetStock {
+		fieldId = 6
+		goto RequiredFieldNotSetError
+	}
 	return nil
 ReadStructBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
 ReadFieldBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
 ReadFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductInfo[fieldId]), err)
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_SkuInfo[fieldId]), err)
 SkipFieldError:
 	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
 
@@ -164,10 +265,10 @@ ReadFieldEndError:
 ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 RequiredFieldNotSetError:
-	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_ProductInfo[fieldId]))
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_SkuInfo[fieldId]))
 }
 
-func (p *ProductInfo) ReadField1(iprot thrift.TProtocol) error {
+func (p *SkuInfo) ReadField1(iprot thrift.TProtocol) error {
 
 	var _field int64
 	if v, err := iprot.ReadI64(); err != nil {
@@ -178,29 +279,66 @@ func (p *ProductInfo) ReadField1(iprot thrift.TProtocol) error {
 	p.Id = _field
 	return nil
 }
-func (p *ProductInfo) ReadField2(iprot thrift.TProtocol) error {
+func (p *SkuInfo) ReadField2(iprot thrift.TProtocol) error {
 
-	var _field string
-	if v, err := iprot.ReadString(); err != nil {
+	var _field int64
+	if v, err := iprot.ReadI64(); err != nil {
 		return err
 	} else {
 		_field = v
 	}
-	p.Name = _field
+	p.ProductId = _field
 	return nil
 }
-func (p *ProductInfo) ReadField3(iprot thrift.TProtocol) error {
+func (p *SkuInfo) ReadField3(iprot thrift.TProtocol) error {
 
-	var _field float64
-	if v, err := iprot.ReadDouble(); err != nil {
+	var _field string
+	if v, err := iprot.ReadString(); err != nil {
 		return err
 	} else {
 		_field = v
 	}
+	p.ProductName = _field
+	return nil
+}
+func (p *SkuInfo) ReadField4(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return err
+	}
+	_field := make(map[string]string, size)
+	for i := 0; i < size; i++ {
+		var _key string
+		if v, err := iprot.ReadString(); err != nil {
+			return err
+		} else {
+			_key = v
+		}
+
+		var _val string
+		if v, err := iprot.ReadString(); err != nil {
+			return err
+		} else {
+			_val = v
+		}
+
+		_field[_key] = _val
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return err
+	}
+	p.Specs = _field
+	return nil
+}
+func (p *SkuInfo) ReadField5(iprot thrift.TProtocol) error {
+	_field := base.NewMoney()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
 	p.Price = _field
 	return nil
 }
-func (p *ProductInfo) ReadField4(iprot thrift.TProtocol) error {
+func (p *SkuInfo) ReadField6(iprot thrift.TProtocol) error {
 
 	var _field int32
 	if v, err := iprot.ReadI32(); err != nil {
@@ -211,11 +349,33 @@ func (p *ProductInfo) ReadField4(iprot thrift.TProtocol) error {
 	p.Stock = _field
 	return nil
 }
+func (p *SkuInfo) ReadField7(iprot thrift.TProtocol) error {
 
-func (p *ProductInfo) Write(oprot thrift.TProtocol) (err error) {
+	var _field int32
+	if v, err := iprot.ReadI32(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Status = _field
+	return nil
+}
+func (p *SkuInfo) ReadField8(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Barcode = _field
+	return nil
+}
+
+func (p *SkuInfo) Write(oprot thrift.TProtocol) (err error) {
 
 	var fieldId int16
-	if err = oprot.WriteStructBegin("ProductInfo"); err != nil {
+	if err = oprot.WriteStructBegin("SkuInfo"); err != nil {
 		goto WriteStructBeginError
 	}
 	if p != nil {
@@ -235,6 +395,22 @@ func (p *ProductInfo) Write(oprot thrift.TProtocol) (err error) {
 			fieldId = 4
 			goto WriteFieldError
 		}
+		if err = p.writeField5(oprot); err != nil {
+			fieldId = 5
+			goto WriteFieldError
+		}
+		if err = p.writeField6(oprot); err != nil {
+			fieldId = 6
+			goto WriteFieldError
+		}
+		if err = p.writeField7(oprot); err != nil {
+			fieldId = 7
+			goto WriteFieldError
+		}
+		if err = p.writeField8(oprot); err != nil {
+			fieldId = 8
+			goto WriteFieldError
+		}
 	}
 	if err = oprot.WriteFieldStop(); err != nil {
 		goto WriteFieldStopError
@@ -253,7 +429,7 @@ WriteStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
 
-func (p *ProductInfo) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *SkuInfo) writeField1(oprot thrift.TProtocol) (err error) {
 	if err = oprot.WriteFieldBegin("id", thrift.I64, 1); err != nil {
 		goto WriteFieldBeginError
 	}
@@ -270,11 +446,11 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
 }
 
-func (p *ProductInfo) writeField2(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("name", thrift.STRING, 2); err != nil {
+func (p *SkuInfo) writeField2(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("product_id", thrift.I64, 2); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteString(p.Name); err != nil {
+	if err := oprot.WriteI64(p.ProductId); err != nil {
 		return err
 	}
 	if err = oprot.WriteFieldEnd(); err != nil {
@@ -287,11 +463,11 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
 }
 
-func (p *ProductInfo) writeField3(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("price", thrift.DOUBLE, 3); err != nil {
+func (p *SkuInfo) writeField3(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("product_name", thrift.STRING, 3); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteDouble(p.Price); err != nil {
+	if err := oprot.WriteString(p.ProductName); err != nil {
 		return err
 	}
 	if err = oprot.WriteFieldEnd(); err != nil {
@@ -304,11 +480,22 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
 }
 
-func (p *ProductInfo) writeField4(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("stock", thrift.I32, 4); err != nil {
+func (p *SkuInfo) writeField4(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("specs", thrift.MAP, 4); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteI32(p.Stock); err != nil {
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Specs)); err != nil {
+		return err
+	}
+	for k, v := range p.Specs {
+		if err := oprot.WriteString(k); err != nil {
+			return err
+		}
+		if err := oprot.WriteString(v); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
 		return err
 	}
 	if err = oprot.WriteFieldEnd(); err != nil {
@@ -321,15 +508,85 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
 }
 
-func (p *ProductInfo) String() string {
+func (p *SkuInfo) writeField5(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("price", thrift.STRUCT, 5); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := p.Price.Write(oprot); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 5 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 5 end error: ", p), err)
+}
+
+func (p *SkuInfo) writeField6(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("stock", thrift.I32, 6); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI32(p.Stock); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 6 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 6 end error: ", p), err)
+}
+
+func (p *SkuInfo) writeField7(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("status", thrift.I32, 7); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI32(p.Status); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 end error: ", p), err)
+}
+
+func (p *SkuInfo) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetBarcode() {
+		if err = oprot.WriteFieldBegin("barcode", thrift.STRING, 8); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Barcode); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 end error: ", p), err)
+}
+
+func (p *SkuInfo) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ProductInfo(%+v)", *p)
+	return fmt.Sprintf("SkuInfo(%+v)", *p)
 
 }
 
-func (p *ProductInfo) DeepEqual(ano *ProductInfo) bool {
+func (p *SkuInfo) DeepEqual(ano *SkuInfo) bool {
 	if p == ano {
 		return true
 	} else if p == nil || ano == nil {
@@ -338,74 +595,4287 @@ func (p *ProductInfo) DeepEqual(ano *ProductInfo) bool {
 	if !p.Field1DeepEqual(ano.Id) {
 		return false
 	}
-	if !p.Field2DeepEqual(ano.Name) {
+	if !p.Field2DeepEqual(ano.ProductId) {
 		return false
 	}
-	if !p.Field3DeepEqual(ano.Price) {
+	if !p.Field3DeepEqual(ano.ProductName) {
 		return false
 	}
-	if !p.Field4DeepEqual(ano.Stock) {
+	if !p.Field4DeepEqual(ano.Specs) {
+		return false
+	}
+	if !p.Field5DeepEqual(ano.Price) {
+		return false
+	}
+	if !p.Field6DeepEqual(ano.Stock) {
+		return false
+	}
+	if !p.Field7DeepEqual(ano.Status) {
+		return false
+	}
+	if !p.Field8DeepEqual(ano.Barcode) {
 		return false
 	}
 	return true
 }
 
-func (p *ProductInfo) Field1DeepEqual(src int64) bool {
+func (p *SkuInfo) Field1DeepEqual(src int64) bool {
 
 	if p.Id != src {
 		return false
 	}
 	return true
 }
-func (p *ProductInfo) Field2DeepEqual(src string) bool {
+func (p *SkuInfo) Field2DeepEqual(src int64) bool {
 
-	if strings.Compare(p.Name, src) != 0 {
+	if p.ProductId != src {
 		return false
 	}
 	return true
 }
-func (p *ProductInfo) Field3DeepEqual(src float64) bool {
+func (p *SkuInfo) Field3DeepEqual(src string) bool {
 
-	if p.Price != src {
+	if strings.Compare(p.ProductName, src) != 0 {
 		return false
 	}
 	return true
 }
-func (p *ProductInfo) Field4DeepEqual(src int32) bool {
+func (p *SkuInfo) Field4DeepEqual(src map[string]string) bool {
 
-	if p.Stock != src {
+	if len(p.Specs) != len(src) {
 		return false
 	}
+	for k, v := range p.Specs {
+		_src := src[k]
+		if strings.Compare(v, _src) != 0 {
+			return false
+		}
+	}
 	return true
 }
+func (p *SkuInfo) Field5DeepEqual(src *base.Money) bool {
 
-type GetProductReq struct {
-	ProductId int64 `thrift:"product_id,1,required" frugal:"1,required,i64" json:"product_id"`
+	if !p.Price.DeepEqual(src) {
+		return false
+	}
+	return true
 }
+func (p *SkuInfo) Field6DeepEqual(src int32) bool {
 
-func NewGetProductReq() *GetProductReq {
-	return &GetProductReq{}
+	if p.Stock != src {
+		return false
+	}
+	return true
 }
+func (p *SkuInfo) Field7DeepEqual(src int32) bool {
 
-func (p *GetProductReq) InitDefault() {
+	if p.Status != src {
+		return false
+	}
+	return true
 }
+func (p *SkuInfo) Field8DeepEqual(src *string) bool {
 
-func (p *GetProductReq) GetProductId() (v int64) {
-	return p.ProductId
-}
+	if p.Barcode == src {
+		return true
+	} else if p.Barcode == nil || src == nil {
+		return false
+	}
+	if strings.Compare(*p.Barcode, *src) != 0 {
+		return false
+	}
+	return true
+}
+
+type ProductInfo struct {
+	Id          int64       `thrift:"id,1,required" frugal:"1,required,i64" json:"id"`
+	Name        string      `thrift:"name,2,required" frugal:"2,required,string" json:"name"`
+	Price       float64     `thrift:"price,3,required" frugal:"3,required,double" json:"price"`
+	Stock       int32       `thrift:"stock,4,required" frugal:"4,required,i32" json:"stock"`
+	Status      int32       `thrift:"status,5" frugal:"5,default,i32" json:"status"`
+	Description *string     `thrift:"description,6,optional" frugal:"6,optional,string" json:"description,omitempty"`
+	Sales       *int32      `thrift:"sales,7,optional" frugal:"7,optional,i32" json:"sales,omitempty"`
+	CategoryId  *int64      `thrift:"category_id,8,optional" frugal:"8,optional,i64" json:"category_id,omitempty"`
+	Skus        []*SkuInfo  `thrift:"skus,9,optional" frugal:"9,optional,list<SkuInfo>" json:"skus,omitempty"`
+	PriceMoney  *base.Money `thrift:"price_money,10,optional" frugal:"10,optional,base.Money" json:"price_money,omitempty"`
+}
+
+func NewProductInfo() *ProductInfo {
+	return &ProductInfo{}
+}
+
+func (p *ProductInfo) InitDefault() {
+}
+
+func (p *ProductInfo) GetId() (v int64) {
+	return p.Id
+}
+
+func (p *ProductInfo) GetName() (v string) {
+	return p.Name
+}
+
+func (p *ProductInfo) GetPrice() (v float64) {
+	return p.Price
+}
+
+func (p *ProductInfo) GetStock() (v int32) {
+	return p.Stock
+}
+
+func (p *ProductInfo) GetStatus() (v int32) {
+	return p.Status
+}
+
+var ProductInfo_Description_DEFAULT string
+
+func (p *ProductInfo) GetDescription() (v string) {
+	if !p.IsSetDescription() {
+		return ProductInfo_Description_DEFAULT
+	}
+	return *p.Description
+}
+
+var ProductInfo_Sales_DEFAULT int32
+
+func (p *ProductInfo) GetSales() (v int32) {
+	if !p.IsSetSales() {
+		return ProductInfo_Sales_DEFAULT
+	}
+	return *p.Sales
+}
+
+var ProductInfo_CategoryId_DEFAULT int64
+
+func (p *ProductInfo) GetCategoryId() (v int64) {
+	if !p.IsSetCategoryId() {
+		return ProductInfo_CategoryId_DEFAULT
+	}
+	return *p.CategoryId
+}
+
+var ProductInfo_Skus_DEFAULT []*SkuInfo
+
+func (p *ProductInfo) GetSkus() (v []*SkuInfo) {
+	if !p.IsSetSkus() {
+		return ProductInfo_Skus_DEFAULT
+	}
+	return p.Skus
+}
+
+var ProductInfo_PriceMoney_DEFAULT *base.Money
+
+func (p *ProductInfo) GetPriceMoney() (v *base.Money) {
+	if !p.IsSetPriceMoney() {
+		return ProductInfo_PriceMoney_DEFAULT
+	}
+	return p.PriceMoney
+}
+func (p *ProductInfo) SetId(val int64) {
+	p.Id = val
+}
+func (p *ProductInfo) SetName(val string) {
+	p.Name = val
+}
+func (p *ProductInfo) SetPrice(val float64) {
+	p.Price = val
+}
+func (p *ProductInfo) SetStock(val int32) {
+	p.Stock = val
+}
+func (p *ProductInfo) SetStatus(val int32) {
+	p.Status = val
+}
+func (p *ProductInfo) SetDescription(val *string) {
+	p.Description = val
+}
+func (p *ProductInfo) SetSales(val *int32) {
+	p.Sales = val
+}
+func (p *ProductInfo) SetCategoryId(val *int64) {
+	p.CategoryId = val
+}
+func (p *ProductInfo) SetSkus(val []*SkuInfo) {
+	p.Skus = val
+}
+func (p *ProductInfo) SetPriceMoney(val *base.Money) {
+	p.PriceMoney = val
+}
+
+var fieldIDToName_ProductInfo = map[int16]string{
+	1:  "id",
+	2:  "name",
+	3:  "price",
+	4:  "stock",
+	5:  "status",
+	6:  "description",
+	7:  "sales",
+	8:  "category_id",
+	9:  "skus",
+	10: "price_money",
+}
+
+func (p *ProductInfo) IsSetDescription() bool {
+	return p.Description != nil
+}
+
+func (p *ProductInfo) IsSetSales() bool {
+	return p.Sales != nil
+}
+
+func (p *ProductInfo) IsSetCategoryId() bool {
+	return p.CategoryId != nil
+}
+
+func (p *ProductInfo) IsSetSkus() bool {
+	return p.Skus != nil
+}
+
+func (p *ProductInfo) IsSetPriceMoney() bool {
+	return p.PriceMoney != nil
+}
+
+func (p *ProductInfo) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetId bool = false
+	var issetName bool = false
+	var issetPrice bool = false
+	var issetStock bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetId = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetName = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 3:
+			if fieldTypeId == thrift.DOUBLE {
+				if err = p.ReadField3(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetPrice = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 4:
+			if fieldTypeId == thrift.I32 {
+				if err = p.ReadField4(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetStock = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 5:
+			if fieldTypeId == thrift.I32 {
+				if err = p.ReadField5(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 6:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField6(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 7:
+			if fieldTypeId == thrift.I32 {
+				if err = p.ReadField7(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 8:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField8(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 9:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField9(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 10:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField10(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetId {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+
+	if !issetName {
+		fieldId = 2
+		goto RequiredFieldNotSetError
+	}
+
+	if !issetPrice {
+		fieldId = 3
+		goto RequiredFieldNotSetError
+	}
+
+	if !issetStock {
+		fieldId = 4
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductInfo[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_ProductInfo[fieldId]))
+}
+
+func (p *ProductInfo) ReadField1(iprot thrift.TProtocol) error {
+
+	var _field int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Id = _field
+	return nil
+}
+func (p *ProductInfo) ReadField2(iprot thrift.TProtocol) error {
+
+	var _field string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Name = _field
+	return nil
+}
+func (p *ProductInfo) ReadField3(iprot thrift.TProtocol) error {
+
+	var _field float64
+	if v, err := iprot.ReadDouble(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Price = _field
+	return nil
+}
+func (p *ProductInfo) ReadField4(iprot thrift.TProtocol) error {
+
+	var _field int32
+	if v, err := iprot.ReadI32(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Stock = _field
+	return nil
+}
+func (p *ProductInfo) ReadField5(iprot thrift.TProtocol) error {
+
+	var _field int32
+	if v, err := iprot.ReadI32(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Status = _field
+	return nil
+}
+func (p *ProductInfo) ReadField6(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Description = _field
+	return nil
+}
+func (p *ProductInfo) ReadField7(iprot thrift.TProtocol) error {
+
+	var _field *int32
+	if v, err := iprot.ReadI32(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Sales = _field
+	return nil
+}
+func (p *ProductInfo) ReadField8(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.CategoryId = _field
+	return nil
+}
+func (p *ProductInfo) ReadField9(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]*SkuInfo, 0, size)
+	values := make([]SkuInfo, size)
+	for i := 0; i < size; i++ {
+		_elem := &values[i]
+		_elem.InitDefault()
+
+		if err := _elem.Read(iprot); err != nil {
+			return err
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
+	}
+	p.Skus = _field
+	return nil
+}
+func (p *ProductInfo) ReadField10(iprot thrift.TProtocol) error {
+	_field := base.NewMoney()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.PriceMoney = _field
+	return nil
+}
+
+func (p *ProductInfo) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("ProductInfo"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+		if err = p.writeField3(oprot); err != nil {
+			fieldId = 3
+			goto WriteFieldError
+		}
+		if err = p.writeField4(oprot); err != nil {
+			fieldId = 4
+			goto WriteFieldError
+		}
+		if err = p.writeField5(oprot); err != nil {
+			fieldId = 5
+			goto WriteFieldError
+		}
+		if err = p.writeField6(oprot); err != nil {
+			fieldId = 6
+			goto WriteFieldError
+		}
+		if err = p.writeField7(oprot); err != nil {
+			fieldId = 7
+			goto WriteFieldError
+		}
+		if err = p.writeField8(oprot); err != nil {
+			fieldId = 8
+			goto WriteFieldError
+		}
+		if err = p.writeField9(oprot); err != nil {
+			fieldId = 9
+			goto WriteFieldError
+		}
+		if err = p.writeField10(oprot); err != nil {
+			fieldId = 10
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ProductInfo) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("id", thrift.I64, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI64(p.Id); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *ProductInfo) writeField2(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("name", thrift.STRING, 2); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteString(p.Name); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+
+func (p *ProductInfo) writeField3(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("price", thrift.DOUBLE, 3); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteDouble(p.Price); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
+}
+
+func (p *ProductInfo) writeField4(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("stock", thrift.I32, 4); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI32(p.Stock); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
+}
+
+func (p *ProductInfo) writeField5(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("status", thrift.I32, 5); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI32(p.Status); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 5 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 5 end error: ", p), err)
+}
+
+func (p *ProductInfo) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDescription() {
+		if err = oprot.WriteFieldBegin("description", thrift.STRING, 6); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Description); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 6 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 6 end error: ", p), err)
+}
+
+func (p *ProductInfo) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSales() {
+		if err = oprot.WriteFieldBegin("sales", thrift.I32, 7); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI32(*p.Sales); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 end error: ", p), err)
+}
+
+func (p *ProductInfo) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCategoryId() {
+		if err = oprot.WriteFieldBegin("category_id", thrift.I64, 8); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.CategoryId); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 end error: ", p), err)
+}
+
+func (p *ProductInfo) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSkus() {
+		if err = oprot.WriteFieldBegin("skus", thrift.LIST, 9); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Skus)); err != nil {
+			return err
+		}
+		for _, v := range p.Skus {
+			if err := v.Write(oprot); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 end error: ", p), err)
+}
+
+func (p *ProductInfo) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPriceMoney() {
+		if err = oprot.WriteFieldBegin("price_money", thrift.STRUCT, 10); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := p.PriceMoney.Write(oprot); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 10 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 10 end error: ", p), err)
+}
+
+func (p *ProductInfo) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductInfo(%+v)", *p)
+
+}
+
+func (p *ProductInfo) DeepEqual(ano *ProductInfo) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.Id) {
+		return false
+	}
+	if !p.Field2DeepEqual(ano.Name) {
+		return false
+	}
+	if !p.Field3DeepEqual(ano.Price) {
+		return false
+	}
+	if !p.Field4DeepEqual(ano.Stock) {
+		return false
+	}
+	if !p.Field5DeepEqual(ano.Status) {
+		return false
+	}
+	if !p.Field6DeepEqual(ano.Description) {
+		return false
+	}
+	if !p.Field7DeepEqual(ano.Sales) {
+		return false
+	}
+	if !p.Field8DeepEqual(ano.CategoryId) {
+		return false
+	}
+	if !p.Field9DeepEqual(ano.Skus) {
+		return false
+	}
+	if !p.Field10DeepEqual(ano.PriceMoney) {
+		return false
+	}
+	return true
+}
+
+func (p *ProductInfo) Field1DeepEqual(src int64) bool {
+
+	if p.Id != src {
+		return false
+	}
+	return true
+}
+func (p *ProductInfo) Field2DeepEqual(src string) bool {
+
+	if strings.Compare(p.Name, src) != 0 {
+		return false
+	}
+	return true
+}
+func (p *ProductInfo) Field3DeepEqual(src float64) bool {
+
+	if p.Price != src {
+		return false
+	}
+	return true
+}
+func (p *ProductInfo) Field4DeepEqual(src int32) bool {
+
+	if p.Stock != src {
+		return false
+	}
+	return true
+}
+func (p *ProductInfo) Field5DeepEqual(src int32) bool {
+
+	if p.Status != src {
+		return false
+	}
+	return true
+}
+func (p *ProductInfo) Field6DeepEqual(src *string) bool {
+
+	if p.Description == src {
+		return true
+	} else if p.Description == nil || src == nil {
+		return false
+	}
+	if strings.Compare(*p.Description, *src) != 0 {
+		return false
+	}
+	return true
+}
+func (p *ProductInfo) Field7DeepEqual(src *int32) bool {
+
+	if p.Sales == src {
+		return true
+	} else if p.Sales == nil || src == nil {
+		return false
+	}
+	if *p.Sales != *src {
+		return false
+	}
+	return true
+}
+func (p *ProductInfo) Field8DeepEqual(src *int64) bool {
+
+	if p.CategoryId == src {
+		return true
+	} else if p.CategoryId == nil || src == nil {
+		return false
+	}
+	if *p.CategoryId != *src {
+		return false
+	}
+	return true
+}
+func (p *ProductInfo) Field9DeepEqual(src []*SkuInfo) bool {
+
+	if len(p.Skus) != len(src) {
+		return false
+	}
+	for i, v := range p.Skus {
+		_src := src[i]
+		if !v.DeepEqual(_src) {
+			return false
+		}
+	}
+	return true
+}
+func (p *ProductInfo) Field10DeepEqual(src *base.Money) bool {
+
+	if !p.PriceMoney.DeepEqual(src) {
+		return false
+	}
+	return true
+}
+
+type GetProductReq struct {
+	ProductId int64 `thrift:"product_id,1,required" frugal:"1,required,i64" json:"product_id"`
+}
+
+func NewGetProductReq() *GetProductReq {
+	return &GetProductReq{}
+}
+
+func (p *GetProductReq) InitDefault() {
+}
+
+func (p *GetProductReq) GetProductId() (v int64) {
+	return p.ProductId
+}
 func (p *GetProductReq) SetProductId(val int64) {
 	p.ProductId = val
 }
 
-var fieldIDToName_GetProductReq = map[int16]string{
-	1: "product_id",
+var fieldIDToName_GetProductReq = map[int16]string{
+	1: "product_id",
+}
+
+func (p *GetProductReq) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetProductId bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetProductId = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetProductId {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_GetProductReq[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_GetProductReq[fieldId]))
+}
+
+func (p *GetProductReq) ReadField1(iprot thrift.TProtocol) error {
+
+	var _field int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.ProductId = _field
+	return nil
+}
+
+func (p *GetProductReq) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("GetProductReq"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *GetProductReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("product_id", thrift.I64, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI64(p.ProductId); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *GetProductReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetProductReq(%+v)", *p)
+
+}
+
+func (p *GetProductReq) DeepEqual(ano *GetProductReq) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.ProductId) {
+		return false
+	}
+	return true
+}
+
+func (p *GetProductReq) Field1DeepEqual(src int64) bool {
+
+	if p.ProductId != src {
+		return false
+	}
+	return true
+}
+
+type DecreaseStockReq struct {
+	ProductId int64  `thrift:"product_id,1,required" frugal:"1,required,i64" json:"product_id"`
+	Quantity  int32  `thrift:"quantity,2,required" frugal:"2,required,i32" json:"quantity"`
+	RequestId string `thrift:"request_id,3,required" frugal:"3,required,string" json:"request_id"`
+	SkuId     *int64 `thrift:"sku_id,4,optional" frugal:"4,optional,i64" json:"sku_id,omitempty"`
+}
+
+func NewDecreaseStockReq() *DecreaseStockReq {
+	return &DecreaseStockReq{}
+}
+
+func (p *DecreaseStockReq) InitDefault() {
+}
+
+func (p *DecreaseStockReq) GetProductId() (v int64) {
+	return p.ProductId
+}
+
+func (p *DecreaseStockReq) GetQuantity() (v int32) {
+	return p.Quantity
+}
+
+func (p *DecreaseStockReq) GetRequestId() (v string) {
+	return p.RequestId
+}
+
+var DecreaseStockReq_SkuId_DEFAULT int64
+
+func (p *DecreaseStockReq) GetSkuId() (v int64) {
+	if !p.IsSetSkuId() {
+		return DecreaseStockReq_SkuId_DEFAULT
+	}
+	return *p.SkuId
+}
+func (p *DecreaseStockReq) SetProductId(val int64) {
+	p.ProductId = val
+}
+func (p *DecreaseStockReq) SetQuantity(val int32) {
+	p.Quantity = val
+}
+func (p *DecreaseStockReq) SetRequestId(val string) {
+	p.RequestId = val
+}
+func (p *DecreaseStockReq) SetSkuId(val *int64) {
+	p.SkuId = val
+}
+
+var fieldIDToName_DecreaseStockReq = map[int16]string{
+	1: "product_id",
+	2: "quantity",
+	3: "request_id",
+	4: "sku_id",
+}
+
+func (p *DecreaseStockReq) IsSetSkuId() bool {
+	return p.SkuId != nil
+}
+
+func (p *DecreaseStockReq) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetProductId bool = false
+	var issetQuantity bool = false
+	var issetRequestId bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetProductId = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.I32 {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetQuantity = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 3:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField3(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetRequestId = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 4:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField4(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetProductId {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+
+	if !issetQuantity {
+		fieldId = 2
+		goto RequiredFieldNotSetError
+	}
+
+	if !issetRequestId {
+		fieldId = 3
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_DecreaseStockReq[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_DecreaseStockReq[fieldId]))
+}
+
+func (p *DecreaseStockReq) ReadField1(iprot thrift.TProtocol) error {
+
+	var _field int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.ProductId = _field
+	return nil
+}
+func (p *DecreaseStockReq) ReadField2(iprot thrift.TProtocol) error {
+
+	var _field int32
+	if v, err := iprot.ReadI32(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.Quantity = _field
+	return nil
+}
+func (p *DecreaseStockReq) ReadField3(iprot thrift.TProtocol) error {
+
+	var _field string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.RequestId = _field
+	return nil
+}
+func (p *DecreaseStockReq) ReadField4(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.SkuId = _field
+	return nil
+}
+
+func (p *DecreaseStockReq) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("DecreaseStockReq"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+		if err = p.writeField3(oprot); err != nil {
+			fieldId = 3
+			goto WriteFieldError
+		}
+		if err = p.writeField4(oprot); err != nil {
+			fieldId = 4
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *DecreaseStockReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("product_id", thrift.I64, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI64(p.ProductId); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *DecreaseStockReq) writeField2(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("quantity", thrift.I32, 2); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI32(p.Quantity); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+
+func (p *DecreaseStockReq) writeField3(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("request_id", thrift.STRING, 3); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteString(p.RequestId); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
+}
+
+func (p *DecreaseStockReq) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSkuId() {
+		if err = oprot.WriteFieldBegin("sku_id", thrift.I64, 4); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.SkuId); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
+}
+
+func (p *DecreaseStockReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DecreaseStockReq(%+v)", *p)
+
+}
+
+func (p *DecreaseStockReq) DeepEqual(ano *DecreaseStockReq) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.ProductId) {
+		return false
+	}
+	if !p.Field2DeepEqual(ano.Quantity) {
+		return false
+	}
+	if !p.Field3DeepEqual(ano.RequestId) {
+		return false
+	}
+	if !p.Field4DeepEqual(ano.SkuId) {
+		return false
+	}
+	return true
+}
+
+func (p *DecreaseStockReq) Field1DeepEqual(src int64) bool {
+
+	if p.ProductId != src {
+		return false
+	}
+	return true
+}
+func (p *DecreaseStockReq) Field2DeepEqual(src int32) bool {
+
+	if p.Quantity != src {
+		return false
+	}
+	return true
+}
+func (p *DecreaseStockReq) Field3DeepEqual(src string) bool {
+
+	if strings.Compare(p.RequestId, src) != 0 {
+		return false
+	}
+	return true
+}
+func (p *DecreaseStockReq) Field4DeepEqual(src *int64) bool {
+
+	if p.SkuId == src {
+		return true
+	} else if p.SkuId == nil || src == nil {
+		return false
+	}
+	if *p.SkuId != *src {
+		return false
+	}
+	return true
+}
+
+type ReleaseStockReq struct {
+	RequestId string `thrift:"request_id,1,required" frugal:"1,required,string" json:"request_id"`
+	ProductId int64  `thrift:"product_id,2,required" frugal:"2,required,i64" json:"product_id"`
+}
+
+func NewReleaseStockReq() *ReleaseStockReq {
+	return &ReleaseStockReq{}
+}
+
+func (p *ReleaseStockReq) InitDefault() {
+}
+
+func (p *ReleaseStockReq) GetRequestId() (v string) {
+	return p.RequestId
+}
+
+func (p *ReleaseStockReq) GetProductId() (v int64) {
+	return p.ProductId
+}
+func (p *ReleaseStockReq) SetRequestId(val string) {
+	p.RequestId = val
+}
+func (p *ReleaseStockReq) SetProductId(val int64) {
+	p.ProductId = val
+}
+
+var fieldIDToName_ReleaseStockReq = map[int16]string{
+	1: "request_id",
+	2: "product_id",
+}
+
+func (p *ReleaseStockReq) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetRequestId bool = false
+	var issetProductId bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetRequestId = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetProductId = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetRequestId {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+
+	if !issetProductId {
+		fieldId = 2
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ReleaseStockReq[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_ReleaseStockReq[fieldId]))
+}
+
+func (p *ReleaseStockReq) ReadField1(iprot thrift.TProtocol) error {
+
+	var _field string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.RequestId = _field
+	return nil
+}
+func (p *ReleaseStockReq) ReadField2(iprot thrift.TProtocol) error {
+
+	var _field int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = v
+	}
+	p.ProductId = _field
+	return nil
+}
+
+func (p *ReleaseStockReq) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("ReleaseStockReq"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ReleaseStockReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("request_id", thrift.STRING, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteString(p.RequestId); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *ReleaseStockReq) writeField2(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("product_id", thrift.I64, 2); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteI64(p.ProductId); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+
+func (p *ReleaseStockReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ReleaseStockReq(%+v)", *p)
+
+}
+
+func (p *ReleaseStockReq) DeepEqual(ano *ReleaseStockReq) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.RequestId) {
+		return false
+	}
+	if !p.Field2DeepEqual(ano.ProductId) {
+		return false
+	}
+	return true
+}
+
+func (p *ReleaseStockReq) Field1DeepEqual(src string) bool {
+
+	if strings.Compare(p.RequestId, src) != 0 {
+		return false
+	}
+	return true
+}
+func (p *ReleaseStockReq) Field2DeepEqual(src int64) bool {
+
+	if p.ProductId != src {
+		return false
+	}
+	return true
+}
+
+type SearchProductsReq struct {
+	Keyword         *string           `thrift:"keyword,1,optional" frugal:"1,optional,string" json:"keyword,omitempty"`
+	MinPriceCents   *int64            `thrift:"min_price_cents,2,optional" frugal:"2,optional,i64" json:"min_price_cents,omitempty"`
+	MaxPriceCents   *int64            `thrift:"max_price_cents,3,optional" frugal:"3,optional,i64" json:"max_price_cents,omitempty"`
+	IncludeOffShelf *bool             `thrift:"include_off_shelf,4,optional" frugal:"4,optional,bool" json:"include_off_shelf,omitempty"`
+	Sort            *string           `thrift:"sort,5,optional" frugal:"5,optional,string" json:"sort,omitempty"`
+	Cursor          *string           `thrift:"cursor,6,optional" frugal:"6,optional,string" json:"cursor,omitempty"`
+	Limit           *int32            `thrift:"limit,7,optional" frugal:"7,optional,i32" json:"limit,omitempty"`
+	CategoryId      *int64            `thrift:"category_id,8,optional" frugal:"8,optional,i64" json:"category_id,omitempty"`
+	Attributes      map[string]string `thrift:"attributes,9,optional" frugal:"9,optional,map<string:string>" json:"attributes,omitempty"`
+}
+
+func NewSearchProductsReq() *SearchProductsReq {
+	return &SearchProductsReq{}
+}
+
+func (p *SearchProductsReq) InitDefault() {
+}
+
+var SearchProductsReq_Keyword_DEFAULT string
+
+func (p *SearchProductsReq) GetKeyword() (v string) {
+	if !p.IsSetKeyword() {
+		return SearchProductsReq_Keyword_DEFAULT
+	}
+	return *p.Keyword
+}
+
+var SearchProductsReq_MinPriceCents_DEFAULT int64
+
+func (p *SearchProductsReq) GetMinPriceCents() (v int64) {
+	if !p.IsSetMinPriceCents() {
+		return SearchProductsReq_MinPriceCents_DEFAULT
+	}
+	return *p.MinPriceCents
+}
+
+var SearchProductsReq_MaxPriceCents_DEFAULT int64
+
+func (p *SearchProductsReq) GetMaxPriceCents() (v int64) {
+	if !p.IsSetMaxPriceCents() {
+		return SearchProductsReq_MaxPriceCents_DEFAULT
+	}
+	return *p.MaxPriceCents
+}
+
+var SearchProductsReq_IncludeOffShelf_DEFAULT bool
+
+func (p *SearchProductsReq) GetIncludeOffShelf() (v bool) {
+	if !p.IsSetIncludeOffShelf() {
+		return SearchProductsReq_IncludeOffShelf_DEFAULT
+	}
+	return *p.IncludeOffShelf
+}
+
+var SearchProductsReq_Sort_DEFAULT string
+
+func (p *SearchProductsReq) GetSort() (v string) {
+	if !p.IsSetSort() {
+		return SearchProductsReq_Sort_DEFAULT
+	}
+	return *p.Sort
+}
+
+var SearchProductsReq_Cursor_DEFAULT string
+
+func (p *SearchProductsReq) GetCursor() (v string) {
+	if !p.IsSetCursor() {
+		return SearchProductsReq_Cursor_DEFAULT
+	}
+	return *p.Cursor
+}
+
+var SearchProductsReq_Limit_DEFAULT int32
+
+func (p *SearchProductsReq) GetLimit() (v int32) {
+	if !p.IsSetLimit() {
+		return SearchProductsReq_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+
+var SearchProductsReq_CategoryId_DEFAULT int64
+
+func (p *SearchProductsReq) GetCategoryId() (v int64) {
+	if !p.IsSetCategoryId() {
+		return SearchProductsReq_CategoryId_DEFAULT
+	}
+	return *p.CategoryId
+}
+
+var SearchProductsReq_Attributes_DEFAULT map[string]string
+
+func (p *SearchProductsReq) GetAttributes() (v map[string]string) {
+	if !p.IsSetAttributes() {
+		return SearchProductsReq_Attributes_DEFAULT
+	}
+	return p.Attributes
+}
+func (p *SearchProductsReq) SetKeyword(val *string) {
+	p.Keyword = val
+}
+func (p *SearchProductsReq) SetMinPriceCents(val *int64) {
+	p.MinPriceCents = val
+}
+func (p *SearchProductsReq) SetMaxPriceCents(val *int64) {
+	p.MaxPriceCents = val
+}
+func (p *SearchProductsReq) SetIncludeOffShelf(val *bool) {
+	p.IncludeOffShelf = val
+}
+func (p *SearchProductsReq) SetSort(val *string) {
+	p.Sort = val
+}
+func (p *SearchProductsReq) SetCursor(val *string) {
+	p.Cursor = val
+}
+func (p *SearchProductsReq) SetLimit(val *int32) {
+	p.Limit = val
+}
+func (p *SearchProductsReq) SetCategoryId(val *int64) {
+	p.CategoryId = val
+}
+func (p *SearchProductsReq) SetAttributes(val map[string]string) {
+	p.Attributes = val
+}
+
+var fieldIDToName_SearchProductsReq = map[int16]string{
+	1: "keyword",
+	2: "min_price_cents",
+	3: "max_price_cents",
+	4: "include_off_shelf",
+	5: "sort",
+	6: "cursor",
+	7: "limit",
+	8: "category_id",
+	9: "attributes",
+}
+
+func (p *SearchProductsReq) IsSetKeyword() bool {
+	return p.Keyword != nil
+}
+
+func (p *SearchProductsReq) IsSetMinPriceCents() bool {
+	return p.MinPriceCents != nil
+}
+
+func (p *SearchProductsReq) IsSetMaxPriceCents() bool {
+	return p.MaxPriceCents != nil
+}
+
+func (p *SearchProductsReq) IsSetIncludeOffShelf() bool {
+	return p.IncludeOffShelf != nil
+}
+
+func (p *SearchProductsReq) IsSetSort() bool {
+	return p.Sort != nil
+}
+
+func (p *SearchProductsReq) IsSetCursor() bool {
+	return p.Cursor != nil
+}
+
+func (p *SearchProductsReq) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *SearchProductsReq) IsSetCategoryId() bool {
+	return p.CategoryId != nil
+}
+
+func (p *SearchProductsReq) IsSetAttributes() bool {
+	return p.Attributes != nil
+}
+
+func (p *SearchProductsReq) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 3:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField3(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 4:
+			if fieldTypeId == thrift.BOOL {
+				if err = p.ReadField4(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 5:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField5(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 6:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField6(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 7:
+			if fieldTypeId == thrift.I32 {
+				if err = p.ReadField7(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 8:
+			if fieldTypeId == thrift.I64 {
+				if err = p.ReadField8(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 9:
+			if fieldTypeId == thrift.MAP {
+				if err = p.ReadField9(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_SearchProductsReq[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *SearchProductsReq) ReadField1(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Keyword = _field
+	return nil
+}
+func (p *SearchProductsReq) ReadField2(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.MinPriceCents = _field
+	return nil
+}
+func (p *SearchProductsReq) ReadField3(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.MaxPriceCents = _field
+	return nil
+}
+func (p *SearchProductsReq) ReadField4(iprot thrift.TProtocol) error {
+
+	var _field *bool
+	if v, err := iprot.ReadBool(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.IncludeOffShelf = _field
+	return nil
+}
+func (p *SearchProductsReq) ReadField5(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Sort = _field
+	return nil
+}
+func (p *SearchProductsReq) ReadField6(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Cursor = _field
+	return nil
+}
+func (p *SearchProductsReq) ReadField7(iprot thrift.TProtocol) error {
+
+	var _field *int32
+	if v, err := iprot.ReadI32(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.Limit = _field
+	return nil
+}
+func (p *SearchProductsReq) ReadField8(iprot thrift.TProtocol) error {
+
+	var _field *int64
+	if v, err := iprot.ReadI64(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.CategoryId = _field
+	return nil
+}
+func (p *SearchProductsReq) ReadField9(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return err
+	}
+	_field := make(map[string]string, size)
+	for i := 0; i < size; i++ {
+		var _key string
+		if v, err := iprot.ReadString(); err != nil {
+			return err
+		} else {
+			_key = v
+		}
+
+		var _val string
+		if v, err := iprot.ReadString(); err != nil {
+			return err
+		} else {
+			_val = v
+		}
+
+		_field[_key] = _val
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return err
+	}
+	p.Attributes = _field
+	return nil
+}
+
+func (p *SearchProductsReq) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("SearchProductsReq"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+		if err = p.writeField3(oprot); err != nil {
+			fieldId = 3
+			goto WriteFieldError
+		}
+		if err = p.writeField4(oprot); err != nil {
+			fieldId = 4
+			goto WriteFieldError
+		}
+		if err = p.writeField5(oprot); err != nil {
+			fieldId = 5
+			goto WriteFieldError
+		}
+		if err = p.writeField6(oprot); err != nil {
+			fieldId = 6
+			goto WriteFieldError
+		}
+		if err = p.writeField7(oprot); err != nil {
+			fieldId = 7
+			goto WriteFieldError
+		}
+		if err = p.writeField8(oprot); err != nil {
+			fieldId = 8
+			goto WriteFieldError
+		}
+		if err = p.writeField9(oprot); err != nil {
+			fieldId = 9
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *SearchProductsReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetKeyword() {
+		if err = oprot.WriteFieldBegin("keyword", thrift.STRING, 1); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Keyword); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *SearchProductsReq) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMinPriceCents() {
+		if err = oprot.WriteFieldBegin("min_price_cents", thrift.I64, 2); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.MinPriceCents); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+
+func (p *SearchProductsReq) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxPriceCents() {
+		if err = oprot.WriteFieldBegin("max_price_cents", thrift.I64, 3); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.MaxPriceCents); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 3 end error: ", p), err)
+}
+
+func (p *SearchProductsReq) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIncludeOffShelf() {
+		if err = oprot.WriteFieldBegin("include_off_shelf", thrift.BOOL, 4); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteBool(*p.IncludeOffShelf); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 4 end error: ", p), err)
+}
+
+func (p *SearchProductsReq) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSort() {
+		if err = oprot.WriteFieldBegin("sort", thrift.STRING, 5); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Sort); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 5 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 5 end error: ", p), err)
+}
+
+func (p *SearchProductsReq) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCursor() {
+		if err = oprot.WriteFieldBegin("cursor", thrift.STRING, 6); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.Cursor); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 6 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 6 end error: ", p), err)
+}
+
+func (p *SearchProductsReq) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err = oprot.WriteFieldBegin("limit", thrift.I32, 7); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI32(*p.Limit); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 7 end error: ", p), err)
+}
+
+func (p *SearchProductsReq) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCategoryId() {
+		if err = oprot.WriteFieldBegin("category_id", thrift.I64, 8); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteI64(*p.CategoryId); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 8 end error: ", p), err)
+}
+
+func (p *SearchProductsReq) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAttributes() {
+		if err = oprot.WriteFieldBegin("attributes", thrift.MAP, 9); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Attributes)); err != nil {
+			return err
+		}
+		for k, v := range p.Attributes {
+			if err := oprot.WriteString(k); err != nil {
+				return err
+			}
+			if err := oprot.WriteString(v); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 9 end error: ", p), err)
+}
+
+func (p *SearchProductsReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SearchProductsReq(%+v)", *p)
+
+}
+
+func (p *SearchProductsReq) DeepEqual(ano *SearchProductsReq) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.Keyword) {
+		return false
+	}
+	if !p.Field2DeepEqual(ano.MinPriceCents) {
+		return false
+	}
+	if !p.Field3DeepEqual(ano.MaxPriceCents) {
+		return false
+	}
+	if !p.Field4DeepEqual(ano.IncludeOffShelf) {
+		return false
+	}
+	if !p.Field5DeepEqual(ano.Sort) {
+		return false
+	}
+	if !p.Field6DeepEqual(ano.Cursor) {
+		return false
+	}
+	if !p.Field7DeepEqual(ano.Limit) {
+		return false
+	}
+	if !p.Field8DeepEqual(ano.CategoryId) {
+		return false
+	}
+	if !p.Field9DeepEqual(ano.Attributes) {
+		return false
+	}
+	return true
+}
+
+func (p *SearchProductsReq) Field1DeepEqual(src *string) bool {
+
+	if p.Keyword == src {
+		return true
+	} else if p.Keyword == nil || src == nil {
+		return false
+	}
+	if strings.Compare(*p.Keyword, *src) != 0 {
+		return false
+	}
+	return true
+}
+func (p *SearchProductsReq) Field2DeepEqual(src *int64) bool {
+
+	if p.MinPriceCents == src {
+		return true
+	} else if p.MinPriceCents == nil || src == nil {
+		return false
+	}
+	if *p.MinPriceCents != *src {
+		return false
+	}
+	return true
+}
+func (p *SearchProductsReq) Field3DeepEqual(src *int64) bool {
+
+	if p.MaxPriceCents == src {
+		return true
+	} else if p.MaxPriceCents == nil || src == nil {
+		return false
+	}
+	if *p.MaxPriceCents != *src {
+		return false
+	}
+	return true
+}
+func (p *SearchProductsReq) Field4DeepEqual(src *bool) bool {
+
+	if p.IncludeOffShelf == src {
+		return true
+	} else if p.IncludeOffShelf == nil || src == nil {
+		return false
+	}
+	if *p.IncludeOffShelf != *src {
+		return false
+	}
+	return true
+}
+func (p *SearchProductsReq) Field5DeepEqual(src *string) bool {
+
+	if p.Sort == src {
+		return true
+	} else if p.Sort == nil || src == nil {
+		return false
+	}
+	if strings.Compare(*p.Sort, *src) != 0 {
+		return false
+	}
+	return true
+}
+func (p *SearchProductsReq) Field6DeepEqual(src *string) bool {
+
+	if p.Cursor == src {
+		return true
+	} else if p.Cursor == nil || src == nil {
+		return false
+	}
+	if strings.Compare(*p.Cursor, *src) != 0 {
+		return false
+	}
+	return true
+}
+func (p *SearchProductsReq) Field7DeepEqual(src *int32) bool {
+
+	if p.Limit == src {
+		return true
+	} else if p.Limit == nil || src == nil {
+		return false
+	}
+	if *p.Limit != *src {
+		return false
+	}
+	return true
+}
+func (p *SearchProductsReq) Field8DeepEqual(src *int64) bool {
+
+	if p.CategoryId == src {
+		return true
+	} else if p.CategoryId == nil || src == nil {
+		return false
+	}
+	if *p.CategoryId != *src {
+		return false
+	}
+	return true
+}
+func (p *SearchProductsReq) Field9DeepEqual(src map[string]string) bool {
+
+	if len(p.Attributes) != len(src) {
+		return false
+	}
+	for k, v := range p.Attributes {
+		_src := src[k]
+		if strings.Compare(v, _src) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type SearchProductsResp struct {
+	Products   []*ProductInfo `thrift:"products,1,required" frugal:"1,required,list<ProductInfo>" json:"products"`
+	NextCursor *string        `thrift:"next_cursor,2,optional" frugal:"2,optional,string" json:"next_cursor,omitempty"`
+}
+
+func NewSearchProductsResp() *SearchProductsResp {
+	return &SearchProductsResp{}
+}
+
+func (p *SearchProductsResp) InitDefault() {
+}
+
+func (p *SearchProductsResp) GetProducts() (v []*ProductInfo) {
+	return p.Products
+}
+
+var SearchProductsResp_NextCursor_DEFAULT string
+
+func (p *SearchProductsResp) GetNextCursor() (v string) {
+	if !p.IsSetNextCursor() {
+		return SearchProductsResp_NextCursor_DEFAULT
+	}
+	return *p.NextCursor
+}
+func (p *SearchProductsResp) SetProducts(val []*ProductInfo) {
+	p.Products = val
+}
+func (p *SearchProductsResp) SetNextCursor(val *string) {
+	p.NextCursor = val
+}
+
+var fieldIDToName_SearchProductsResp = map[int16]string{
+	1: "products",
+	2: "next_cursor",
+}
+
+func (p *SearchProductsResp) IsSetNextCursor() bool {
+	return p.NextCursor != nil
+}
+
+func (p *SearchProductsResp) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetProducts bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetProducts = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		case 2:
+			if fieldTypeId == thrift.STRING {
+				if err = p.ReadField2(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetProducts {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_SearchProductsResp[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_SearchProductsResp[fieldId]))
+}
+
+func (p *SearchProductsResp) ReadField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]*ProductInfo, 0, size)
+	values := make([]ProductInfo, size)
+	for i := 0; i < size; i++ {
+		_elem := &values[i]
+		_elem.InitDefault()
+
+		if err := _elem.Read(iprot); err != nil {
+			return err
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
+	}
+	p.Products = _field
+	return nil
+}
+func (p *SearchProductsResp) ReadField2(iprot thrift.TProtocol) error {
+
+	var _field *string
+	if v, err := iprot.ReadString(); err != nil {
+		return err
+	} else {
+		_field = &v
+	}
+	p.NextCursor = _field
+	return nil
+}
+
+func (p *SearchProductsResp) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("SearchProductsResp"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+		if err = p.writeField2(oprot); err != nil {
+			fieldId = 2
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *SearchProductsResp) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("products", thrift.LIST, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Products)); err != nil {
+		return err
+	}
+	for _, v := range p.Products {
+		if err := v.Write(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *SearchProductsResp) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNextCursor() {
+		if err = oprot.WriteFieldBegin("next_cursor", thrift.STRING, 2); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteString(*p.NextCursor); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+}
+
+func (p *SearchProductsResp) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SearchProductsResp(%+v)", *p)
+
+}
+
+func (p *SearchProductsResp) DeepEqual(ano *SearchProductsResp) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.Products) {
+		return false
+	}
+	if !p.Field2DeepEqual(ano.NextCursor) {
+		return false
+	}
+	return true
+}
+
+func (p *SearchProductsResp) Field1DeepEqual(src []*ProductInfo) bool {
+
+	if len(p.Products) != len(src) {
+		return false
+	}
+	for i, v := range p.Products {
+		_src := src[i]
+		if !v.DeepEqual(_src) {
+			return false
+		}
+	}
+	return true
+}
+func (p *SearchProductsResp) Field2DeepEqual(src *string) bool {
+
+	if p.NextCursor == src {
+		return true
+	} else if p.NextCursor == nil || src == nil {
+		return false
+	}
+	if strings.Compare(*p.NextCursor, *src) != 0 {
+		return false
+	}
+	return true
+}
+
+type ListProductsReq struct {
+	ProductIds []int64 `thrift:"product_ids,1,required" frugal:"1,required,list<i64>" json:"product_ids"`
+}
+
+func NewListProductsReq() *ListProductsReq {
+	return &ListProductsReq{}
+}
+
+func (p *ListProductsReq) InitDefault() {
+}
+
+func (p *ListProductsReq) GetProductIds() (v []int64) {
+	return p.ProductIds
+}
+func (p *ListProductsReq) SetProductIds(val []int64) {
+	p.ProductIds = val
+}
+
+var fieldIDToName_ListProductsReq = map[int16]string{
+	1: "product_ids",
+}
+
+func (p *ListProductsReq) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetProductIds bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetProductIds = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetProductIds {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ListProductsReq[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_ListProductsReq[fieldId]))
+}
+
+func (p *ListProductsReq) ReadField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]int64, 0, size)
+	for i := 0; i < size; i++ {
+
+		var _elem int64
+		if v, err := iprot.ReadI64(); err != nil {
+			return err
+		} else {
+			_elem = v
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
+	}
+	p.ProductIds = _field
+	return nil
+}
+
+func (p *ListProductsReq) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("ListProductsReq"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ListProductsReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("product_ids", thrift.LIST, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteListBegin(thrift.I64, len(p.ProductIds)); err != nil {
+		return err
+	}
+	for _, v := range p.ProductIds {
+		if err := oprot.WriteI64(v); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *ListProductsReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListProductsReq(%+v)", *p)
+
+}
+
+func (p *ListProductsReq) DeepEqual(ano *ListProductsReq) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.ProductIds) {
+		return false
+	}
+	return true
+}
+
+func (p *ListProductsReq) Field1DeepEqual(src []int64) bool {
+
+	if len(p.ProductIds) != len(src) {
+		return false
+	}
+	for i, v := range p.ProductIds {
+		_src := src[i]
+		if v != _src {
+			return false
+		}
+	}
+	return true
+}
+
+type ListSkusReq struct {
+	SkuIds []int64 `thrift:"sku_ids,1,required" frugal:"1,required,list<i64>" json:"sku_ids"`
+}
+
+func NewListSkusReq() *ListSkusReq {
+	return &ListSkusReq{}
+}
+
+func (p *ListSkusReq) InitDefault() {
+}
+
+func (p *ListSkusReq) GetSkuIds() (v []int64) {
+	return p.SkuIds
+}
+func (p *ListSkusReq) SetSkuIds(val []int64) {
+	p.SkuIds = val
+}
+
+var fieldIDToName_ListSkusReq = map[int16]string{
+	1: "sku_ids",
+}
+
+func (p *ListSkusReq) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+	var issetSkuIds bool = false
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+				issetSkuIds = true
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	if !issetSkuIds {
+		fieldId = 1
+		goto RequiredFieldNotSetError
+	}
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ListSkusReq[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+RequiredFieldNotSetError:
+	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_ListSkusReq[fieldId]))
+}
+
+func (p *ListSkusReq) ReadField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]int64, 0, size)
+	for i := 0; i < size; i++ {
+
+		var _elem int64
+		if v, err := iprot.ReadI64(); err != nil {
+			return err
+		} else {
+			_elem = v
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
+	}
+	p.SkuIds = _field
+	return nil
+}
+
+func (p *ListSkusReq) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("ListSkusReq"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ListSkusReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("sku_ids", thrift.LIST, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := oprot.WriteListBegin(thrift.I64, len(p.SkuIds)); err != nil {
+		return err
+	}
+	for _, v := range p.SkuIds {
+		if err := oprot.WriteI64(v); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *ListSkusReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListSkusReq(%+v)", *p)
+
+}
+
+func (p *ListSkusReq) DeepEqual(ano *ListSkusReq) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.SkuIds) {
+		return false
+	}
+	return true
+}
+
+func (p *ListSkusReq) Field1DeepEqual(src []int64) bool {
+
+	if len(p.SkuIds) != len(src) {
+		return false
+	}
+	for i, v := range p.SkuIds {
+		_src := src[i]
+		if v != _src {
+			return false
+		}
+	}
+	return true
+}
+
+type ProductService interface {
+	GetProduct(ctx context.Context, req *GetProductReq) (r *ProductInfo, err error)
+
+	ListProducts(ctx context.Context, req *ListProductsReq) (r []*ProductInfo, err error)
+
+	SearchProducts(ctx context.Context, req *SearchProductsReq) (r *SearchProductsResp, err error)
+
+	ListSkus(ctx context.Context, req *ListSkusReq) (r []*SkuInfo, err error)
+
+	DecreaseStock(ctx context.Context, req *DecreaseStockReq) (r bool, err error)
+
+	ReleaseStock(ctx context.Context, req *ReleaseStockReq) (r bool, err error)
+}
+
+type ProductServiceGetProductArgs struct {
+	Req *GetProductReq `thrift:"req,1" frugal:"1,default,GetProductReq" json:"req"`
+}
+
+func NewProductServiceGetProductArgs() *ProductServiceGetProductArgs {
+	return &ProductServiceGetProductArgs{}
+}
+
+func (p *ProductServiceGetProductArgs) InitDefault() {
+}
+
+var ProductServiceGetProductArgs_Req_DEFAULT *GetProductReq
+
+func (p *ProductServiceGetProductArgs) GetReq() (v *GetProductReq) {
+	if !p.IsSetReq() {
+		return ProductServiceGetProductArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceGetProductArgs) SetReq(val *GetProductReq) {
+	p.Req = val
+}
+
+var fieldIDToName_ProductServiceGetProductArgs = map[int16]string{
+	1: "req",
+}
+
+func (p *ProductServiceGetProductArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceGetProductArgs) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceGetProductArgs[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *ProductServiceGetProductArgs) ReadField1(iprot thrift.TProtocol) error {
+	_field := NewGetProductReq()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Req = _field
+	return nil
+}
+
+func (p *ProductServiceGetProductArgs) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("GetProduct_args"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ProductServiceGetProductArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *ProductServiceGetProductArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceGetProductArgs(%+v)", *p)
+
+}
+
+func (p *ProductServiceGetProductArgs) DeepEqual(ano *ProductServiceGetProductArgs) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.Req) {
+		return false
+	}
+	return true
+}
+
+func (p *ProductServiceGetProductArgs) Field1DeepEqual(src *GetProductReq) bool {
+
+	if !p.Req.DeepEqual(src) {
+		return false
+	}
+	return true
+}
+
+type ProductServiceGetProductResult struct {
+	Success *ProductInfo `thrift:"success,0,optional" frugal:"0,optional,ProductInfo" json:"success,omitempty"`
+}
+
+func NewProductServiceGetProductResult() *ProductServiceGetProductResult {
+	return &ProductServiceGetProductResult{}
+}
+
+func (p *ProductServiceGetProductResult) InitDefault() {
+}
+
+var ProductServiceGetProductResult_Success_DEFAULT *ProductInfo
+
+func (p *ProductServiceGetProductResult) GetSuccess() (v *ProductInfo) {
+	if !p.IsSetSuccess() {
+		return ProductServiceGetProductResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ProductServiceGetProductResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ProductInfo)
+}
+
+var fieldIDToName_ProductServiceGetProductResult = map[int16]string{
+	0: "success",
+}
+
+func (p *ProductServiceGetProductResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ProductServiceGetProductResult) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField0(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceGetProductResult[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *ProductServiceGetProductResult) ReadField0(iprot thrift.TProtocol) error {
+	_field := NewProductInfo()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Success = _field
+	return nil
+}
+
+func (p *ProductServiceGetProductResult) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("GetProduct_result"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField0(oprot); err != nil {
+			fieldId = 0
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ProductServiceGetProductResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err = oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 end error: ", p), err)
+}
+
+func (p *ProductServiceGetProductResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceGetProductResult(%+v)", *p)
+
+}
+
+func (p *ProductServiceGetProductResult) DeepEqual(ano *ProductServiceGetProductResult) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field0DeepEqual(ano.Success) {
+		return false
+	}
+	return true
+}
+
+func (p *ProductServiceGetProductResult) Field0DeepEqual(src *ProductInfo) bool {
+
+	if !p.Success.DeepEqual(src) {
+		return false
+	}
+	return true
+}
+
+type ProductServiceListProductsArgs struct {
+	Req *ListProductsReq `thrift:"req,1" frugal:"1,default,ListProductsReq" json:"req"`
+}
+
+func NewProductServiceListProductsArgs() *ProductServiceListProductsArgs {
+	return &ProductServiceListProductsArgs{}
+}
+
+func (p *ProductServiceListProductsArgs) InitDefault() {
+}
+
+var ProductServiceListProductsArgs_Req_DEFAULT *ListProductsReq
+
+func (p *ProductServiceListProductsArgs) GetReq() (v *ListProductsReq) {
+	if !p.IsSetReq() {
+		return ProductServiceListProductsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceListProductsArgs) SetReq(val *ListProductsReq) {
+	p.Req = val
+}
+
+var fieldIDToName_ProductServiceListProductsArgs = map[int16]string{
+	1: "req",
+}
+
+func (p *ProductServiceListProductsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceListProductsArgs) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceListProductsArgs[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *ProductServiceListProductsArgs) ReadField1(iprot thrift.TProtocol) error {
+	_field := NewListProductsReq()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Req = _field
+	return nil
+}
+
+func (p *ProductServiceListProductsArgs) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("ListProducts_args"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ProductServiceListProductsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *ProductServiceListProductsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceListProductsArgs(%+v)", *p)
+
+}
+
+func (p *ProductServiceListProductsArgs) DeepEqual(ano *ProductServiceListProductsArgs) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.Req) {
+		return false
+	}
+	return true
+}
+
+func (p *ProductServiceListProductsArgs) Field1DeepEqual(src *ListProductsReq) bool {
+
+	if !p.Req.DeepEqual(src) {
+		return false
+	}
+	return true
+}
+
+type ProductServiceListProductsResult struct {
+	Success []*ProductInfo `thrift:"success,0,optional" frugal:"0,optional,list<ProductInfo>" json:"success,omitempty"`
+}
+
+func NewProductServiceListProductsResult() *ProductServiceListProductsResult {
+	return &ProductServiceListProductsResult{}
+}
+
+func (p *ProductServiceListProductsResult) InitDefault() {
+}
+
+var ProductServiceListProductsResult_Success_DEFAULT []*ProductInfo
+
+func (p *ProductServiceListProductsResult) GetSuccess() (v []*ProductInfo) {
+	if !p.IsSetSuccess() {
+		return ProductServiceListProductsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ProductServiceListProductsResult) SetSuccess(x interface{}) {
+	p.Success = x.([]*ProductInfo)
+}
+
+var fieldIDToName_ProductServiceListProductsResult = map[int16]string{
+	0: "success",
+}
+
+func (p *ProductServiceListProductsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ProductServiceListProductsResult) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField0(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceListProductsResult[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *ProductServiceListProductsResult) ReadField0(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return err
+	}
+	_field := make([]*ProductInfo, 0, size)
+	values := make([]ProductInfo, size)
+	for i := 0; i < size; i++ {
+		_elem := &values[i]
+		_elem.InitDefault()
+
+		if err := _elem.Read(iprot); err != nil {
+			return err
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return err
+	}
+	p.Success = _field
+	return nil
+}
+
+func (p *ProductServiceListProductsResult) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("ListProducts_result"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField0(oprot); err != nil {
+			fieldId = 0
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ProductServiceListProductsResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err = oprot.WriteFieldBegin("success", thrift.LIST, 0); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Success)); err != nil {
+			return err
+		}
+		for _, v := range p.Success {
+			if err := v.Write(oprot); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 end error: ", p), err)
+}
+
+func (p *ProductServiceListProductsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceListProductsResult(%+v)", *p)
+
+}
+
+func (p *ProductServiceListProductsResult) DeepEqual(ano *ProductServiceListProductsResult) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field0DeepEqual(ano.Success) {
+		return false
+	}
+	return true
+}
+
+func (p *ProductServiceListProductsResult) Field0DeepEqual(src []*ProductInfo) bool {
+
+	if len(p.Success) != len(src) {
+		return false
+	}
+	for i, v := range p.Success {
+		_src := src[i]
+		if !v.DeepEqual(_src) {
+			return false
+		}
+	}
+	return true
+}
+
+type ProductServiceSearchProductsArgs struct {
+	Req *SearchProductsReq `thrift:"req,1" frugal:"1,default,SearchProductsReq" json:"req"`
+}
+
+func NewProductServiceSearchProductsArgs() *ProductServiceSearchProductsArgs {
+	return &ProductServiceSearchProductsArgs{}
+}
+
+func (p *ProductServiceSearchProductsArgs) InitDefault() {
+}
+
+var ProductServiceSearchProductsArgs_Req_DEFAULT *SearchProductsReq
+
+func (p *ProductServiceSearchProductsArgs) GetReq() (v *SearchProductsReq) {
+	if !p.IsSetReq() {
+		return ProductServiceSearchProductsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceSearchProductsArgs) SetReq(val *SearchProductsReq) {
+	p.Req = val
+}
+
+var fieldIDToName_ProductServiceSearchProductsArgs = map[int16]string{
+	1: "req",
+}
+
+func (p *ProductServiceSearchProductsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceSearchProductsArgs) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 1:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField1(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceSearchProductsArgs[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *ProductServiceSearchProductsArgs) ReadField1(iprot thrift.TProtocol) error {
+	_field := NewSearchProductsReq()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Req = _field
+	return nil
+}
+
+func (p *ProductServiceSearchProductsArgs) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("SearchProducts_args"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField1(oprot); err != nil {
+			fieldId = 1
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ProductServiceSearchProductsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		goto WriteFieldBeginError
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return err
+	}
+	if err = oprot.WriteFieldEnd(); err != nil {
+		goto WriteFieldEndError
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
+}
+
+func (p *ProductServiceSearchProductsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceSearchProductsArgs(%+v)", *p)
+
+}
+
+func (p *ProductServiceSearchProductsArgs) DeepEqual(ano *ProductServiceSearchProductsArgs) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field1DeepEqual(ano.Req) {
+		return false
+	}
+	return true
+}
+
+func (p *ProductServiceSearchProductsArgs) Field1DeepEqual(src *SearchProductsReq) bool {
+
+	if !p.Req.DeepEqual(src) {
+		return false
+	}
+	return true
+}
+
+type ProductServiceSearchProductsResult struct {
+	Success *SearchProductsResp `thrift:"success,0,optional" frugal:"0,optional,SearchProductsResp" json:"success,omitempty"`
 }
 
-func (p *GetProductReq) Read(iprot thrift.TProtocol) (err error) {
+func NewProductServiceSearchProductsResult() *ProductServiceSearchProductsResult {
+	return &ProductServiceSearchProductsResult{}
+}
+
+func (p *ProductServiceSearchProductsResult) InitDefault() {
+}
+
+var ProductServiceSearchProductsResult_Success_DEFAULT *SearchProductsResp
+
+func (p *ProductServiceSearchProductsResult) GetSuccess() (v *SearchProductsResp) {
+	if !p.IsSetSuccess() {
+		return ProductServiceSearchProductsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *ProductServiceSearchProductsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SearchProductsResp)
+}
+
+var fieldIDToName_ProductServiceSearchProductsResult = map[int16]string{
+	0: "success",
+}
+
+func (p *ProductServiceSearchProductsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ProductServiceSearchProductsResult) Read(iprot thrift.TProtocol) (err error) {
+
+	var fieldTypeId thrift.TType
+	var fieldId int16
+
+	if _, err = iprot.ReadStructBegin(); err != nil {
+		goto ReadStructBeginError
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err = iprot.ReadFieldBegin()
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+
+		switch fieldId {
+		case 0:
+			if fieldTypeId == thrift.STRUCT {
+				if err = p.ReadField0(iprot); err != nil {
+					goto ReadFieldError
+				}
+			} else if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		default:
+			if err = iprot.Skip(fieldTypeId); err != nil {
+				goto SkipFieldError
+			}
+		}
+		if err = iprot.ReadFieldEnd(); err != nil {
+			goto ReadFieldEndError
+		}
+	}
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
+	}
+
+	return nil
+ReadStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
+ReadFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
+ReadFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceSearchProductsResult[fieldId]), err)
+SkipFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
+
+ReadFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
+ReadStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+}
+
+func (p *ProductServiceSearchProductsResult) ReadField0(iprot thrift.TProtocol) error {
+	_field := NewSearchProductsResp()
+	if err := _field.Read(iprot); err != nil {
+		return err
+	}
+	p.Success = _field
+	return nil
+}
+
+func (p *ProductServiceSearchProductsResult) Write(oprot thrift.TProtocol) (err error) {
+
+	var fieldId int16
+	if err = oprot.WriteStructBegin("SearchProducts_result"); err != nil {
+		goto WriteStructBeginError
+	}
+	if p != nil {
+		if err = p.writeField0(oprot); err != nil {
+			fieldId = 0
+			goto WriteFieldError
+		}
+	}
+	if err = oprot.WriteFieldStop(); err != nil {
+		goto WriteFieldStopError
+	}
+	if err = oprot.WriteStructEnd(); err != nil {
+		goto WriteStructEndError
+	}
+	return nil
+WriteStructBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+WriteFieldError:
+	return thrift.PrependError(fmt.Sprintf("%T write field %d error: ", p, fieldId), err)
+WriteFieldStopError:
+	return thrift.PrependError(fmt.Sprintf("%T write field stop error: ", p), err)
+WriteStructEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
+}
+
+func (p *ProductServiceSearchProductsResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err = oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
+	}
+	return nil
+WriteFieldBeginError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 begin error: ", p), err)
+WriteFieldEndError:
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 end error: ", p), err)
+}
+
+func (p *ProductServiceSearchProductsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ProductServiceSearchProductsResult(%+v)", *p)
+
+}
+
+func (p *ProductServiceSearchProductsResult) DeepEqual(ano *ProductServiceSearchProductsResult) bool {
+	if p == ano {
+		return true
+	} else if p == nil || ano == nil {
+		return false
+	}
+	if !p.Field0DeepEqual(ano.Success) {
+		return false
+	}
+	return true
+}
+
+func (p *ProductServiceSearchProductsResult) Field0DeepEqual(src *SearchProductsResp) bool {
+
+	if !p.Success.DeepEqual(src) {
+		return false
+	}
+	return true
+}
+
+type ProductServiceListSkusArgs struct {
+	Req *ListSkusReq `thrift:"req,1" frugal:"1,default,ListSkusReq" json:"req"`
+}
+
+func NewProductServiceListSkusArgs() *ProductServiceListSkusArgs {
+	return &ProductServiceListSkusArgs{}
+}
+
+func (p *ProductServiceListSkusArgs) InitDefault() {
+}
+
+var ProductServiceListSkusArgs_Req_DEFAULT *ListSkusReq
+
+func (p *ProductServiceListSkusArgs) GetReq() (v *ListSkusReq) {
+	if !p.IsSetReq() {
+		return ProductServiceListSkusArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *ProductServiceListSkusArgs) SetReq(val *ListSkusReq) {
+	p.Req = val
+}
+
+var fieldIDToName_ProductServiceListSkusArgs = map[int16]string{
+	1: "req",
+}
+
+func (p *ProductServiceListSkusArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ProductServiceListSkusArgs) Read(iprot thrift.TProtocol) (err error) {
 
 	var fieldTypeId thrift.TType
 	var fieldId int16
-	var issetProductId bool = false
 
 	if _, err = iprot.ReadStructBegin(); err != nil {
 		goto ReadStructBeginError
@@ -422,11 +4892,10 @@ func (p *GetProductReq) Read(iprot thrift.TProtocol) (err error) {
 
 		switch fieldId {
 		case 1:
-			if fieldTypeId == thrift.I64 {
+			if fieldTypeId == thrift.STRUCT {
 				if err = p.ReadField1(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetProductId = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
@@ -443,17 +4912,13 @@ func (p *GetProductReq) Read(iprot thrift.TProtocol) (err error) {
 		goto ReadStructEndError
 	}
 
-	if !issetProductId {
-		fieldId = 1
-		goto RequiredFieldNotSetError
-	}
 	return nil
 ReadStructBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
 ReadFieldBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
 ReadFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_GetProductReq[fieldId]), err)
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceListSkusArgs[fieldId]), err)
 SkipFieldError:
 	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
 
@@ -461,26 +4926,21 @@ ReadFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
 ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-RequiredFieldNotSetError:
-	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_GetProductReq[fieldId]))
 }
 
-func (p *GetProductReq) ReadField1(iprot thrift.TProtocol) error {
-
-	var _field int64
-	if v, err := iprot.ReadI64(); err != nil {
+func (p *ProductServiceListSkusArgs) ReadField1(iprot thrift.TProtocol) error {
+	_field := NewListSkusReq()
+	if err := _field.Read(iprot); err != nil {
 		return err
-	} else {
-		_field = v
 	}
-	p.ProductId = _field
+	p.Req = _field
 	return nil
 }
 
-func (p *GetProductReq) Write(oprot thrift.TProtocol) (err error) {
+func (p *ProductServiceListSkusArgs) Write(oprot thrift.TProtocol) (err error) {
 
 	var fieldId int16
-	if err = oprot.WriteStructBegin("GetProductReq"); err != nil {
+	if err = oprot.WriteStructBegin("ListSkus_args"); err != nil {
 		goto WriteStructBeginError
 	}
 	if p != nil {
@@ -506,11 +4966,11 @@ WriteStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
 
-func (p *GetProductReq) writeField1(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("product_id", thrift.I64, 1); err != nil {
+func (p *ProductServiceListSkusArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err = oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		goto WriteFieldBeginError
 	}
-	if err := oprot.WriteI64(p.ProductId); err != nil {
+	if err := p.Req.Write(oprot); err != nil {
 		return err
 	}
 	if err = oprot.WriteFieldEnd(); err != nil {
@@ -523,71 +4983,69 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
 }
 
-func (p *GetProductReq) String() string {
+func (p *ProductServiceListSkusArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GetProductReq(%+v)", *p)
+	return fmt.Sprintf("ProductServiceListSkusArgs(%+v)", *p)
 
 }
 
-func (p *GetProductReq) DeepEqual(ano *GetProductReq) bool {
+func (p *ProductServiceListSkusArgs) DeepEqual(ano *ProductServiceListSkusArgs) bool {
 	if p == ano {
 		return true
 	} else if p == nil || ano == nil {
 		return false
 	}
-	if !p.Field1DeepEqual(ano.ProductId) {
+	if !p.Field1DeepEqual(ano.Req) {
 		return false
 	}
 	return true
 }
 
-func (p *GetProductReq) Field1DeepEqual(src int64) bool {
+func (p *ProductServiceListSkusArgs) Field1DeepEqual(src *ListSkusReq) bool {
 
-	if p.ProductId != src {
+	if !p.Req.DeepEqual(src) {
 		return false
 	}
 	return true
 }
 
-type DecreaseStockReq struct {
-	ProductId int64 `thrift:"product_id,1,required" frugal:"1,required,i64" json:"product_id"`
-	Quantity  int32 `thrift:"quantity,2,required" frugal:"2,required,i32" json:"quantity"`
+type ProductServiceListSkusResult struct {
+	Success []*SkuInfo `thrift:"success,0,optional" frugal:"0,optional,list<SkuInfo>" json:"success,omitempty"`
 }
 
-func NewDecreaseStockReq() *DecreaseStockReq {
-	return &DecreaseStockReq{}
+func NewProductServiceListSkusResult() *ProductServiceListSkusResult {
+	return &ProductServiceListSkusResult{}
 }
 
-func (p *DecreaseStockReq) InitDefault() {
+func (p *ProductServiceListSkusResult) InitDefault() {
 }
 
-func (p *DecreaseStockReq) GetProductId() (v int64) {
-	return p.ProductId
-}
+var ProductServiceListSkusResult_Success_DEFAULT []*SkuInfo
 
-func (p *DecreaseStockReq) GetQuantity() (v int32) {
-	return p.Quantity
+func (p *ProductServiceListSkusResult) GetSuccess() (v []*SkuInfo) {
+	if !p.IsSetSuccess() {
+		return ProductServiceListSkusResult_Success_DEFAULT
+	}
+	return p.Success
 }
-func (p *DecreaseStockReq) SetProductId(val int64) {
-	p.ProductId = val
+func (p *ProductServiceListSkusResult) SetSuccess(x interface{}) {
+	p.Success = x.([]*SkuInfo)
 }
-func (p *DecreaseStockReq) SetQuantity(val int32) {
-	p.Quantity = val
+
+var fieldIDToName_ProductServiceListSkusResult = map[int16]string{
+	0: "success",
 }
 
-var fieldIDToName_DecreaseStockReq = map[int16]string{
-	1: "product_id",
-	2: "quantity",
+func (p *ProductServiceListSkusResult) IsSetSuccess() bool {
+	return p.Success != nil
 }
 
-func (p *DecreaseStockReq) Read(iprot thrift.TProtocol) (err error) {
+func (p *ProductServiceListSkusResult) Read(iprot thrift.TProtocol) (err error) {
 
 	var fieldTypeId thrift.TType
 	var fieldId int16
-	var issetProductId bool = false
-	var issetQuantity bool = false
 
 	if _, err = iprot.ReadStructBegin(); err != nil {
 		goto ReadStructBeginError
@@ -603,21 +5061,11 @@ func (p *DecreaseStockReq) Read(iprot thrift.TProtocol) (err error) {
 		}
 
 		switch fieldId {
-		case 1:
-			if fieldTypeId == thrift.I64 {
-				if err = p.ReadField1(iprot); err != nil {
-					goto ReadFieldError
-				}
-				issetProductId = true
-			} else if err = iprot.Skip(fieldTypeId); err != nil {
-				goto SkipFieldError
-			}
-		case 2:
-			if fieldTypeId == thrift.I32 {
-				if err = p.ReadField2(iprot); err != nil {
+		case 0:
+			if fieldTypeId == thrift.LIST {
+				if err = p.ReadField0(iprot); err != nil {
 					goto ReadFieldError
 				}
-				issetQuantity = true
 			} else if err = iprot.Skip(fieldTypeId); err != nil {
 				goto SkipFieldError
 			}
@@ -630,26 +5078,17 @@ func (p *DecreaseStockReq) Read(iprot thrift.TProtocol) (err error) {
 			goto ReadFieldEndError
 		}
 	}
-	if err = iprot.ReadStructEnd(); err != nil {
-		goto ReadStructEndError
-	}
-
-	if !issetProductId {
-		fieldId = 1
-		goto RequiredFieldNotSetError
+	if err = iprot.ReadStructEnd(); err != nil {
+		goto ReadStructEndError
 	}
 
-	if !issetQuantity {
-		fieldId = 2
-		goto RequiredFieldNotSetError
-	}
 	return nil
 ReadStructBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct begin error: ", p), err)
 ReadFieldBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
 ReadFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_DecreaseStockReq[fieldId]), err)
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceListSkusResult[fieldId]), err)
 SkipFieldError:
 	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
 
@@ -657,46 +5096,41 @@ ReadFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read field end error", p), err)
 ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-RequiredFieldNotSetError:
-	return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("required field %s is not set", fieldIDToName_DecreaseStockReq[fieldId]))
 }
 
-func (p *DecreaseStockReq) ReadField1(iprot thrift.TProtocol) error {
-
-	var _field int64
-	if v, err := iprot.ReadI64(); err != nil {
+func (p *ProductServiceListSkusResult) ReadField0(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
 		return err
-	} else {
-		_field = v
 	}
-	p.ProductId = _field
-	return nil
-}
-func (p *DecreaseStockReq) ReadField2(iprot thrift.TProtocol) error {
+	_field := make([]*SkuInfo, 0, size)
+	values := make([]SkuInfo, size)
+	for i := 0; i < size; i++ {
+		_elem := &values[i]
+		_elem.InitDefault()
 
-	var _field int32
-	if v, err := iprot.ReadI32(); err != nil {
+		if err := _elem.Read(iprot); err != nil {
+			return err
+		}
+
+		_field = append(_field, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
 		return err
-	} else {
-		_field = v
 	}
-	p.Quantity = _field
+	p.Success = _field
 	return nil
 }
 
-func (p *DecreaseStockReq) Write(oprot thrift.TProtocol) (err error) {
+func (p *ProductServiceListSkusResult) Write(oprot thrift.TProtocol) (err error) {
 
 	var fieldId int16
-	if err = oprot.WriteStructBegin("DecreaseStockReq"); err != nil {
+	if err = oprot.WriteStructBegin("ListSkus_result"); err != nil {
 		goto WriteStructBeginError
 	}
 	if p != nil {
-		if err = p.writeField1(oprot); err != nil {
-			fieldId = 1
-			goto WriteFieldError
-		}
-		if err = p.writeField2(oprot); err != nil {
-			fieldId = 2
+		if err = p.writeField0(oprot); err != nil {
+			fieldId = 0
 			goto WriteFieldError
 		}
 	}
@@ -717,116 +5151,99 @@ WriteStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
 
-func (p *DecreaseStockReq) writeField1(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("product_id", thrift.I64, 1); err != nil {
-		goto WriteFieldBeginError
-	}
-	if err := oprot.WriteI64(p.ProductId); err != nil {
-		return err
-	}
-	if err = oprot.WriteFieldEnd(); err != nil {
-		goto WriteFieldEndError
-	}
-	return nil
-WriteFieldBeginError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 1 begin error: ", p), err)
-WriteFieldEndError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
-}
-
-func (p *DecreaseStockReq) writeField2(oprot thrift.TProtocol) (err error) {
-	if err = oprot.WriteFieldBegin("quantity", thrift.I32, 2); err != nil {
-		goto WriteFieldBeginError
-	}
-	if err := oprot.WriteI32(p.Quantity); err != nil {
-		return err
-	}
-	if err = oprot.WriteFieldEnd(); err != nil {
-		goto WriteFieldEndError
+func (p *ProductServiceListSkusResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err = oprot.WriteFieldBegin("success", thrift.LIST, 0); err != nil {
+			goto WriteFieldBeginError
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Success)); err != nil {
+			return err
+		}
+		for _, v := range p.Success {
+			if err := v.Write(oprot); err != nil {
+				return err
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return err
+		}
+		if err = oprot.WriteFieldEnd(); err != nil {
+			goto WriteFieldEndError
+		}
 	}
 	return nil
 WriteFieldBeginError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 2 begin error: ", p), err)
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 begin error: ", p), err)
 WriteFieldEndError:
-	return thrift.PrependError(fmt.Sprintf("%T write field 2 end error: ", p), err)
+	return thrift.PrependError(fmt.Sprintf("%T write field 0 end error: ", p), err)
 }
 
-func (p *DecreaseStockReq) String() string {
+func (p *ProductServiceListSkusResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("DecreaseStockReq(%+v)", *p)
+	return fmt.Sprintf("ProductServiceListSkusResult(%+v)", *p)
 
 }
 
-func (p *DecreaseStockReq) DeepEqual(ano *DecreaseStockReq) bool {
+func (p *ProductServiceListSkusResult) DeepEqual(ano *ProductServiceListSkusResult) bool {
 	if p == ano {
 		return true
 	} else if p == nil || ano == nil {
 		return false
 	}
-	if !p.Field1DeepEqual(ano.ProductId) {
-		return false
-	}
-	if !p.Field2DeepEqual(ano.Quantity) {
+	if !p.Field0DeepEqual(ano.Success) {
 		return false
 	}
 	return true
 }
 
-func (p *DecreaseStockReq) Field1DeepEqual(src int64) bool {
+func (p *ProductServiceListSkusResult) Field0DeepEqual(src []*SkuInfo) bool {
 
-	if p.ProductId != src {
+	if len(p.Success) != len(src) {
 		return false
 	}
-	return true
-}
-func (p *DecreaseStockReq) Field2DeepEqual(src int32) bool {
-
-	if p.Quantity != src {
-		return false
+	for i, v := range p.Success {
+		_src := src[i]
+		if !v.DeepEqual(_src) {
+			return false
+		}
 	}
 	return true
 }
 
-type ProductService interface {
-	GetProduct(ctx context.Context, req *GetProductReq) (r *ProductInfo, err error)
-
-	DecreaseStock(ctx context.Context, req *DecreaseStockReq) (r bool, err error)
-}
-
-type ProductServiceGetProductArgs struct {
-	Req *GetProductReq `thrift:"req,1" frugal:"1,default,GetProductReq" json:"req"`
+type ProductServiceDecreaseStockArgs struct {
+	Req *DecreaseStockReq `thrift:"req,1" frugal:"1,default,DecreaseStockReq" json:"req"`
 }
 
-func NewProductServiceGetProductArgs() *ProductServiceGetProductArgs {
-	return &ProductServiceGetProductArgs{}
+func NewProductServiceDecreaseStockArgs() *ProductServiceDecreaseStockArgs {
+	return &ProductServiceDecreaseStockArgs{}
 }
 
-func (p *ProductServiceGetProductArgs) InitDefault() {
+func (p *ProductServiceDecreaseStockArgs) InitDefault() {
 }
 
-var ProductServiceGetProductArgs_Req_DEFAULT *GetProductReq
+var ProductServiceDecreaseStockArgs_Req_DEFAULT *DecreaseStockReq
 
-func (p *ProductServiceGetProductArgs) GetReq() (v *GetProductReq) {
+func (p *ProductServiceDecreaseStockArgs) GetReq() (v *DecreaseStockReq) {
 	if !p.IsSetReq() {
-		return ProductServiceGetProductArgs_Req_DEFAULT
+		return ProductServiceDecreaseStockArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *ProductServiceGetProductArgs) SetReq(val *GetProductReq) {
+func (p *ProductServiceDecreaseStockArgs) SetReq(val *DecreaseStockReq) {
 	p.Req = val
 }
 
-var fieldIDToName_ProductServiceGetProductArgs = map[int16]string{
+var fieldIDToName_ProductServiceDecreaseStockArgs = map[int16]string{
 	1: "req",
 }
 
-func (p *ProductServiceGetProductArgs) IsSetReq() bool {
+func (p *ProductServiceDecreaseStockArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *ProductServiceGetProductArgs) Read(iprot thrift.TProtocol) (err error) {
+func (p *ProductServiceDecreaseStockArgs) Read(iprot thrift.TProtocol) (err error) {
 
 	var fieldTypeId thrift.TType
 	var fieldId int16
@@ -872,7 +5289,7 @@ ReadStructBeginError:
 ReadFieldBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
 ReadFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceGetProductArgs[fieldId]), err)
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceDecreaseStockArgs[fieldId]), err)
 SkipFieldError:
 	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
 
@@ -882,8 +5299,8 @@ ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 }
 
-func (p *ProductServiceGetProductArgs) ReadField1(iprot thrift.TProtocol) error {
-	_field := NewGetProductReq()
+func (p *ProductServiceDecreaseStockArgs) ReadField1(iprot thrift.TProtocol) error {
+	_field := NewDecreaseStockReq()
 	if err := _field.Read(iprot); err != nil {
 		return err
 	}
@@ -891,10 +5308,10 @@ func (p *ProductServiceGetProductArgs) ReadField1(iprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *ProductServiceGetProductArgs) Write(oprot thrift.TProtocol) (err error) {
+func (p *ProductServiceDecreaseStockArgs) Write(oprot thrift.TProtocol) (err error) {
 
 	var fieldId int16
-	if err = oprot.WriteStructBegin("GetProduct_args"); err != nil {
+	if err = oprot.WriteStructBegin("DecreaseStock_args"); err != nil {
 		goto WriteStructBeginError
 	}
 	if p != nil {
@@ -920,7 +5337,7 @@ WriteStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
 
-func (p *ProductServiceGetProductArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *ProductServiceDecreaseStockArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err = oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		goto WriteFieldBeginError
 	}
@@ -937,15 +5354,15 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
 }
 
-func (p *ProductServiceGetProductArgs) String() string {
+func (p *ProductServiceDecreaseStockArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ProductServiceGetProductArgs(%+v)", *p)
+	return fmt.Sprintf("ProductServiceDecreaseStockArgs(%+v)", *p)
 
 }
 
-func (p *ProductServiceGetProductArgs) DeepEqual(ano *ProductServiceGetProductArgs) bool {
+func (p *ProductServiceDecreaseStockArgs) DeepEqual(ano *ProductServiceDecreaseStockArgs) bool {
 	if p == ano {
 		return true
 	} else if p == nil || ano == nil {
@@ -957,7 +5374,7 @@ func (p *ProductServiceGetProductArgs) DeepEqual(ano *ProductServiceGetProductAr
 	return true
 }
 
-func (p *ProductServiceGetProductArgs) Field1DeepEqual(src *GetProductReq) bool {
+func (p *ProductServiceDecreaseStockArgs) Field1DeepEqual(src *DecreaseStockReq) bool {
 
 	if !p.Req.DeepEqual(src) {
 		return false
@@ -965,38 +5382,38 @@ func (p *ProductServiceGetProductArgs) Field1DeepEqual(src *GetProductReq) bool
 	return true
 }
 
-type ProductServiceGetProductResult struct {
-	Success *ProductInfo `thrift:"success,0,optional" frugal:"0,optional,ProductInfo" json:"success,omitempty"`
+type ProductServiceDecreaseStockResult struct {
+	Success *bool `thrift:"success,0,optional" frugal:"0,optional,bool" json:"success,omitempty"`
 }
 
-func NewProductServiceGetProductResult() *ProductServiceGetProductResult {
-	return &ProductServiceGetProductResult{}
+func NewProductServiceDecreaseStockResult() *ProductServiceDecreaseStockResult {
+	return &ProductServiceDecreaseStockResult{}
 }
 
-func (p *ProductServiceGetProductResult) InitDefault() {
+func (p *ProductServiceDecreaseStockResult) InitDefault() {
 }
 
-var ProductServiceGetProductResult_Success_DEFAULT *ProductInfo
+var ProductServiceDecreaseStockResult_Success_DEFAULT bool
 
-func (p *ProductServiceGetProductResult) GetSuccess() (v *ProductInfo) {
+func (p *ProductServiceDecreaseStockResult) GetSuccess() (v bool) {
 	if !p.IsSetSuccess() {
-		return ProductServiceGetProductResult_Success_DEFAULT
+		return ProductServiceDecreaseStockResult_Success_DEFAULT
 	}
-	return p.Success
+	return *p.Success
 }
-func (p *ProductServiceGetProductResult) SetSuccess(x interface{}) {
-	p.Success = x.(*ProductInfo)
+func (p *ProductServiceDecreaseStockResult) SetSuccess(x interface{}) {
+	p.Success = x.(*bool)
 }
 
-var fieldIDToName_ProductServiceGetProductResult = map[int16]string{
+var fieldIDToName_ProductServiceDecreaseStockResult = map[int16]string{
 	0: "success",
 }
 
-func (p *ProductServiceGetProductResult) IsSetSuccess() bool {
+func (p *ProductServiceDecreaseStockResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *ProductServiceGetProductResult) Read(iprot thrift.TProtocol) (err error) {
+func (p *ProductServiceDecreaseStockResult) Read(iprot thrift.TProtocol) (err error) {
 
 	var fieldTypeId thrift.TType
 	var fieldId int16
@@ -1016,7 +5433,7 @@ func (p *ProductServiceGetProductResult) Read(iprot thrift.TProtocol) (err error
 
 		switch fieldId {
 		case 0:
-			if fieldTypeId == thrift.STRUCT {
+			if fieldTypeId == thrift.BOOL {
 				if err = p.ReadField0(iprot); err != nil {
 					goto ReadFieldError
 				}
@@ -1042,7 +5459,7 @@ ReadStructBeginError:
 ReadFieldBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
 ReadFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceGetProductResult[fieldId]), err)
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceDecreaseStockResult[fieldId]), err)
 SkipFieldError:
 	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
 
@@ -1052,19 +5469,22 @@ ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 }
 
-func (p *ProductServiceGetProductResult) ReadField0(iprot thrift.TProtocol) error {
-	_field := NewProductInfo()
-	if err := _field.Read(iprot); err != nil {
+func (p *ProductServiceDecreaseStockResult) ReadField0(iprot thrift.TProtocol) error {
+
+	var _field *bool
+	if v, err := iprot.ReadBool(); err != nil {
 		return err
+	} else {
+		_field = &v
 	}
 	p.Success = _field
 	return nil
 }
 
-func (p *ProductServiceGetProductResult) Write(oprot thrift.TProtocol) (err error) {
+func (p *ProductServiceDecreaseStockResult) Write(oprot thrift.TProtocol) (err error) {
 
 	var fieldId int16
-	if err = oprot.WriteStructBegin("GetProduct_result"); err != nil {
+	if err = oprot.WriteStructBegin("DecreaseStock_result"); err != nil {
 		goto WriteStructBeginError
 	}
 	if p != nil {
@@ -1090,12 +5510,12 @@ WriteStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
 
-func (p *ProductServiceGetProductResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *ProductServiceDecreaseStockResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
-		if err = oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+		if err = oprot.WriteFieldBegin("success", thrift.BOOL, 0); err != nil {
 			goto WriteFieldBeginError
 		}
-		if err := p.Success.Write(oprot); err != nil {
+		if err := oprot.WriteBool(*p.Success); err != nil {
 			return err
 		}
 		if err = oprot.WriteFieldEnd(); err != nil {
@@ -1109,15 +5529,15 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 0 end error: ", p), err)
 }
 
-func (p *ProductServiceGetProductResult) String() string {
+func (p *ProductServiceDecreaseStockResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ProductServiceGetProductResult(%+v)", *p)
+	return fmt.Sprintf("ProductServiceDecreaseStockResult(%+v)", *p)
 
 }
 
-func (p *ProductServiceGetProductResult) DeepEqual(ano *ProductServiceGetProductResult) bool {
+func (p *ProductServiceDecreaseStockResult) DeepEqual(ano *ProductServiceDecreaseStockResult) bool {
 	if p == ano {
 		return true
 	} else if p == nil || ano == nil {
@@ -1129,46 +5549,51 @@ func (p *ProductServiceGetProductResult) DeepEqual(ano *ProductServiceGetProduct
 	return true
 }
 
-func (p *ProductServiceGetProductResult) Field0DeepEqual(src *ProductInfo) bool {
+func (p *ProductServiceDecreaseStockResult) Field0DeepEqual(src *bool) bool {
 
-	if !p.Success.DeepEqual(src) {
+	if p.Success == src {
+		return true
+	} else if p.Success == nil || src == nil {
+		return false
+	}
+	if *p.Success != *src {
 		return false
 	}
 	return true
 }
 
-type ProductServiceDecreaseStockArgs struct {
-	Req *DecreaseStockReq `thrift:"req,1" frugal:"1,default,DecreaseStockReq" json:"req"`
+type ProductServiceReleaseStockArgs struct {
+	Req *ReleaseStockReq `thrift:"req,1" frugal:"1,default,ReleaseStockReq" json:"req"`
 }
 
-func NewProductServiceDecreaseStockArgs() *ProductServiceDecreaseStockArgs {
-	return &ProductServiceDecreaseStockArgs{}
+func NewProductServiceReleaseStockArgs() *ProductServiceReleaseStockArgs {
+	return &ProductServiceReleaseStockArgs{}
 }
 
-func (p *ProductServiceDecreaseStockArgs) InitDefault() {
+func (p *ProductServiceReleaseStockArgs) InitDefault() {
 }
 
-var ProductServiceDecreaseStockArgs_Req_DEFAULT *DecreaseStockReq
+var ProductServiceReleaseStockArgs_Req_DEFAULT *ReleaseStockReq
 
-func (p *ProductServiceDecreaseStockArgs) GetReq() (v *DecreaseStockReq) {
+func (p *ProductServiceReleaseStockArgs) GetReq() (v *ReleaseStockReq) {
 	if !p.IsSetReq() {
-		return ProductServiceDecreaseStockArgs_Req_DEFAULT
+		return ProductServiceReleaseStockArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *ProductServiceDecreaseStockArgs) SetReq(val *DecreaseStockReq) {
+func (p *ProductServiceReleaseStockArgs) SetReq(val *ReleaseStockReq) {
 	p.Req = val
 }
 
-var fieldIDToName_ProductServiceDecreaseStockArgs = map[int16]string{
+var fieldIDToName_ProductServiceReleaseStockArgs = map[int16]string{
 	1: "req",
 }
 
-func (p *ProductServiceDecreaseStockArgs) IsSetReq() bool {
+func (p *ProductServiceReleaseStockArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *ProductServiceDecreaseStockArgs) Read(iprot thrift.TProtocol) (err error) {
+func (p *ProductServiceReleaseStockArgs) Read(iprot thrift.TProtocol) (err error) {
 
 	var fieldTypeId thrift.TType
 	var fieldId int16
@@ -1214,7 +5639,7 @@ ReadStructBeginError:
 ReadFieldBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
 ReadFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceDecreaseStockArgs[fieldId]), err)
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceReleaseStockArgs[fieldId]), err)
 SkipFieldError:
 	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
 
@@ -1224,8 +5649,8 @@ ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 }
 
-func (p *ProductServiceDecreaseStockArgs) ReadField1(iprot thrift.TProtocol) error {
-	_field := NewDecreaseStockReq()
+func (p *ProductServiceReleaseStockArgs) ReadField1(iprot thrift.TProtocol) error {
+	_field := NewReleaseStockReq()
 	if err := _field.Read(iprot); err != nil {
 		return err
 	}
@@ -1233,10 +5658,10 @@ func (p *ProductServiceDecreaseStockArgs) ReadField1(iprot thrift.TProtocol) err
 	return nil
 }
 
-func (p *ProductServiceDecreaseStockArgs) Write(oprot thrift.TProtocol) (err error) {
+func (p *ProductServiceReleaseStockArgs) Write(oprot thrift.TProtocol) (err error) {
 
 	var fieldId int16
-	if err = oprot.WriteStructBegin("DecreaseStock_args"); err != nil {
+	if err = oprot.WriteStructBegin("ReleaseStock_args"); err != nil {
 		goto WriteStructBeginError
 	}
 	if p != nil {
@@ -1262,7 +5687,7 @@ WriteStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
 
-func (p *ProductServiceDecreaseStockArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *ProductServiceReleaseStockArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err = oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		goto WriteFieldBeginError
 	}
@@ -1279,15 +5704,15 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 1 end error: ", p), err)
 }
 
-func (p *ProductServiceDecreaseStockArgs) String() string {
+func (p *ProductServiceReleaseStockArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ProductServiceDecreaseStockArgs(%+v)", *p)
+	return fmt.Sprintf("ProductServiceReleaseStockArgs(%+v)", *p)
 
 }
 
-func (p *ProductServiceDecreaseStockArgs) DeepEqual(ano *ProductServiceDecreaseStockArgs) bool {
+func (p *ProductServiceReleaseStockArgs) DeepEqual(ano *ProductServiceReleaseStockArgs) bool {
 	if p == ano {
 		return true
 	} else if p == nil || ano == nil {
@@ -1299,7 +5724,7 @@ func (p *ProductServiceDecreaseStockArgs) DeepEqual(ano *ProductServiceDecreaseS
 	return true
 }
 
-func (p *ProductServiceDecreaseStockArgs) Field1DeepEqual(src *DecreaseStockReq) bool {
+func (p *ProductServiceReleaseStockArgs) Field1DeepEqual(src *ReleaseStockReq) bool {
 
 	if !p.Req.DeepEqual(src) {
 		return false
@@ -1307,38 +5732,38 @@ func (p *ProductServiceDecreaseStockArgs) Field1DeepEqual(src *DecreaseStockReq)
 	return true
 }
 
-type ProductServiceDecreaseStockResult struct {
+type ProductServiceReleaseStockResult struct {
 	Success *bool `thrift:"success,0,optional" frugal:"0,optional,bool" json:"success,omitempty"`
 }
 
-func NewProductServiceDecreaseStockResult() *ProductServiceDecreaseStockResult {
-	return &ProductServiceDecreaseStockResult{}
+func NewProductServiceReleaseStockResult() *ProductServiceReleaseStockResult {
+	return &ProductServiceReleaseStockResult{}
 }
 
-func (p *ProductServiceDecreaseStockResult) InitDefault() {
+func (p *ProductServiceReleaseStockResult) InitDefault() {
 }
 
-var ProductServiceDecreaseStockResult_Success_DEFAULT bool
+var ProductServiceReleaseStockResult_Success_DEFAULT bool
 
-func (p *ProductServiceDecreaseStockResult) GetSuccess() (v bool) {
+func (p *ProductServiceReleaseStockResult) GetSuccess() (v bool) {
 	if !p.IsSetSuccess() {
-		return ProductServiceDecreaseStockResult_Success_DEFAULT
+		return ProductServiceReleaseStockResult_Success_DEFAULT
 	}
 	return *p.Success
 }
-func (p *ProductServiceDecreaseStockResult) SetSuccess(x interface{}) {
+func (p *ProductServiceReleaseStockResult) SetSuccess(x interface{}) {
 	p.Success = x.(*bool)
 }
 
-var fieldIDToName_ProductServiceDecreaseStockResult = map[int16]string{
+var fieldIDToName_ProductServiceReleaseStockResult = map[int16]string{
 	0: "success",
 }
 
-func (p *ProductServiceDecreaseStockResult) IsSetSuccess() bool {
+func (p *ProductServiceReleaseStockResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *ProductServiceDecreaseStockResult) Read(iprot thrift.TProtocol) (err error) {
+func (p *ProductServiceReleaseStockResult) Read(iprot thrift.TProtocol) (err error) {
 
 	var fieldTypeId thrift.TType
 	var fieldId int16
@@ -1384,7 +5809,7 @@ ReadStructBeginError:
 ReadFieldBeginError:
 	return thrift.PrependError(fmt.Sprintf("%T read field %d begin error: ", p, fieldId), err)
 ReadFieldError:
-	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceDecreaseStockResult[fieldId]), err)
+	return thrift.PrependError(fmt.Sprintf("%T read field %d '%s' error: ", p, fieldId, fieldIDToName_ProductServiceReleaseStockResult[fieldId]), err)
 SkipFieldError:
 	return thrift.PrependError(fmt.Sprintf("%T field %d skip type %d error: ", p, fieldId, fieldTypeId), err)
 
@@ -1394,7 +5819,7 @@ ReadStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 }
 
-func (p *ProductServiceDecreaseStockResult) ReadField0(iprot thrift.TProtocol) error {
+func (p *ProductServiceReleaseStockResult) ReadField0(iprot thrift.TProtocol) error {
 
 	var _field *bool
 	if v, err := iprot.ReadBool(); err != nil {
@@ -1406,10 +5831,10 @@ func (p *ProductServiceDecreaseStockResult) ReadField0(iprot thrift.TProtocol) e
 	return nil
 }
 
-func (p *ProductServiceDecreaseStockResult) Write(oprot thrift.TProtocol) (err error) {
+func (p *ProductServiceReleaseStockResult) Write(oprot thrift.TProtocol) (err error) {
 
 	var fieldId int16
-	if err = oprot.WriteStructBegin("DecreaseStock_result"); err != nil {
+	if err = oprot.WriteStructBegin("ReleaseStock_result"); err != nil {
 		goto WriteStructBeginError
 	}
 	if p != nil {
@@ -1435,7 +5860,7 @@ WriteStructEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write struct end error: ", p), err)
 }
 
-func (p *ProductServiceDecreaseStockResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *ProductServiceReleaseStockResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err = oprot.WriteFieldBegin("success", thrift.BOOL, 0); err != nil {
 			goto WriteFieldBeginError
@@ -1454,15 +5879,15 @@ WriteFieldEndError:
 	return thrift.PrependError(fmt.Sprintf("%T write field 0 end error: ", p), err)
 }
 
-func (p *ProductServiceDecreaseStockResult) String() string {
+func (p *ProductServiceReleaseStockResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ProductServiceDecreaseStockResult(%+v)", *p)
+	return fmt.Sprintf("ProductServiceReleaseStockResult(%+v)", *p)
 
 }
 
-func (p *ProductServiceDecreaseStockResult) DeepEqual(ano *ProductServiceDecreaseStockResult) bool {
+func (p *ProductServiceReleaseStockResult) DeepEqual(ano *ProductServiceReleaseStockResult) bool {
 	if p == ano {
 		return true
 	} else if p == nil || ano == nil {
@@ -1474,7 +5899,7 @@ func (p *ProductServiceDecreaseStockResult) DeepEqual(ano *ProductServiceDecreas
 	return true
 }
 
-func (p *ProductServiceDecreaseStockResult) Field0DeepEqual(src *bool) bool {
+func (p *ProductServiceReleaseStockResult) Field0DeepEqual(src *bool) bool {
 
 	if p.Success == src {
 		return true
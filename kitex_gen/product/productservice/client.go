@@ -12,7 +12,11 @@ import (
 // Client is designed to provide IDL-compatible methods with call-option parameter for kitex framework.
 type Client interface {
 	GetProduct(ctx context.Context, req *product.GetProductReq, callOptions ...callopt.Option) (r *product.ProductInfo, err error)
+	ListProducts(ctx context.Context, req *product.ListProductsReq, callOptions ...callopt.Option) (r []*product.ProductInfo, err error)
+	SearchProducts(ctx context.Context, req *product.SearchProductsReq, callOptions ...callopt.Option) (r *product.SearchProductsResp, err error)
+	ListSkus(ctx context.Context, req *product.ListSkusReq, callOptions ...callopt.Option) (r []*product.SkuInfo, err error)
 	DecreaseStock(ctx context.Context, req *product.DecreaseStockReq, callOptions ...callopt.Option) (r bool, err error)
+	ReleaseStock(ctx context.Context, req *product.ReleaseStockReq, callOptions ...callopt.Option) (r bool, err error)
 }
 
 // NewClient creates a client for the service defined in IDL.
@@ -49,7 +53,27 @@ func (p *kProductServiceClient) GetProduct(ctx context.Context, req *product.Get
 	return p.kClient.GetProduct(ctx, req)
 }
 
+func (p *kProductServiceClient) ListProducts(ctx context.Context, req *product.ListProductsReq, callOptions ...callopt.Option) (r []*product.ProductInfo, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListProducts(ctx, req)
+}
+
+func (p *kProductServiceClient) SearchProducts(ctx context.Context, req *product.SearchProductsReq, callOptions ...callopt.Option) (r *product.SearchProductsResp, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.SearchProducts(ctx, req)
+}
+
+func (p *kProductServiceClient) ListSkus(ctx context.Context, req *product.ListSkusReq, callOptions ...callopt.Option) (r []*product.SkuInfo, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListSkus(ctx, req)
+}
+
 func (p *kProductServiceClient) DecreaseStock(ctx context.Context, req *product.DecreaseStockReq, callOptions ...callopt.Option) (r bool, err error) {
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.DecreaseStock(ctx, req)
 }
+
+func (p *kProductServiceClient) ReleaseStock(ctx context.Context, req *product.ReleaseStockReq, callOptions ...callopt.Option) (r bool, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ReleaseStock(ctx, req)
+}
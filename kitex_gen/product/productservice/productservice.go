@@ -20,6 +20,27 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"ListProducts": kitex.NewMethodInfo(
+		listProductsHandler,
+		newProductServiceListProductsArgs,
+		newProductServiceListProductsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"SearchProducts": kitex.NewMethodInfo(
+		searchProductsHandler,
+		newProductServiceSearchProductsArgs,
+		newProductServiceSearchProductsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ListSkus": kitex.NewMethodInfo(
+		listSkusHandler,
+		newProductServiceListSkusArgs,
+		newProductServiceListSkusResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 	"DecreaseStock": kitex.NewMethodInfo(
 		decreaseStockHandler,
 		newProductServiceDecreaseStockArgs,
@@ -27,6 +48,13 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"ReleaseStock": kitex.NewMethodInfo(
+		releaseStockHandler,
+		newProductServiceReleaseStockArgs,
+		newProductServiceReleaseStockResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 }
 
 var (
@@ -111,6 +139,60 @@ func newProductServiceGetProductResult() interface{} {
 	return product.NewProductServiceGetProductResult()
 }
 
+func listProductsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceListProductsArgs)
+	realResult := result.(*product.ProductServiceListProductsResult)
+	success, err := handler.(product.ProductService).ListProducts(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newProductServiceListProductsArgs() interface{} {
+	return product.NewProductServiceListProductsArgs()
+}
+
+func newProductServiceListProductsResult() interface{} {
+	return product.NewProductServiceListProductsResult()
+}
+
+func searchProductsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceSearchProductsArgs)
+	realResult := result.(*product.ProductServiceSearchProductsResult)
+	success, err := handler.(product.ProductService).SearchProducts(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newProductServiceSearchProductsArgs() interface{} {
+	return product.NewProductServiceSearchProductsArgs()
+}
+
+func newProductServiceSearchProductsResult() interface{} {
+	return product.NewProductServiceSearchProductsResult()
+}
+
+func listSkusHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceListSkusArgs)
+	realResult := result.(*product.ProductServiceListSkusResult)
+	success, err := handler.(product.ProductService).ListSkus(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newProductServiceListSkusArgs() interface{} {
+	return product.NewProductServiceListSkusArgs()
+}
+
+func newProductServiceListSkusResult() interface{} {
+	return product.NewProductServiceListSkusResult()
+}
+
 func decreaseStockHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
 	realArg := arg.(*product.ProductServiceDecreaseStockArgs)
 	realResult := result.(*product.ProductServiceDecreaseStockResult)
@@ -129,6 +211,24 @@ func newProductServiceDecreaseStockResult() interface{} {
 	return product.NewProductServiceDecreaseStockResult()
 }
 
+func releaseStockHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*product.ProductServiceReleaseStockArgs)
+	realResult := result.(*product.ProductServiceReleaseStockResult)
+	success, err := handler.(product.ProductService).ReleaseStock(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = &success
+	return nil
+}
+func newProductServiceReleaseStockArgs() interface{} {
+	return product.NewProductServiceReleaseStockArgs()
+}
+
+func newProductServiceReleaseStockResult() interface{} {
+	return product.NewProductServiceReleaseStockResult()
+}
+
 type kClient struct {
 	c client.Client
 }
@@ -149,6 +249,36 @@ func (p *kClient) GetProduct(ctx context.Context, req *product.GetProductReq) (r
 	return _result.GetSuccess(), nil
 }
 
+func (p *kClient) ListProducts(ctx context.Context, req *product.ListProductsReq) (r []*product.ProductInfo, err error) {
+	var _args product.ProductServiceListProductsArgs
+	_args.Req = req
+	var _result product.ProductServiceListProductsResult
+	if err = p.c.Call(ctx, "ListProducts", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) SearchProducts(ctx context.Context, req *product.SearchProductsReq) (r *product.SearchProductsResp, err error) {
+	var _args product.ProductServiceSearchProductsArgs
+	_args.Req = req
+	var _result product.ProductServiceSearchProductsResult
+	if err = p.c.Call(ctx, "SearchProducts", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListSkus(ctx context.Context, req *product.ListSkusReq) (r []*product.SkuInfo, err error) {
+	var _args product.ProductServiceListSkusArgs
+	_args.Req = req
+	var _result product.ProductServiceListSkusResult
+	if err = p.c.Call(ctx, "ListSkus", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
 func (p *kClient) DecreaseStock(ctx context.Context, req *product.DecreaseStockReq) (r bool, err error) {
 	var _args product.ProductServiceDecreaseStockArgs
 	_args.Req = req
@@ -158,3 +288,13 @@ func (p *kClient) DecreaseStock(ctx context.Context, req *product.DecreaseStockR
 	}
 	return _result.GetSuccess(), nil
 }
+
+func (p *kClient) ReleaseStock(ctx context.Context, req *product.ReleaseStockReq) (r bool, err error) {
+	var _args product.ProductServiceReleaseStockArgs
+	_args.Req = req
+	var _result product.ProductServiceReleaseStockResult
+	if err = p.c.Call(ctx, "ReleaseStock", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
@@ -12,25 +12,31 @@ import (
 var Conf *Config
 
 type ServiceConfig struct {
-	IP      string `yaml:"ip"`
-	UserHTTPPort    int  `yaml:"user_http_port"`
-	UserRpcPort int `yaml:"user_rpc_port"`
-	ProductHTTPPort    int  `yaml:"product_http_port"`
-	ProductRpcPort int `yaml:"product_rpc_port"`
-	CartHTTPPort    int  `yaml:"cart_http_port"`
-	CartRpcPort int `yaml:"cart_rpc_port"`
-	OrderHTTPPort    int  `yaml:"order_http_port"`
-	OrderRpcPort int `yaml:"order_rpc_port"`
-	PaymentHTTPPort    int  `yaml:"payment_http_port"`
-	PaymentRpcPort int `yaml:"payment_rpc_port"`
+	IP              string `yaml:"ip"`
+	UserHTTPPort    int    `yaml:"user_http_port"`
+	UserRpcPort     int    `yaml:"user_rpc_port"`
+	ProductHTTPPort int    `yaml:"product_http_port"`
+	ProductRpcPort  int    `yaml:"product_rpc_port"`
+	CartHTTPPort    int    `yaml:"cart_http_port"`
+	CartRpcPort     int    `yaml:"cart_rpc_port"`
+	OrderHTTPPort   int    `yaml:"order_http_port"`
+	OrderRpcPort    int    `yaml:"order_rpc_port"`
+	PaymentHTTPPort int    `yaml:"payment_http_port"`
+	PaymentRpcPort  int    `yaml:"payment_rpc_port"`
 }
 
 type Config struct {
-	Redis   RedisConfig   `yaml:"redis"`
-	MySQL   MySQLConfig   `yaml:"mysql"`
-	Consul  ConsulConfig  `yaml:"consul"`
-	JWT     JWTConfig     `yaml:"jwt"`
-	Service ServiceConfig `yaml:"service"`
+	Redis       RedisConfig       `yaml:"redis"`
+	MySQL       MySQLConfig       `yaml:"mysql"`
+	Consul      ConsulConfig      `yaml:"consul"`
+	JWT         JWTConfig         `yaml:"jwt"`
+	Service     ServiceConfig     `yaml:"service"`
+	Cart        CartConfig        `yaml:"cart"`
+	Product     ProductConfig     `yaml:"product"`
+	Order       OrderConfig       `yaml:"order"`
+	Event       EventConfig       `yaml:"event"`
+	Payment     PaymentConfig     `yaml:"payment"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
 }
 
 type RedisConfig struct {
@@ -62,6 +68,45 @@ type JWTConfig struct {
 	Issuer      string `yaml:"issuer"`       // 签发机构
 }
 
+// 购物车配置
+type CartConfig struct {
+	MaxItemQuantity int `yaml:"max_item_quantity"` // 单个商品限购数量，0表示不限
+}
+
+// 商品配置
+type ProductConfig struct {
+	SearchBackend       string `yaml:"search_backend"`         // mysql（默认，FULLTEXT索引）或 memory（进程内倒排索引，仅用于测试与单机开发）
+	CacheTTLSeconds     int    `yaml:"cache_ttl_seconds"`      // 商品详情缓存时间，默认600秒，实际过期时间随机浮动±10%
+	CacheNullTTLSeconds int    `yaml:"cache_null_ttl_seconds"` // 不存在的商品的空值缓存时间，默认60秒
+}
+
+// 订单配置
+type OrderConfig struct {
+	PayTimeoutMinutes int  `yaml:"pay_timeout_minutes"` // 未支付订单自动取消时间
+	AllowCancelPaid   bool `yaml:"allow_cancel_paid"`   // 是否允许用户取消已支付未发货的订单（转入退款）
+}
+
+// 领域事件配置
+type EventConfig struct {
+	Broker string `yaml:"broker"` // 只支持 redis（默认，Redis Streams），各服务独立进程部署，进程内代理无法跨服务投递
+	Stream string `yaml:"stream"` // Redis Streams 的流名称
+}
+
+// 支付配置
+type PaymentConfig struct {
+	Provider           string `yaml:"provider"`             // 支付渠道，目前支持 mock（本地模拟网关）
+	GatewayURL         string `yaml:"gateway_url"`          // 支付页面地址前缀
+	CallbackURL        string `yaml:"callback_url"`         // 支付结果异步通知地址
+	CallbackSecret     string `yaml:"callback_secret"`      // 异步通知签名密钥
+	PollAfterMinutes   int    `yaml:"poll_after_minutes"`   // 待支付记录超过该时间未收到通知时主动查询渠道
+	ExpireAfterMinutes int    `yaml:"expire_after_minutes"` // 待支付记录超过该时间仍未支付则关闭
+}
+
+// 幂等中间件配置
+type IdempotencyConfig struct {
+	LockTTLSeconds int `yaml:"lock_ttl_seconds"` // 处理中状态的过期时间，默认60秒；处理期间按该时间的1/3定期续期
+}
+
 // 其他配置结构体...
 
 func Init() error {
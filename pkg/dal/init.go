@@ -18,8 +18,8 @@ func InitDB() {
 		panic(fmt.Sprintf("数据库连接失败: %v", err))
 	}
 
-	// 自动迁移表结构
-	if err := DB.AutoMigrate(&User{}, &Product{}, &Order{}); err != nil {
+	// 同步表结构并执行数据迁移
+	if err := Migrate(DB); err != nil {
 		panic(fmt.Sprintf("数据库迁移失败: %v", err))
 	}
 
@@ -0,0 +1,240 @@
+package dal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 迁移使用的 MySQL 命名锁，等待超过 migrationLockTimeout 秒视为失败
+const (
+	migrationLockName    = "douyin:schema_migration"
+	migrationLockTimeout = 60
+)
+
+var errMigrationLockTimeout = errors.New("等待其他实例完成迁移超时")
+
+// models 由 AutoMigrate 同步结构的表
+var models = []interface{}{
+	&User{}, &Product{}, &SKU{}, &ProductAudit{}, &Category{}, &AttributeDefinition{}, &ProductAttribute{},
+	&Order{}, &StockOperation{}, &PaymentRecord{},
+	&RefundRecord{}, &OrderStatusHistory{}, &CheckoutSaga{}, &OutboxEvent{}, &DeadLetterEvent{},
+	&SchemaMigration{},
+}
+
+// dataMigration 一次性数据迁移，执行后记录到 schema_migrations，之后启动不再执行
+type dataMigration struct {
+	Name string
+	Run  func(db *gorm.DB) error
+}
+
+// dataMigrations 按顺序执行，已发布的迁移不能改名
+var dataMigrations = []dataMigration{
+	{Name: "money_cents", Run: migrateMoney}, // 金额字段由浮点迁移为整数分
+	{Name: "default_skus", Run: migrateSKUs}, // 历史商品补建默认SKU
+}
+
+// Migrate 同步表结构并执行尚未执行的数据迁移
+// 各服务启动时都会调用，迁移在同一个数据库连接持有的命名锁内进行：
+// 同时启动的实例依次拿到锁，后拿到锁的实例发现迁移已记录便直接跳过。
+func Migrate(db *gorm.DB) error {
+	return db.Connection(func(conn *gorm.DB) error {
+		var locked sql.NullInt64
+		if err := conn.Raw("SELECT GET_LOCK(?, ?)", migrationLockName, migrationLockTimeout).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("获取迁移锁失败: %w", err)
+		}
+		if !locked.Valid || locked.Int64 != 1 {
+			return errMigrationLockTimeout
+		}
+		defer conn.Exec("SELECT RELEASE_LOCK(?)", migrationLockName)
+
+		if err := conn.AutoMigrate(models...); err != nil {
+			return err
+		}
+		return runDataMigrations(conn)
+	})
+}
+
+// runDataMigrations 执行未记录的数据迁移，调用方需持有迁移锁
+func runDataMigrations(db *gorm.DB) error {
+	var applied []string
+	if err := db.Model(&SchemaMigration{}).Pluck("name", &applied).Error; err != nil {
+		return err
+	}
+	done := make(map[string]bool, len(applied))
+	for _, name := range applied {
+		done[name] = true
+	}
+
+	for _, m := range dataMigrations {
+		if done[m.Name] {
+			continue
+		}
+		if err := m.Run(db); err != nil {
+			return fmt.Errorf("%s: %w", m.Name, err)
+		}
+		if err := db.Create(&SchemaMigration{Name: m.Name, AppliedAt: time.Now()}).Error; err != nil {
+			return fmt.Errorf("%s: 记录迁移失败: %w", m.Name, err)
+		}
+		zap.L().Info("数据迁移完成", zap.String("migration", m.Name))
+	}
+	return nil
+}
+
+// 旧版本浮点金额列 -> 新的整数分列前缀
+var legacyMoneyColumns = []struct {
+	Table  string
+	Column string
+	Prefix string
+}{
+	{Table: "products", Column: "price", Prefix: "price_"},
+	{Table: "orders", Column: "amount", Prefix: "amount_"},
+	{Table: "payment_records", Column: "amount", Prefix: "amount_"},
+}
+
+// migrateMoney 将历史浮点金额迁移为 Money（分+币种），可重复执行
+func migrateMoney(db *gorm.DB) error {
+	for _, c := range legacyMoneyColumns {
+		if !db.Migrator().HasColumn(c.Table, c.Column) {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			sql := fmt.Sprintf("UPDATE %s SET %scents = ROUND(%s * 100), %scurrency = ?",
+				c.Table, c.Prefix, c.Column, c.Prefix)
+			if err := tx.Exec(sql, money.DefaultCurrency).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(c.Table, c.Column)
+		})
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", c.Table, c.Column, err)
+		}
+		zap.L().Info("金额字段迁移完成",
+			zap.String("table", c.Table),
+			zap.String("column", c.Column))
+	}
+
+	return migrateOrderSnapshotsV1(db)
+}
+
+// v1版本订单行，金额为浮点元
+type orderItemSnapshotV1 struct {
+	ProductID uint    `json:"product_id"`
+	Name      string  `json:"name"`
+	UnitPrice float64 `json:"unit_price"`
+	Quantity  int     `json:"quantity"`
+	Subtotal  float64 `json:"subtotal"`
+}
+
+// migrateOrderSnapshotsV1 将v1商品快照升级为当前版本
+func migrateOrderSnapshotsV1(db *gorm.DB) error {
+	var orders []Order
+	return db.Select("id", "items").
+		Where("items LIKE ?", `{"version":1,%`).
+		FindInBatches(&orders, 100, func(tx *gorm.DB, batch int) error {
+			for _, o := range orders {
+				var v1 struct {
+					Items []orderItemSnapshotV1 `json:"items"`
+				}
+				if err := json.Unmarshal([]byte(o.Items), &v1); err != nil {
+					return fmt.Errorf("订单%d快照解析失败: %w", o.ID, err)
+				}
+
+				snapshot := OrderItemsSnapshot{Version: OrderItemsSnapshotVersion}
+				for _, item := range v1.Items {
+					snapshot.Items = append(snapshot.Items, OrderItemSnapshot{
+						ProductID: item.ProductID,
+						SkuID:     item.ProductID, // 历史商品的默认SKU沿用商品ID，见 migrateSKUs
+						Name:      item.Name,
+						UnitPrice: money.FromCents(int64(math.Round(item.UnitPrice * 100))),
+						Quantity:  item.Quantity,
+						Subtotal:  money.FromCents(int64(math.Round(item.Subtotal * 100))),
+					})
+				}
+
+				data, err := json.Marshal(snapshot)
+				if err != nil {
+					return err
+				}
+				if err := tx.Model(&Order{}).Where("id = ?", o.ID).Update("items", string(data)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// migrateOrderSnapshotsV2 v2订单行没有SKU，补为商品的默认SKU
+func migrateOrderSnapshotsV2(db *gorm.DB) error {
+	var orders []Order
+	return db.Select("id", "items").
+		Where("items LIKE ?", `{"version":2,%`).
+		FindInBatches(&orders, 100, func(tx *gorm.DB, batch int) error {
+			for _, o := range orders {
+				var snapshot OrderItemsSnapshot
+				if err := json.Unmarshal([]byte(o.Items), &snapshot); err != nil {
+					return fmt.Errorf("订单%d快照解析失败: %w", o.ID, err)
+				}
+				snapshot.Version = OrderItemsSnapshotVersion
+				for i := range snapshot.Items {
+					if snapshot.Items[i].SkuID == 0 {
+						snapshot.Items[i].SkuID = snapshot.Items[i].ProductID
+					}
+				}
+
+				data, err := json.Marshal(snapshot)
+				if err != nil {
+					return err
+				}
+				if err := tx.Model(&Order{}).Where("id = ?", o.ID).Update("items", string(data)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// migrateSKUs 引入SKU前的商品（含已删除的）各补建一个默认SKU，并为v2订单快照补上SKU
+// 默认SKU沿用商品ID作为SKU ID，历史购物车条目、库存流水与订单快照中的商品ID可直接作为SKU ID使用；
+// SKU表非空时新ID可能与商品ID冲突，因此不再补建，之后的商品都由商品服务创建SKU。
+// 迁移只在持有迁移锁时执行一次，不会与其他实例并发插入。
+func migrateSKUs(db *gorm.DB) error {
+	var count int64
+	if err := db.Unscoped().Model(&SKU{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		if err := createDefaultSKUs(db); err != nil {
+			return err
+		}
+	}
+	return migrateOrderSnapshotsV2(db)
+}
+
+// createDefaultSKUs 按商品ID建默认SKU，历史库存流水指向默认SKU
+func createDefaultSKUs(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`INSERT INTO skus (id, created_at, updated_at, deleted_at, product_id, specs,
+			price_cents, price_currency, stock, sales, barcode, status)
+			SELECT id, created_at, updated_at, deleted_at, id, '{}',
+			price_cents, price_currency, stock, sales, '', 1 FROM products`)
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := tx.Exec("UPDATE stock_operations SET sku_id = product_id WHERE sku_id = 0").Error; err != nil {
+			return err
+		}
+		if result.RowsAffected > 0 {
+			zap.L().Info("历史商品默认SKU已创建", zap.Int64("count", result.RowsAffected))
+		}
+		return nil
+	})
+}
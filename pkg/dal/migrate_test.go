@@ -0,0 +1,58 @@
+package dal
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestRunDataMigrationsOnce(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "dal.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("打开测试数据库失败: %v", err)
+	}
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		t.Fatalf("测试数据库迁移失败: %v", err)
+	}
+
+	runs := map[string]int{}
+	failing := true
+	prev := dataMigrations
+	dataMigrations = []dataMigration{
+		{Name: "first", Run: func(*gorm.DB) error { runs["first"]++; return nil }},
+		{Name: "second", Run: func(*gorm.DB) error {
+			runs["second"]++
+			if failing {
+				return errors.New("迁移失败")
+			}
+			return nil
+		}},
+	}
+	t.Cleanup(func() { dataMigrations = prev })
+
+	// 失败的迁移不记录，下次启动重新执行；已完成的迁移不再执行
+	if err := runDataMigrations(db); err == nil {
+		t.Fatal("runDataMigrations() 应返回迁移错误")
+	}
+	failing = false
+	for i := 0; i < 2; i++ {
+		if err := runDataMigrations(db); err != nil {
+			t.Fatalf("runDataMigrations() 错误 = %v", err)
+		}
+	}
+
+	if runs["first"] != 1 || runs["second"] != 2 {
+		t.Errorf("执行次数 = %v，期望 first 1次、second 2次", runs)
+	}
+	var count int64
+	db.Model(&SchemaMigration{}).Count(&count)
+	if count != 2 {
+		t.Errorf("迁移记录 = %d，期望 2", count)
+	}
+}
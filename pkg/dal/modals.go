@@ -3,6 +3,7 @@ package dal
 import (
 	"time"
 
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
 	"gorm.io/gorm"
 )
 
@@ -10,9 +11,27 @@ var DB *gorm.DB // 全局数据库实例
 type OrderStatus string
 
 const (
-	OrderStatusUnpaid   OrderStatus = "unpaid"
-	OrderStatusPaid     OrderStatus = "paid"
-	OrderStatusCanceled OrderStatus = "canceled"
+	OrderStatusUnpaid    OrderStatus = "unpaid"
+	OrderStatusPaid      OrderStatus = "paid"
+	OrderStatusShipped   OrderStatus = "shipped"
+	OrderStatusDelivered OrderStatus = "delivered"
+	OrderStatusCompleted OrderStatus = "completed"
+	OrderStatusCanceled  OrderStatus = "canceled"
+	OrderStatusRefunding OrderStatus = "refunding"
+	OrderStatusRefunded  OrderStatus = "refunded"
+)
+
+// 用户角色，商家与管理员由运维在数据库中设置
+const (
+	RoleCustomer = "customer"
+	RoleMerchant = "merchant" // 只能管理自己创建的商品
+	RoleAdmin    = "admin"
+)
+
+// 商品上下架状态
+const (
+	ProductStatusOffShelf = 0
+	ProductStatusOnShelf  = 1
 )
 
 // User 用户模型
@@ -20,17 +39,94 @@ type User struct {
 	gorm.Model        // 包含ID, CreatedAt等字段
 	Username   string `gorm:"type:varchar(50);uniqueIndex;not null"`
 	Password   string `gorm:"type:varchar(100);not null"`
+	Role       string `gorm:"type:varchar(20);not null;default:'customer'"`
 	LastLogin  *time.Time
 }
 
 // Product 商品模型
 type Product struct {
 	gorm.Model
-	Name        string  `gorm:"type:varchar(100)"`
-	Description string  `gorm:"type:text"`
-	Price       float64 `gorm:"type:decimal(10,2)"`
-	Stock       int     `gorm:"default:0"`
-	Status      int     `gorm:"default:1"` // 1-上架 0-下架
+	Name        string      `gorm:"type:varchar(100)"`
+	Description string      `gorm:"type:text"`
+	Price       money.Money `gorm:"embedded;embeddedPrefix:price_"` // 起售价，即上架SKU的最低价，随SKU变更同步
+	Stock       int         `gorm:"default:0"`                      // 所有SKU的库存之和
+	Sales       int         `gorm:"default:0;index"`                // 销量，随库存扣减与归还同步变化
+	Status      int         `gorm:"default:1"`                      // 1-上架 0-下架
+	MerchantID  uint        `gorm:"index"`                          // 创建商品的商家，0表示平台商品
+	CategoryID  uint        `gorm:"index"`                          // 所属分类（叶子或任意层级），0表示未分类
+}
+
+// SKU 商品的可售单元（如某款T恤的 红色/L 码），价格与库存按SKU维护
+// 单规格商品只有一个Specs为空的SKU。
+type SKU struct {
+	gorm.Model
+	ProductID uint        `gorm:"index"`
+	Specs     string      `gorm:"type:varchar(500)"` // 规格组合，按编码排序的JSON对象，如 {"color":"红色","size":"L"}，同一商品内唯一
+	Price     money.Money `gorm:"embedded;embeddedPrefix:price_"`
+	Stock     int         `gorm:"default:0"`
+	Sales     int         `gorm:"default:0"`
+	Barcode   string      `gorm:"type:varchar(64);index"`
+	Status    int         `gorm:"default:1"` // 1-上架 0-下架
+}
+
+// Category 商品分类（树形）
+// Path为从根到自身的ID路径（如 /1/5/12/），以前缀匹配查询整棵子树。
+type Category struct {
+	gorm.Model
+	ParentID uint   `gorm:"index"` // 0表示顶级分类
+	Name     string `gorm:"type:varchar(50);not null"`
+	Path     string `gorm:"type:varchar(255);index"`
+	Level    int    // 顶级分类为1
+	Sort     int    `gorm:"default:0"` // 同级分类排序，越小越靠前
+}
+
+// 分类属性的值类型
+const (
+	AttrTypeString = "string"
+	AttrTypeNumber = "number"
+	AttrTypeBool   = "bool"
+	AttrTypeEnum   = "enum"
+)
+
+// AttributeDefinition 分类下的属性定义（如尺码、颜色、材质），子分类继承上级分类的属性
+type AttributeDefinition struct {
+	gorm.Model
+	CategoryID uint   `gorm:"uniqueIndex:idx_category_attr_code"`
+	Code       string `gorm:"type:varchar(50);uniqueIndex:idx_category_attr_code"` // 属性编码，同一分类链上唯一
+	Name       string `gorm:"type:varchar(50)"`
+	Type       string `gorm:"type:varchar(20)"` // string/number/bool/enum
+	Options    string `gorm:"type:text"`        // enum类型的可选值，JSON数组
+	Unit       string `gorm:"type:varchar(20)"` // number类型的单位，如 cm
+	Required   bool
+	Filterable bool // 是否可用于商品筛选
+}
+
+// ProductAttribute 商品的属性值
+type ProductAttribute struct {
+	ProductID   uint     `gorm:"primaryKey"`
+	AttributeID uint     `gorm:"primaryKey;index"`
+	Value       string   `gorm:"type:varchar(255);index"` // 规范化后的值：数值去掉多余的0，布尔为true/false
+	NumberValue *float64 `gorm:"index"`                   // number类型的数值，用于范围筛选
+}
+
+// ProductAudit 商品变更审计记录
+type ProductAudit struct {
+	ID        uint   `gorm:"primarykey"`
+	ProductID uint   `gorm:"index"`
+	Actor     uint   `gorm:"index"`            // 操作人用户ID
+	Action    string `gorm:"type:varchar(20)"` // create/update/delete
+	Changes   string `gorm:"type:text"`        // JSON：字段 -> {old, new}
+	CreatedAt time.Time
+}
+
+// StockOperation 库存操作流水，RequestID唯一保证扣减幂等
+type StockOperation struct {
+	gorm.Model
+	RequestID  string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ProductID  uint   `gorm:"index"`
+	SkuID      uint   `gorm:"index"`
+	Quantity   int
+	ReleasedAt *time.Time // 非空表示该次扣减已归还
 }
 
 // Cart 购物车模型
@@ -42,19 +138,119 @@ type Cart struct {
 
 type Order struct {
 	gorm.Model
-	UserID  uint
-	OrderNo string `gorm:"type:varchar(32);uniqueIndex"`
-	Amount  float64
+	UserID  uint        `gorm:"index"`
+	OrderNo string      `gorm:"type:varchar(32);uniqueIndex"`
+	Amount  money.Money `gorm:"embedded;embeddedPrefix:amount_"`
 	Items   string      // JSON存储商品快照
 	Status  OrderStatus `gorm:"type:varchar(20);index"`
 }
 
+// OrderStatusHistory 订单状态流转记录
+type OrderStatusHistory struct {
+	ID         uint        `gorm:"primarykey"`
+	OrderNo    string      `gorm:"type:varchar(32);index;not null"`
+	FromStatus OrderStatus `gorm:"type:varchar(20)"`
+	ToStatus   OrderStatus `gorm:"type:varchar(20)"`
+	Actor      string      `gorm:"type:varchar(64)"`  // 操作方，如 user:1 / payment-service / system
+	Reason     string      `gorm:"type:varchar(255)"` // 变更原因
+	CreatedAt  time.Time
+}
+
+// OrderItemsSnapshotVersion 订单商品快照格式版本，快照结构变更时递增
+// v1: 金额为float64（元）; v2: 金额为Money（分+币种）; v3: 订单行增加SKU
+const OrderItemsSnapshotVersion = 3
+
+// OrderItemsSnapshot 下单时的商品快照，JSON序列化后存入Order.Items
+type OrderItemsSnapshot struct {
+	Version int                 `json:"version"`
+	Items   []OrderItemSnapshot `json:"items"`
+}
+
+// OrderItemSnapshot 单个订单行
+type OrderItemSnapshot struct {
+	ProductID uint              `json:"product_id"`
+	SkuID     uint              `json:"sku_id"`
+	Specs     map[string]string `json:"specs,omitempty"` // 下单时的规格组合
+	Name      string            `json:"name"`
+	UnitPrice money.Money       `json:"unit_price"`
+	Quantity  int               `json:"quantity"`
+	Subtotal  money.Money       `json:"subtotal"`
+}
 
 type PaymentRecord struct {
-    gorm.Model
-    OrderID     string `gorm:"uniqueIndex"`
-    PaymentID   string
-    Amount      float64
-    Status      string // pending/success/failed
-    UserID      uint
-}
\ No newline at end of file
+	gorm.Model
+	OrderID   string      `gorm:"uniqueIndex"`
+	PaymentID string      `gorm:"type:varchar(36);index"` // 对账与渠道查询按支付单号匹配
+	Amount    money.Money `gorm:"embedded;embeddedPrefix:amount_"`
+	Status    string      // pending/success/failed/canceled/refund_pending（订单已取消，待退款）/partially_refunded/refunded
+	UserID    uint
+}
+
+// RefundRecord 退款记录，一笔支付可以有多笔部分退款，成功与处理中的退款合计不超过支付金额
+type RefundRecord struct {
+	gorm.Model
+	RefundID  string      `gorm:"type:varchar(36);uniqueIndex;not null"`
+	PaymentID string      `gorm:"type:varchar(36);index"`
+	OrderID   string      `gorm:"type:varchar(32);index"`
+	Amount    money.Money `gorm:"embedded;embeddedPrefix:amount_"`
+	Status    string      `gorm:"type:varchar(20);index"` // pending/success/failed
+	Reason    string      `gorm:"type:varchar(255)"`
+	TradeNo   string      `gorm:"type:varchar(64)"`  // 渠道退款单号
+	LastError string      `gorm:"type:varchar(512)"` // 渠道返回的失败原因
+	UserID    uint
+}
+
+// SagaStatus 下单流程状态
+type SagaStatus string
+
+const (
+	SagaStatusRunning      SagaStatus = "running"
+	SagaStatusCompensating SagaStatus = "compensating"
+	SagaStatusCompleted    SagaStatus = "completed"
+	SagaStatusCompensated  SagaStatus = "compensated"
+)
+
+// CheckoutSaga 下单流程（saga）执行状态，每完成一步持久化一次，用于失败补偿与崩溃恢复
+type CheckoutSaga struct {
+	gorm.Model
+	SagaID    string     `gorm:"type:varchar(36);uniqueIndex;not null"`
+	UserID    uint       `gorm:"index"`
+	OrderNo   string     `gorm:"type:varchar(32);index"`
+	Status    SagaStatus `gorm:"type:varchar(20);index"`
+	Step      int        // 已完成的步骤数
+	Data      string     `gorm:"type:text"`          // JSON存储流程数据
+	LastError string     `gorm:"type:varchar(512)"`  // 最近一次失败原因
+	Owner     string     `gorm:"type:varchar(36)"`   // 当前执行方，恢复任务接管时更换，只有执行方可以写入
+	Version   int        `gorm:"not null;default:0"` // 每次写入递增
+}
+
+// OutboxEvent 事务性发件箱：与业务数据在同一事务中写入，由中继任务投递到消息代理
+type OutboxEvent struct {
+	ID          uint       `gorm:"primarykey"`
+	EventID     string     `gorm:"type:varchar(36);uniqueIndex;not null"`
+	Type        string     `gorm:"type:varchar(64);not null"`
+	AggregateID string     `gorm:"type:varchar(64);index"` // 订单号等聚合标识
+	Payload     string     `gorm:"type:text"`
+	PublishedAt *time.Time `gorm:"index"` // 为空表示尚未投递
+	Attempts    int
+	LastError   string `gorm:"type:varchar(512)"`
+	CreatedAt   time.Time
+}
+
+// DeadLetterEvent 多次处理失败的事件，等待人工排查或重放
+type DeadLetterEvent struct {
+	ID          uint   `gorm:"primarykey"`
+	EventID     string `gorm:"type:varchar(36);index"`
+	Type        string `gorm:"type:varchar(64)"`
+	Consumer    string `gorm:"type:varchar(64);index"` // 消费组
+	AggregateID string `gorm:"type:varchar(64);index"`
+	Payload     string `gorm:"type:text"`
+	Error       string `gorm:"type:varchar(512)"`
+	CreatedAt   time.Time
+}
+
+// SchemaMigration 已执行的数据迁移，见 Migrate
+type SchemaMigration struct {
+	Name      string `gorm:"type:varchar(64);primaryKey"`
+	AppliedAt time.Time
+}
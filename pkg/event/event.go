@@ -0,0 +1,109 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+)
+
+// 领域事件类型
+const (
+	OrderCreated     = "OrderCreated"
+	OrderPaid        = "OrderPaid"
+	OrderCanceled    = "OrderCanceled"
+	PaymentSucceeded = "PaymentSucceeded"
+	RefundRequested  = "RefundRequested"
+	RefundSucceeded  = "RefundSucceeded"
+	// PaymentRefundPending 支付成功但订单已取消，支付记录转为待退款，由支付服务发起全额退款
+	PaymentRefundPending = "PaymentRefundPending"
+)
+
+// Message 投递给消费方的事件
+// 投递语义为至少一次，消费方需按ID去重或保证处理幂等。
+type Message struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+// Decode 解析事件内容
+func (m *Message) Decode(v interface{}) error {
+	return json.Unmarshal(m.Payload, v)
+}
+
+// Handler 事件处理函数，返回错误时事件会被重新投递
+type Handler func(ctx context.Context, msg *Message) error
+
+// Broker 消息代理
+type Broker interface {
+	// Publish 发布事件
+	Publish(ctx context.Context, msg *Message) error
+	// Subscribe 以消费组订阅事件，同一消费组内每条事件只由一个订阅者处理。
+	// 注册后立即返回，ctx结束时停止消费。
+	Subscribe(ctx context.Context, group string, handler Handler) error
+}
+
+// OrderEvent 订单事件内容
+type OrderEvent struct {
+	OrderNo    string      `json:"order_no"`
+	UserID     uint        `json:"user_id"`
+	Status     string      `json:"status"`
+	FromStatus string      `json:"from_status,omitempty"`
+	Amount     money.Money `json:"amount"`
+	ProductIDs []uint      `json:"product_ids,omitempty"`
+	SkuIDs     []uint      `json:"sku_ids,omitempty"`
+	Actor      string      `json:"actor,omitempty"`
+	Reason     string      `json:"reason,omitempty"`
+}
+
+// PaymentEvent 支付事件内容
+type PaymentEvent struct {
+	PaymentID string      `json:"payment_id"`
+	OrderNo   string      `json:"order_no"`
+	UserID    uint        `json:"user_id"`
+	Amount    money.Money `json:"amount"`
+}
+
+// RefundEvent 退款事件内容
+type RefundEvent struct {
+	RefundID  string      `json:"refund_id"`
+	PaymentID string      `json:"payment_id"`
+	OrderNo   string      `json:"order_no"`
+	UserID    uint        `json:"user_id"`
+	Amount    money.Money `json:"amount"`
+	Full      bool        `json:"full"` // 退款后支付金额已全部退回
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// DefaultBroker 全局消息代理，由 Init 按配置创建
+var DefaultBroker Broker
+
+// ErrMemoryBrokerUnsupported 各服务以独立进程部署，进程内代理无法跨服务投递事件
+var ErrMemoryBrokerUnsupported = errors.New("memory消息代理只在单个进程内投递，服务间事件必须使用Redis Streams（event.broker: redis）")
+
+// Init 按配置初始化消息代理（需先初始化配置与Redis）
+// 订单、购物车、支付服务分别在各自进程中发布与消费事件，只支持Redis Streams；
+// 配置为memory时启动失败，避免事件只在发布方进程内投递而其他服务永远收不到。
+func Init() error {
+	conf := config.Conf.Event
+	switch conf.Broker {
+	case "", "redis":
+		stream := conf.Stream
+		if stream == "" {
+			stream = "domain:events"
+		}
+		DefaultBroker = NewRedisBroker(stream)
+	case "memory":
+		return ErrMemoryBrokerUnsupported
+	default:
+		return fmt.Errorf("不支持的消息代理类型: %s", conf.Broker)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package event
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+)
+
+func TestInit(t *testing.T) {
+	prevConf, prevBroker := config.Conf, DefaultBroker
+	t.Cleanup(func() { config.Conf, DefaultBroker = prevConf, prevBroker })
+
+	tests := []struct {
+		broker  string
+		want    error
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"redis", nil, false},
+		{"memory", ErrMemoryBrokerUnsupported, true},
+		{"kafka", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.broker, func(t *testing.T) {
+			config.Conf = &config.Config{Event: config.EventConfig{Broker: tt.broker}}
+			DefaultBroker = nil
+
+			err := Init()
+			if (err != nil) != tt.wantErr || (tt.want != nil && !errors.Is(err, tt.want)) {
+				t.Fatalf("Init() 错误 = %v，期望 %v", err, tt.want)
+			}
+			if _, ok := DefaultBroker.(*RedisBroker); !tt.wantErr && !ok {
+				t.Errorf("DefaultBroker = %T，期望 *RedisBroker", DefaultBroker)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	memoryRetryBase = time.Second
+	memoryRetryMax  = 30 * time.Second
+)
+
+// MemoryBroker 进程内消息代理
+// 事件异步投递给每个消费组中的一个订阅者，处理失败时退避重试；
+// 事件不持久化，进程退出时未处理完的事件会丢失；只能在发布与消费位于同一进程时使用（如测试），
+// 服务部署不能使用，见 Init。
+type MemoryBroker struct {
+	mu     sync.RWMutex
+	groups map[string]*memoryGroup
+}
+
+type memoryGroup struct {
+	subscribers []memorySubscriber
+	next        int
+}
+
+type memorySubscriber struct {
+	ctx     context.Context
+	handler Handler
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{groups: make(map[string]*memoryGroup)}
+}
+
+// Publish 投递事件，不等待处理完成
+func (b *MemoryBroker) Publish(ctx context.Context, msg *Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, g := range b.groups {
+		// 清理已停止的订阅者
+		active := g.subscribers[:0]
+		for _, sub := range g.subscribers {
+			if sub.ctx.Err() == nil {
+				active = append(active, sub)
+			}
+		}
+		g.subscribers = active
+		if len(active) == 0 {
+			continue
+		}
+
+		sub := active[g.next%len(active)]
+		g.next++
+		go deliver(sub, msg)
+	}
+	return nil
+}
+
+// Subscribe 注册订阅者
+func (b *MemoryBroker) Subscribe(ctx context.Context, group string, handler Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g, ok := b.groups[group]
+	if !ok {
+		g = &memoryGroup{}
+		b.groups[group] = g
+	}
+	g.subscribers = append(g.subscribers, memorySubscriber{ctx: ctx, handler: handler})
+	return nil
+}
+
+func deliver(sub memorySubscriber, msg *Message) {
+	delay := memoryRetryBase
+	for {
+		err := sub.handler(sub.ctx, msg)
+		if err == nil {
+			return
+		}
+		zap.L().Warn("事件处理失败，稍后重试",
+			zap.String("event_id", msg.ID),
+			zap.String("type", msg.Type),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+
+		select {
+		case <-sub.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > memoryRetryMax {
+			delay = memoryRetryMax
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	relayInterval  = 500 * time.Millisecond
+	relayBatchSize = 100
+	maxErrorLen    = 500
+)
+
+// Add 在业务事务中写入待发布的事件，事务回滚时事件一并丢弃
+func Add(tx *gorm.DB, eventType, aggregateID string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("事件序列化失败: %w", err)
+	}
+
+	record := &dal.OutboxEvent{
+		EventID:     uuid.New().String(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     string(data),
+	}
+	if err := tx.Create(record).Error; err != nil {
+		return fmt.Errorf("事件写入失败: %w", err)
+	}
+	return nil
+}
+
+// StartRelay 启动发件箱中继，把未发布的事件按写入顺序投递到消息代理，ctx结束时退出
+// 多个服务副本可同时运行（SKIP LOCKED 领取）；投递后提交前崩溃会导致重复投递，消费方需幂等。
+func StartRelay(ctx context.Context, broker Broker) {
+	go func() {
+		ticker := time.NewTicker(relayInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for {
+					n, err := relayOnce(ctx, broker)
+					if err != nil {
+						zap.L().Error("事件投递失败", zap.Error(err))
+						break
+					}
+					if n < relayBatchSize {
+						break
+					}
+				}
+			}
+		}
+	}()
+}
+
+// relayOnce 投递一批事件，返回本批领取的事件数
+// 遇到投递失败即停止本批，保证同一聚合的事件不会乱序。
+func relayOnce(ctx context.Context, broker Broker) (int, error) {
+	var count int
+	var publishErr error
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var records []dal.OutboxEvent
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL").
+			Order("id").
+			Limit(relayBatchSize).
+			Find(&records).Error; err != nil {
+			return fmt.Errorf("待发布事件查询失败: %w", err)
+		}
+		count = len(records)
+
+		var published []uint
+		for i := range records {
+			record := &records[i]
+			if publishErr = broker.Publish(ctx, toMessage(record)); publishErr != nil {
+				errMsg := []rune(publishErr.Error())
+				if len(errMsg) > maxErrorLen {
+					errMsg = errMsg[:maxErrorLen]
+				}
+				if err := tx.Model(record).Updates(map[string]interface{}{
+					"attempts":   gorm.Expr("attempts + 1"),
+					"last_error": string(errMsg),
+				}).Error; err != nil {
+					return err
+				}
+				break
+			}
+			published = append(published, record.ID)
+		}
+
+		if len(published) > 0 {
+			if err := tx.Model(&dal.OutboxEvent{}).
+				Where("id IN ?", published).
+				Update("published_at", time.Now()).Error; err != nil {
+				return fmt.Errorf("事件发布状态更新失败: %w", err)
+			}
+		}
+		// 已投递的事件与失败次数需要提交，投递错误在事务外返回
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, publishErr
+}
+
+func toMessage(record *dal.OutboxEvent) *Message {
+	return &Message{
+		ID:          record.EventID,
+		Type:        record.Type,
+		AggregateID: record.AggregateID,
+		Payload:     json.RawMessage(record.Payload),
+		OccurredAt:  record.CreatedAt,
+	}
+}
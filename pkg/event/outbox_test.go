@@ -0,0 +1,118 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+	"gorm.io/gorm"
+)
+
+// addEvents 在一个事务中写入订单事件
+func addEvents(t *testing.T, orderNos ...string) {
+	t.Helper()
+	err := dal.DB.Transaction(func(tx *gorm.DB) error {
+		for _, orderNo := range orderNos {
+			if err := Add(tx, OrderCreated, orderNo, OrderEvent{OrderNo: orderNo}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("写入事件失败: %v", err)
+	}
+}
+
+func TestRelayDeliversToEveryGroup(t *testing.T) {
+	testutil.DB(t, &dal.OutboxEvent{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := NewMemoryBroker()
+	received := make(chan string, 10)
+	for _, group := range []string{"cart", "stats"} {
+		group := group
+		broker.Subscribe(ctx, group, func(ctx context.Context, msg *Message) error {
+			received <- group + ":" + msg.AggregateID
+			return nil
+		})
+	}
+	addEvents(t, "T1", "T2")
+
+	if n, err := relayOnce(ctx, broker); err != nil || n != 2 {
+		t.Fatalf("relayOnce() = %d, %v，期望 2", n, err)
+	}
+	var got []string
+	for len(got) < 4 {
+		select {
+		case msg := <-received:
+			got = append(got, msg)
+		case <-time.After(time.Second):
+			t.Fatalf("等待投递超时，已收到 %v", got)
+		}
+	}
+	sort.Strings(got)
+	if want := []string{"cart:T1", "cart:T2", "stats:T1", "stats:T2"}; !slices.Equal(got, want) {
+		t.Errorf("收到的事件 = %v，期望 %v", got, want)
+	}
+
+	// 已发布的事件不再投递
+	if n, err := relayOnce(ctx, broker); err != nil || n != 0 {
+		t.Errorf("第二次 relayOnce() = %d, %v，期望 0", n, err)
+	}
+}
+
+// flakyBroker 对指定聚合的事件返回投递失败
+type flakyBroker struct {
+	fail      string
+	published []string
+}
+
+func (b *flakyBroker) Publish(ctx context.Context, msg *Message) error {
+	if msg.AggregateID == b.fail {
+		return errors.New("代理不可用")
+	}
+	b.published = append(b.published, msg.AggregateID)
+	return nil
+}
+
+func (b *flakyBroker) Subscribe(ctx context.Context, group string, handler Handler) error { return nil }
+
+func TestRelayStopsAtFailedEvent(t *testing.T) {
+	testutil.DB(t, &dal.OutboxEvent{})
+	ctx := context.Background()
+	addEvents(t, "T1", "T2", "T3")
+
+	broker := &flakyBroker{fail: "T2"}
+	if _, err := relayOnce(ctx, broker); err == nil {
+		t.Fatal("relayOnce() 应返回投递错误")
+	}
+	if !slices.Equal(broker.published, []string{"T1"}) {
+		t.Errorf("已投递 = %v，期望只有 T1（失败后停止以保证顺序）", broker.published)
+	}
+
+	var records []dal.OutboxEvent
+	dal.DB.Order("id").Find(&records)
+	if records[0].PublishedAt == nil || records[1].PublishedAt != nil || records[2].PublishedAt != nil {
+		t.Errorf("发布状态 = %v/%v/%v，期望只有第一条已发布",
+			records[0].PublishedAt, records[1].PublishedAt, records[2].PublishedAt)
+	}
+	if records[1].Attempts != 1 || records[1].LastError == "" {
+		t.Errorf("失败事件 attempts=%d last_error=%q，期望记录一次失败", records[1].Attempts, records[1].LastError)
+	}
+
+	// 代理恢复后按顺序投递剩余事件
+	broker.fail = ""
+	if n, err := relayOnce(ctx, broker); err != nil || n != 2 {
+		t.Fatalf("relayOnce() = %d, %v，期望 2", n, err)
+	}
+	if !slices.Equal(broker.published, []string{"T1", "T2", "T3"}) {
+		t.Errorf("已投递 = %v，期望 [T1 T2 T3]", broker.published)
+	}
+}
@@ -0,0 +1,140 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	goredis "github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+const (
+	redisStreamMaxLen = 100000
+	redisReadCount    = 10
+	redisReadBlock    = 2 * time.Second
+	// 已投递但超过该时长未确认的事件会被重新领取（处理失败或消费者崩溃）
+	redisClaimMinIdle = 30 * time.Second
+	redisRetryDelay   = time.Second
+)
+
+// RedisBroker 基于 Redis Streams 的消息代理
+// 每个消费组对应一个 Redis 消费组；处理成功后XACK，失败的事件留在待确认列表，
+// 空闲超过 redisClaimMinIdle 后由同组的消费者重新领取。
+type RedisBroker struct {
+	stream   string
+	consumer string
+}
+
+func NewRedisBroker(stream string) *RedisBroker {
+	host, _ := os.Hostname()
+	return &RedisBroker{
+		stream:   stream,
+		consumer: fmt.Sprintf("%s-%d", host, os.Getpid()),
+	}
+}
+
+// Publish 追加事件到流，流长度近似限制在 redisStreamMaxLen
+func (b *RedisBroker) Publish(ctx context.Context, msg *Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return redis.Client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: b.stream,
+		MaxLen: redisStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type":    msg.Type,
+			"message": data,
+		},
+	}).Err()
+}
+
+// Subscribe 创建消费组（不存在时）并启动消费协程
+func (b *RedisBroker) Subscribe(ctx context.Context, group string, handler Handler) error {
+	err := redis.Client.XGroupCreateMkStream(ctx, b.stream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("消费组%s创建失败: %w", group, err)
+	}
+
+	go b.consume(ctx, group, handler)
+	return nil
+}
+
+func (b *RedisBroker) consume(ctx context.Context, group string, handler Handler) {
+	for ctx.Err() == nil {
+		// 先重新领取超时未确认的事件
+		claimed, _, err := redis.Client.XAutoClaim(ctx, &goredis.XAutoClaimArgs{
+			Stream:   b.stream,
+			Group:    group,
+			Consumer: b.consumer,
+			MinIdle:  redisClaimMinIdle,
+			Start:    "0-0",
+			Count:    redisReadCount,
+		}).Result()
+		if err != nil && ctx.Err() == nil {
+			zap.L().Warn("事件重新领取失败", zap.String("group", group), zap.Error(err))
+		}
+		b.handle(ctx, group, handler, claimed)
+
+		streams, err := redis.Client.XReadGroup(ctx, &goredis.XReadGroupArgs{
+			Group:    group,
+			Consumer: b.consumer,
+			Streams:  []string{b.stream, ">"},
+			Count:    redisReadCount,
+			Block:    redisReadBlock,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, goredis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			zap.L().Error("事件读取失败", zap.String("group", group), zap.Error(err))
+			time.Sleep(redisRetryDelay)
+			continue
+		}
+		for _, stream := range streams {
+			b.handle(ctx, group, handler, stream.Messages)
+		}
+	}
+}
+
+func (b *RedisBroker) handle(ctx context.Context, group string, handler Handler, messages []goredis.XMessage) {
+	for _, xmsg := range messages {
+		var msg Message
+		raw, _ := xmsg.Values["message"].(string)
+		if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+			// 无法解析的事件直接确认，避免反复投递
+			zap.L().Error("事件解析失败",
+				zap.String("group", group),
+				zap.String("stream_id", xmsg.ID),
+				zap.Error(err))
+			b.ack(ctx, group, xmsg.ID)
+			continue
+		}
+
+		if err := handler(ctx, &msg); err != nil {
+			zap.L().Warn("事件处理失败，等待重新投递",
+				zap.String("group", group),
+				zap.String("event_id", msg.ID),
+				zap.String("type", msg.Type),
+				zap.Error(err))
+			continue
+		}
+		b.ack(ctx, group, xmsg.ID)
+	}
+}
+
+func (b *RedisBroker) ack(ctx context.Context, group, id string) {
+	if err := redis.Client.XAck(ctx, b.stream, group, id).Err(); err != nil {
+		zap.L().Warn("事件确认失败",
+			zap.String("group", group),
+			zap.String("stream_id", id),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,59 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"go.uber.org/zap"
+)
+
+const retryBaseDelay = 200 * time.Millisecond
+
+// WithRetry 为事件处理函数增加重试与死信
+// 处理失败时按指数退避重试，共尝试attempts次；仍然失败时写入死信表并确认事件，
+// 避免单条坏事件反复投递。死信写入失败时返回错误，交给消息代理重新投递。
+func WithRetry(consumer string, attempts int, handler Handler) Handler {
+	return func(ctx context.Context, msg *Message) error {
+		var err error
+		delay := retryBaseDelay
+		for i := 0; i < attempts; i++ {
+			if err = handler(ctx, msg); err == nil {
+				return nil
+			}
+			if i == attempts-1 {
+				break
+			}
+			zap.L().Warn("事件处理失败，准备重试",
+				zap.String("consumer", consumer),
+				zap.String("event_id", msg.ID),
+				zap.Int("attempt", i+1),
+				zap.Error(err))
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+
+		zap.L().Error("事件多次处理失败，转入死信",
+			zap.String("consumer", consumer),
+			zap.String("event_id", msg.ID),
+			zap.String("type", msg.Type),
+			zap.Error(err))
+		errMsg := []rune(err.Error())
+		if len(errMsg) > maxErrorLen {
+			errMsg = errMsg[:maxErrorLen]
+		}
+		return dal.DB.WithContext(ctx).Create(&dal.DeadLetterEvent{
+			EventID:     msg.ID,
+			Type:        msg.Type,
+			Consumer:    consumer,
+			AggregateID: msg.AggregateID,
+			Payload:     string(msg.Payload),
+			Error:       string(errMsg),
+		}).Error
+	}
+}
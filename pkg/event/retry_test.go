@@ -0,0 +1,49 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+func TestWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int // 前几次处理失败
+		wantCalls    int
+		wantDeadLtrs int64
+	}{
+		{"首次成功", 0, 1, 0},
+		{"重试后成功", 2, 3, 0},
+		{"多次失败转入死信", 3, 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.DB(t, &dal.DeadLetterEvent{})
+			calls := 0
+			handler := WithRetry("test", 3, func(ctx context.Context, msg *Message) error {
+				calls++
+				if calls <= tt.failures {
+					return errors.New("处理失败")
+				}
+				return nil
+			})
+
+			msg := &Message{ID: "evt-1", Type: OrderCreated, AggregateID: "T1", Payload: []byte(`{}`)}
+			if err := handler(context.Background(), msg); err != nil {
+				t.Fatalf("WithRetry() 错误 = %v，转入死信后应确认事件", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("处理次数 = %d，期望 %d", calls, tt.wantCalls)
+			}
+			var count int64
+			dal.DB.Model(&dal.DeadLetterEvent{}).Where("event_id = ? AND consumer = ?", msg.ID, "test").Count(&count)
+			if count != tt.wantDeadLtrs {
+				t.Errorf("死信数 = %d，期望 %d", count, tt.wantDeadLtrs)
+			}
+		})
+	}
+}
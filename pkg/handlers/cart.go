@@ -2,44 +2,55 @@ package handlers
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"strconv"
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	cartService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/cart"
+	"go.uber.org/zap"
 )
 
+// AddToCart 加入购物车，多规格商品需指定sku_id，单规格商品可只传product_id
 func AddToCart(c context.Context, ctx *app.RequestContext) {
 	userID := ctx.GetUint("userID")
-	productIDStr := ctx.Query("product_id")
 
 	// 参数校验
-	productID, err := strconv.ParseUint(productIDStr, 10, 64)
+	var productID, skuID uint64
+	var err error
+	if v := ctx.Query("sku_id"); v != "" {
+		if skuID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			ctx.JSON(400, "SKU ID格式错误")
+			return
+		}
+	}
+	if v := ctx.Query("product_id"); v != "" || skuID == 0 {
+		if productID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			ctx.JSON(400, "商品ID格式错误")
+			return
+		}
+	}
+
+	// 商品存在、上架、库存与限购校验在cartService中通过商品服务完成
+	resolved, err := cartService.ResolveSKU(c, uint(productID), uint(skuID))
 	if err != nil {
-		ctx.JSON(400, "商品ID格式错误")
+		respondCartError(ctx, err)
 		return
 	}
-
-	// 校验商品是否存在
-	// var p dal.Product
-	// if err := dal.DB.First(&p, productID).Error; err != nil {
-	// 	ctx.JSON(404, "商品不存在")
-	// 	return
-	// }
-
-	// 使用HSET存储 {用户ID: {商品ID:数量}}
-	key := fmt.Sprintf("cart:%d", userID)
-	if err := redis.Client.HIncrBy(c,key, strconv.FormatUint(productID, 10), 1).Err(); err != nil {
-		ctx.JSON(500, "系统错误,购物车操作失败")
+	newCount, err := cartService.AddItem(c, userID, resolved, 1)
+	if err != nil {
+		respondCartError(ctx, err)
 		return
 	}
 
 	ctx.JSON(200, map[string]interface{}{
-        "user_id":    userID,
-        "product_id": productID,
-        "new_count":  redis.Client.HGet(c, key, strconv.FormatUint(productID, 10)).Val(),
-    })
+		"user_id":    userID,
+		"product_id": productID,
+		"sku_id":     resolved,
+		"new_count":  newCount,
+	})
 }
 
 // 带版本号的清空操作
@@ -49,20 +60,196 @@ func ClearCart(c context.Context, ctx *app.RequestContext) {
 		ctx.JSON(400, "需要确认版本号")
 		return
 	}
-	key := fmt.Sprintf("cart:%d", ctx.GetUint("userID"))
-	if err := redis.Client.Del(c, key).Err(); err != nil {
-        ctx.JSON(500, "清空购物车失败")
-        return
-    }
+	if err := cartService.Clear(c, ctx.GetUint("userID")); err != nil {
+		ctx.JSON(500, "清空购物车失败")
+		return
+	}
 	ctx.JSON(200, "购物车已清空")
 }
+
+// CartItemView 购物车条目（附带商品与规格信息）
+type CartItemView struct {
+	SkuID     uint              `json:"sku_id"`
+	ProductID uint              `json:"product_id"`
+	Quantity  int               `json:"quantity"`
+	Name      string            `json:"name"`
+	Specs     map[string]string `json:"specs,omitempty"`
+	Price     money.Money       `json:"price"`
+	Stock     int               `json:"stock"`
+	Available bool              `json:"available"` // SKU或商品已下架/删除时为false
+}
+
+// GetCart 查看购物车，条目信息从商品服务批量补全
+func GetCart(c context.Context, ctx *app.RequestContext) {
+	userID := ctx.GetUint("userID")
+
+	items, err := cartService.GetItems(c, userID)
+	if err != nil {
+		ctx.JSON(500, "购物车查询失败")
+		return
+	}
+	skuIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		skuIDs = append(skuIDs, item.SkuID)
+	}
+	skus, err := cartService.LookupSKUs(c, skuIDs)
+	if err != nil {
+		zap.L().Error("商品信息查询失败", zap.Uint("userID", userID), zap.Error(err))
+		ctx.JSON(500, "商品信息查询失败")
+		return
+	}
+
+	views := make([]CartItemView, 0, len(items))
+	totalPrice := money.Zero(money.DefaultCurrency)
+	for _, item := range items {
+		view := CartItemView{SkuID: item.SkuID, Quantity: item.Quantity}
+
+		info, ok := skus[item.SkuID]
+		if !ok {
+			// SKU已不存在，保留条目由用户自行移除
+			views = append(views, view)
+			continue
+		}
+
+		view.ProductID = uint(info.ProductId)
+		view.Name = info.ProductName
+		view.Specs = info.Specs
+		view.Price = money.FromIDL(info.Price)
+		view.Stock = int(info.Stock)
+		view.Available = info.Status == 1
+		if !view.Available {
+			views = append(views, view)
+			continue
+		}
+		if sum, err := totalPrice.Add(view.Price.Mul(int64(item.Quantity))); err == nil {
+			totalPrice = sum
+		} else {
+			// 暂不支持多币种购物车，异币种商品不计入合计
+			zap.L().Warn("购物车商品币种不一致",
+				zap.Uint("skuID", item.SkuID),
+				zap.Error(err))
+		}
+		views = append(views, view)
+	}
+
+	ctx.JSON(200, map[string]interface{}{
+		"user_id":     userID,
+		"items":       views,
+		"total_price": totalPrice,
+	})
+}
+
+// cartItemSKU 确定操作的购物车条目
+// /cart/skus/:sku_id 直接指定SKU；/cart/items/:product_id 为引入SKU前的接口，
+// 与AddToCart一样按商品解析SKU，多规格商品需通过sku_id查询参数指定。
+func cartItemSKU(c context.Context, ctx *app.RequestContext) (uint, bool) {
+	if v := ctx.Param("sku_id"); v != "" {
+		skuID, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			ctx.JSON(400, "SKU ID格式错误")
+			return 0, false
+		}
+		return uint(skuID), true
+	}
+
+	productID, err := strconv.ParseUint(ctx.Param("product_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, "商品ID格式错误")
+		return 0, false
+	}
+	var skuID uint64
+	if v := ctx.Query("sku_id"); v != "" {
+		if skuID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			ctx.JSON(400, "SKU ID格式错误")
+			return 0, false
+		}
+	}
+	resolved, err := cartService.ResolveSKU(c, uint(productID), uint(skuID))
+	if err != nil {
+		respondCartError(ctx, err)
+		return 0, false
+	}
+	return resolved, true
+}
+
+// UpdateCartItem 设置SKU的精确数量（0表示移除）
+func UpdateCartItem(c context.Context, ctx *app.RequestContext) {
+	userID := ctx.GetUint("userID")
+	skuID, ok := cartItemSKU(c, ctx)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Quantity *int `json:"quantity"`
+	}
+	if err := ctx.BindJSON(&req); err != nil || req.Quantity == nil || *req.Quantity < 0 {
+		ctx.JSON(400, "商品数量错误")
+		return
+	}
+
+	if err := cartService.SetQuantity(c, userID, skuID, *req.Quantity); err != nil {
+		respondCartError(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, map[string]interface{}{
+		"user_id":  userID,
+		"sku_id":   skuID,
+		"quantity": *req.Quantity,
+	})
+}
+
+// RemoveCartItem 从购物车移除单个SKU
+func RemoveCartItem(c context.Context, ctx *app.RequestContext) {
+	userID := ctx.GetUint("userID")
+	skuID, ok := cartItemSKU(c, ctx)
+	if !ok {
+		return
+	}
+
+	if err := cartService.RemoveItem(c, userID, skuID); err != nil {
+		ctx.JSON(500, "系统错误,购物车操作失败")
+		return
+	}
+	ctx.JSON(200, "商品已移除")
+}
+
+// 购物车业务错误转HTTP响应，商品校验失败时附带详细信息
+func respondCartError(ctx *app.RequestContext, err error) {
+	var vErr *cartService.ValidationError
+	if errors.As(err, &vErr) {
+		code := 409
+		switch {
+		case errors.Is(err, cartService.ErrProductNotFound):
+			code = 404
+		case errors.Is(err, cartService.ErrProductOffShelf),
+			errors.Is(err, cartService.ErrSKURequired):
+			code = 400
+		}
+		ctx.JSON(code, map[string]interface{}{
+			"code":    code,
+			"message": vErr.Err.Error(),
+			"detail":  vErr,
+		})
+		return
+	}
+
+	if errors.Is(err, cartService.ErrInvalidParams) {
+		ctx.JSON(400, "商品数量错误")
+		return
+	}
+	zap.L().Error("购物车操作失败", zap.Error(err))
+	ctx.JSON(500, "系统错误,购物车操作失败")
+}
+
 func TestRedis(c context.Context, ctx *app.RequestContext) {
-    err := redis.Client.Set(c, "test_key", "hello", 10*time.Second).Err()
-    if err != nil {
-        ctx.JSON(500, "写入失败")
-        return
-    }
-    
-    val, err := redis.Client.Get(c, "test_key").Result()
-    ctx.JSON(200, map[string]interface{}{"value": val})
-}
\ No newline at end of file
+	err := redis.Client.Set(c, "test_key", "hello", 10*time.Second).Err()
+	if err != nil {
+		ctx.JSON(500, "写入失败")
+		return
+	}
+
+	val, err := redis.Client.Get(c, "test_key").Result()
+	ctx.JSON(200, map[string]interface{}{"value": val})
+}
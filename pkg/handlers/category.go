@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	productService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/product"
+)
+
+// ListCategories 查询分类树
+// @Param root query int false "只返回该分类的子树，默认返回全部分类"
+// @Router /categories [get]
+func ListCategories(c context.Context, ctx *app.RequestContext) {
+	var rootID uint64
+	if v := ctx.Query("root"); v != "" {
+		var err error
+		if rootID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			ctx.JSON(400, map[string]string{"error": "root格式错误"})
+			return
+		}
+	}
+
+	tree, err := productService.CategoryTree(c, uint(rootID))
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(200, map[string]interface{}{"categories": tree})
+}
+
+// GetCategory 查询分类详情，返回可用的属性定义（含从上级分类继承的）
+func GetCategory(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "分类ID格式错误"})
+		return
+	}
+
+	category, err := productService.GetCategory(c, uint(id))
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	attributes, err := productService.CategoryAttributes(c, uint(id))
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(200, map[string]interface{}{
+		"category":   category,
+		"attributes": attributes,
+	})
+}
+
+// 分类请求体，更新时为空的字段保持不变
+type categoryRequest struct {
+	ParentID uint    `json:"parent_id"` // 仅创建时有效，0表示顶级分类
+	Name     *string `json:"name"`
+	Sort     *int    `json:"sort"`
+}
+
+// CreateCategory 创建分类（管理员）
+func CreateCategory(c context.Context, ctx *app.RequestContext) {
+	var req categoryRequest
+	if err := ctx.BindJSON(&req); err != nil || req.Name == nil {
+		ctx.JSON(400, map[string]string{"error": "无效请求参数"})
+		return
+	}
+	var sortNo int
+	if req.Sort != nil {
+		sortNo = *req.Sort
+	}
+
+	category, err := productService.CreateCategory(c, req.ParentID, *req.Name, sortNo)
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(201, category)
+}
+
+// UpdateCategory 修改分类名称或排序（管理员）
+func UpdateCategory(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "分类ID格式错误"})
+		return
+	}
+	var req categoryRequest
+	if err := ctx.BindJSON(&req); err != nil {
+		ctx.JSON(400, map[string]string{"error": "无效请求参数"})
+		return
+	}
+
+	category, err := productService.UpdateCategory(c, uint(id), req.Name, req.Sort)
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(200, category)
+}
+
+// DeleteCategory 删除分类（管理员），分类下仍有子分类或商品时拒绝
+func DeleteCategory(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "分类ID格式错误"})
+		return
+	}
+	if err := productService.DeleteCategory(c, uint(id)); err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(200, map[string]string{"status": "deleted"})
+}
+
+// DefineCategoryAttribute 在分类下定义属性（管理员）
+func DefineCategoryAttribute(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "分类ID格式错误"})
+		return
+	}
+	var req struct {
+		Code       string   `json:"code"`
+		Name       string   `json:"name"`
+		Type       string   `json:"type"`    // string/number/bool/enum
+		Options    []string `json:"options"` // enum类型的可选值
+		Unit       string   `json:"unit"`
+		Required   bool     `json:"required"`
+		Filterable bool     `json:"filterable"`
+	}
+	if err := ctx.BindJSON(&req); err != nil {
+		ctx.JSON(400, map[string]string{"error": "无效请求参数"})
+		return
+	}
+
+	def, err := productService.DefineAttribute(c, uint(id), productService.AttributeInput{
+		Code:       req.Code,
+		Name:       req.Name,
+		Type:       req.Type,
+		Options:    req.Options,
+		Unit:       req.Unit,
+		Required:   req.Required,
+		Filterable: req.Filterable,
+	})
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(201, def)
+}
+
+// AssignProductCategory 设置商品分类与属性值（商家/管理员），属性值整体替换
+func AssignProductCategory(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "商品ID格式错误"})
+		return
+	}
+	var req struct {
+		CategoryID uint              `json:"category_id"` // 0表示取消分类
+		Attributes map[string]string `json:"attributes"`  // 属性编码 -> 取值
+	}
+	if err := ctx.BindJSON(&req); err != nil {
+		ctx.JSON(400, map[string]string{"error": "无效请求参数"})
+		return
+	}
+
+	values, err := productService.AssignCategory(c, currentOperator(ctx), uint(id), req.CategoryID, req.Attributes)
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(200, map[string]interface{}{
+		"product_id":  id,
+		"category_id": req.CategoryID,
+		"attributes":  values,
+	})
+}
+
+// GetProductAttributes 查询商品属性值
+func GetProductAttributes(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "商品ID格式错误"})
+		return
+	}
+	values, err := productService.ProductAttributes(c, uint(id))
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(200, map[string]interface{}{"attributes": values})
+}
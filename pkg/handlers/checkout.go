@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	checkoutService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/checkout"
+	orderService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/order"
+	"go.uber.org/zap"
+)
+
+// Checkout 结算购物车
+// @Summary 结算购物车生成订单与支付链接，失败时自动回滚购物车、库存与订单
+// @Param product_ids body []int false "指定结算的商品ID（含该商品在购物车中的所有SKU）"
+// @Param sku_ids body []int false "指定结算的SKU ID，与product_ids都为空时结算整个购物车"
+// @Router /checkout [post]
+func Checkout(c context.Context, ctx *app.RequestContext) {
+	userID, oerr := currentUserID(ctx)
+	if oerr != nil {
+		respondError(ctx, oerr.Code, oerr)
+		return
+	}
+
+	// 请求体可选
+	var req struct {
+		ProductIDs []uint `json:"product_ids"`
+		SkuIDs     []uint `json:"sku_ids"`
+	}
+	if len(ctx.Request.Body()) > 0 {
+		if err := ctx.BindJSON(&req); err != nil {
+			respondError(ctx, 400, ErrInvalidParams.WithCode(400).WithDetail(err.Error()))
+			return
+		}
+	}
+
+	result, err := checkoutService.Checkout(c, userID, checkoutService.Selection{
+		ProductIDs: req.ProductIDs,
+		SkuIDs:     req.SkuIDs,
+	})
+	if err != nil {
+		oerr := checkoutError(err)
+		respondError(ctx, oerr.Code, oerr)
+		return
+	}
+
+	ctx.JSON(200, map[string]interface{}{
+		"saga_id":     result.SagaID,
+		"order_no":    result.OrderNo,
+		"payment_id":  result.PaymentID,
+		"amount":      result.Amount,
+		"payment_url": result.PaymentURL,
+	})
+}
+
+// 下单流程错误转为订单错误
+func checkoutError(err error) *OrderError {
+	switch {
+	case errors.Is(err, checkoutService.ErrCartEmpty):
+		return ErrInvalidParams.WithCode(400).WithDetail(err.Error())
+	case errors.Is(err, orderService.ErrEmptyItems),
+		errors.Is(err, orderService.ErrInvalidItem),
+		errors.Is(err, orderService.ErrProductNotFound),
+		errors.Is(err, orderService.ErrProductOffShelf):
+		return pricingError(err)
+	}
+	if _, ok := kerrors.FromBizStatusError(err); ok {
+		return stockError(err)
+	}
+	zap.L().Error("下单流程执行失败", zap.Error(err))
+	return ErrOrderCreateFailed.WithCode(500)
+}
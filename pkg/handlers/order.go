@@ -2,44 +2,46 @@ package handlers
 
 import (
 	"context"
-	// "errors"
-	// "encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/kitex/pkg/kerrors"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	orderService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/order"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/util"
-	"github.com/go-redis/redis/v8"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // 订单相关错误定义
 var (
 	ErrInvalidParams     = NewOrderError("参数错误")
 	ErrProductNotFound   = NewOrderError("商品不存在")
+	ErrProductOffShelf   = NewOrderError("商品已下架")
 	ErrStockInsufficient = NewOrderError("库存不足")
+	ErrStockConflict     = NewOrderError("库存预占请求已失效，请重新下单")
 	ErrOrderCreateFailed = NewOrderError("订单创建失败")
 )
 
 type OrderHandler struct {
-	db          *gorm.DB
-	redisClient *redis.Client
-	orderNoGen  util.OrderNoGenerator
+	db         *gorm.DB
+	orderNoGen util.OrderNoGenerator
 }
 
-func NewOrderHandler(db *gorm.DB, redisClient *redis.Client) *OrderHandler {
+func NewOrderHandler(db *gorm.DB) *OrderHandler {
 	return &OrderHandler{
-		db:          db,
-		redisClient: redisClient,
-		orderNoGen:  util.NewSonyflakeGenerator(),
+		db:         db,
+		orderNoGen: util.NewSonyflakeGenerator(),
 	}
 }
 
 type CartItem struct {
 	ProductID uint `json:"product_id"`
+	SkuID     uint `json:"sku_id"` // 多规格商品必填，单规格商品可省略
 	Quantity  int  `json:"quantity"`
 }
 
@@ -47,16 +49,9 @@ type CartItem struct {
 // @Summary 创建新订单
 // @Router /orders [post]
 func (h *OrderHandler) CreateOrder(c context.Context, ctx *app.RequestContext) {
-	// 正确获取用户ID
-	userIDVal, exists := ctx.Get("userID")
-	if !exists {
-		respondError(ctx, 401, NewOrderError("用户未认证").WithCode(401))
-		return
-	}
-
-	userID, ok := userIDVal.(uint)
-	if !ok {
-		respondError(ctx, 401, NewOrderError("用户认证信息异常").WithCode(401))
+	userID, oerr := currentUserID(ctx)
+	if oerr != nil {
+		respondError(ctx, oerr.Code, oerr)
 		return
 	}
 
@@ -71,109 +66,253 @@ func (h *OrderHandler) CreateOrder(c context.Context, ctx *app.RequestContext) {
 		return
 	}
 
-	order, err := h.createOrderTransaction(userID, cartItems)
+	order, err := h.createOrderTransaction(c, userID, cartItems)
 	if err != nil {
 		respondError(ctx, err.Code, err)
 		return
 	}
 
-	go h.cleanCartAsync(userID, c)
+	// 购物车由订阅 OrderCreated 事件的购物车服务清理
 	ctx.JSON(200, order)
 }
 
+// ListOrders 我的订单列表
+// @Summary 按状态、创建时间过滤，游标分页
+// @Param status query string false "订单状态"
+// @Param start_time query string false "起始时间（RFC3339或2006-01-02）"
+// @Param end_time query string false "结束时间（RFC3339或2006-01-02，按日期时包含当天）"
+// @Param cursor query string false "上一页返回的next_cursor"
+// @Param limit query int false "每页数量，默认20，最大100"
+// @Router /orders [get]
+func (h *OrderHandler) ListOrders(c context.Context, ctx *app.RequestContext) {
+	userID, oerr := currentUserID(ctx)
+	if oerr != nil {
+		respondError(ctx, oerr.Code, oerr)
+		return
+	}
+
+	q := orderService.ListQuery{
+		UserID: userID,
+		Status: dal.OrderStatus(ctx.Query("status")),
+		Cursor: ctx.Query("cursor"),
+	}
+	var err error
+	if q.Start, err = parseTimeParam(ctx.Query("start_time"), false); err != nil {
+		respondError(ctx, 400, ErrInvalidParams.WithCode(400).WithDetail("start_time: "+err.Error()))
+		return
+	}
+	if q.End, err = parseTimeParam(ctx.Query("end_time"), true); err != nil {
+		respondError(ctx, 400, ErrInvalidParams.WithCode(400).WithDetail("end_time: "+err.Error()))
+		return
+	}
+	if limit := ctx.Query("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil || q.Limit <= 0 {
+			respondError(ctx, 400, ErrInvalidParams.WithCode(400).WithDetail("limit: "+limit))
+			return
+		}
+	}
+
+	result, err := orderService.ListOrders(c, q)
+	if err != nil {
+		respondOrderServiceError(ctx, err)
+		return
+	}
+
+	orders := make([]orderView, 0, len(result.Orders))
+	for i := range result.Orders {
+		orders = append(orders, newOrderView(&result.Orders[i]))
+	}
+	ctx.JSON(200, map[string]interface{}{
+		"orders":      orders,
+		"next_cursor": result.NextCursor,
+		"has_more":    result.NextCursor != "",
+	})
+}
+
+// GetOrder 订单详情
+// @Summary 返回订单头、商品行与状态流转历史，仅限订单所属用户
+// @Router /orders/{order_no} [get]
+func (h *OrderHandler) GetOrder(c context.Context, ctx *app.RequestContext) {
+	userID, oerr := currentUserID(ctx)
+	if oerr != nil {
+		respondError(ctx, oerr.Code, oerr)
+		return
+	}
+
+	detail, err := orderService.GetDetail(c, userID, ctx.Param("order_no"))
+	if err != nil {
+		respondOrderServiceError(ctx, err)
+		return
+	}
+
+	history := make([]statusHistoryView, 0, len(detail.History))
+	for _, record := range detail.History {
+		history = append(history, statusHistoryView{
+			FromStatus: record.FromStatus,
+			ToStatus:   record.ToStatus,
+			Actor:      record.Actor,
+			Reason:     record.Reason,
+			CreatedAt:  record.CreatedAt,
+		})
+	}
+	ctx.JSON(200, map[string]interface{}{
+		"order":   newOrderView(&detail.Order),
+		"items":   detail.Items,
+		"history": history,
+	})
+}
+
+// CancelOrder 用户取消订单
+// @Summary 取消未支付订单（配置允许时也可取消已支付未发货订单并转入退款），归还库存
+// @Router /orders/{order_no}/cancel [post]
+func (h *OrderHandler) CancelOrder(c context.Context, ctx *app.RequestContext) {
+	userID, oerr := currentUserID(ctx)
+	if oerr != nil {
+		respondError(ctx, oerr.Code, oerr)
+		return
+	}
+
+	// 请求体可选
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if len(ctx.Request.Body()) > 0 {
+		if err := ctx.BindJSON(&req); err != nil {
+			respondError(ctx, 400, ErrInvalidParams.WithCode(400).WithDetail(err.Error()))
+			return
+		}
+	}
+
+	order, err := orderService.Cancel(c, userID, ctx.Param("order_no"), req.Reason)
+	if err != nil {
+		respondOrderServiceError(ctx, err)
+		return
+	}
+	ctx.JSON(200, newOrderView(order))
+}
+
+// 订单头展示结构
+type orderView struct {
+	OrderNo   string          `json:"order_no"`
+	Status    dal.OrderStatus `json:"status"`
+	Amount    money.Money     `json:"amount"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+func newOrderView(o *dal.Order) orderView {
+	return orderView{
+		OrderNo:   o.OrderNo,
+		Status:    o.Status,
+		Amount:    o.Amount,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+}
+
+// 状态流转历史展示结构
+type statusHistoryView struct {
+	FromStatus dal.OrderStatus `json:"from_status"`
+	ToStatus   dal.OrderStatus `json:"to_status"`
+	Actor      string          `json:"actor"`
+	Reason     string          `json:"reason,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
 // 事务性订单创建
-func (h *OrderHandler) createOrderTransaction(userID uint, items []CartItem) (*dal.Order, *OrderError) {
+func (h *OrderHandler) createOrderTransaction(c context.Context, userID uint, items []CartItem) (*dal.Order, *OrderError) {
+	// 定价涉及RPC调用，在事务外完成，避免长时间占用数据库连接
+	lines, oerr := priceItems(c, items)
+	if oerr != nil {
+		return nil, oerr
+	}
+
+	// 先通过商品服务预扣库存，订单超时/取消时按同一幂等键归还
+	orderNo := h.orderNoGen.Generate()
+	if err := orderService.ReserveStock(c, orderNo, lines); err != nil {
+		return nil, stockError(err)
+	}
+
 	tx := h.db.Begin()
 	if tx.Error != nil {
+		h.releaseStock(orderNo, lines)
 		return nil, NewOrderError("事务启动失败").WithCode(500)
 	}
 
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
+			h.releaseStock(orderNo, lines)
 		}
 	}()
 
-	// 扣减库存
-	// if err := h.deductStock(tx, items); err != nil {
-	// 	tx.Rollback()
-	// 	return nil, err
-	// }
-
 	// 创建订单
-	order, err := h.createOrderRecord(tx, userID, items)
+	order, err := h.createOrderRecord(tx, userID, orderNo, lines)
 	if err != nil {
 		tx.Rollback()
+		h.releaseStock(orderNo, lines)
 		return nil, err
 	}
 
 	if err := tx.Commit().Error; err != nil {
+		h.releaseStock(orderNo, lines)
 		return nil, NewOrderError("事务提交失败").WithCode(500)
 	}
 
+	// 登记超时取消任务；失败时由定时扫表兜底
+	if err := orderService.ScheduleTimeout(c, order.OrderNo, order.CreatedAt); err != nil {
+		zap.L().Warn("订单超时任务登记失败",
+			zap.String("order_no", order.OrderNo),
+			zap.Error(err))
+	}
+
 	return order, nil
 }
 
-// Redis分布式锁示例
-// func acquireLock(key string, ttl time.Duration) bool {
-// 	result := redis.Client.SetNX(key, "locked", ttl)
-// 	return result.Val()
-// }
-
-// 扣减库存（带悲观锁）
-func (h *OrderHandler) deductStock(tx *gorm.DB, items []CartItem) *OrderError {
-	// 在扣库存前加锁
-	// if !acquireLock("product_lock:"+productID, 10*time.Second) {
-	// 	return errors.New("系统繁忙，请重试")
-	// }
-	// defer redis.Client.Del("product_lock:" + productID)
-	for _, item := range items {
-		var product dal.Product
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			First(&product, item.ProductID).Error; err != nil {
-
-			if err == gorm.ErrRecordNotFound {
-				return ErrProductNotFound.WithDetail(fmt.Sprintf("productID: %d", item.ProductID))
-			}
-			return NewOrderError("库存查询失败").WithCode(500)
-		}
-
-		if product.Stock < item.Quantity {
-			return ErrStockInsufficient.WithDetail(fmt.Sprintf("productID: %d, stock: %d", item.ProductID, product.Stock))
-		}
-
-		if err := tx.Model(&product).
-			Update("stock", gorm.Expr("stock - ?", item.Quantity)).Error; err != nil {
+// 订单创建失败时归还预扣库存，使用独立上下文避免请求结束后被取消
+func (h *OrderHandler) releaseStock(orderNo string, lines []dal.OrderItemSnapshot) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := orderService.ReleaseStock(ctx, orderNo, lines); err != nil {
+		zap.L().Error("预扣库存归还失败",
+			zap.String("order_no", orderNo),
+			zap.Error(err))
+	}
+}
 
-			zap.L().Error("库存扣减失败",
-				zap.Uint("productID", item.ProductID),
-				zap.Int("quantity", item.Quantity),
-				zap.Error(err))
-			return NewOrderError("库存更新失败").WithCode(500)
+// 商品服务扣减库存的业务错误转为订单错误
+func stockError(err error) *OrderError {
+	if bizErr, ok := kerrors.FromBizStatusError(err); ok {
+		switch bizErr.BizStatusCode() {
+		case 404:
+			return ErrProductNotFound.WithCode(404).WithDetail(err.Error())
+		case 409:
+			return ErrStockInsufficient.WithCode(409).WithDetail(err.Error())
+		case 410:
+			return ErrProductOffShelf.WithCode(400).WithDetail(err.Error())
+		case 422:
+			// 同一预扣请求已被归还（如超时取消或下单流程回滚），或请求ID被复用于不同商品/数量
+			return ErrStockConflict.WithCode(409).WithDetail(err.Error())
 		}
 	}
-	return nil
+	zap.L().Error("库存扣减失败", zap.Error(err))
+	return NewOrderError("库存扣减失败").WithCode(500)
 }
 
 // 创建订单记录
-func (h *OrderHandler) createOrderRecord(tx *gorm.DB, userID uint, items []CartItem) (*dal.Order, *OrderError) {
-	total, err := calculateTotal(items)
+func (h *OrderHandler) createOrderRecord(tx *gorm.DB, userID uint, orderNo string, lines []dal.OrderItemSnapshot) (*dal.Order, *OrderError) {
+	order, err := orderService.NewOrder(userID, orderNo, lines)
 	if err != nil {
-		return nil, NewOrderError("金额计算失败").WithCode(400)
-	}
-
-	order := &dal.Order{
-		UserID:  userID,
-		OrderNo: h.orderNoGen.Generate(),
-		Status:  dal.OrderStatusUnpaid,
-		Amount:  total,
-		Items:   marshalItems(items),
+		if errors.Is(err, money.ErrCurrencyMismatch) {
+			return nil, NewOrderError("金额计算失败").WithCode(400).WithDetail(err.Error())
+		}
+		return nil, NewOrderError("商品快照生成失败").WithCode(500)
 	}
 
-	if err := tx.Create(order).Error; err != nil {
+	if _, err := orderService.Insert(tx, order, lines); err != nil {
 		zap.L().Error("订单创建失败",
 			zap.Uint("userID", userID),
-			zap.Any("items", items),
+			zap.Any("items", lines),
 			zap.Error(err))
 		return nil, ErrOrderCreateFailed.WithCode(500)
 	}
@@ -181,23 +320,6 @@ func (h *OrderHandler) createOrderRecord(tx *gorm.DB, userID uint, items []CartI
 	return order, nil
 }
 
-// 异步清理购物车
-func (h *OrderHandler) cleanCartAsync(userID uint, c context.Context) {
-	const maxRetry = 3
-	key := fmt.Sprintf("cart:%d", userID)
-
-	for i := 0; i < maxRetry; i++ {
-		if err := h.redisClient.Del(c).Err(); err == nil {
-			return
-		}
-		time.Sleep(time.Duration(i+1) * 100 * time.Millisecond)
-	}
-
-	zap.L().Warn("购物车清理失败",
-		zap.Uint("userID", userID),
-		zap.String("key", key))
-}
-
 // OrderError 订单业务错误
 type OrderError struct {
 	Code    int    `json:"code"`
@@ -209,25 +331,100 @@ func NewOrderError(msg string) *OrderError {
 	return &OrderError{Message: msg}
 }
 
+// WithCode 返回副本，避免修改包级错误变量
 func (e *OrderError) WithCode(code int) *OrderError {
-	e.Code = code
-	return e
+	clone := *e
+	clone.Code = code
+	return &clone
 }
 
+// WithDetail 返回副本，避免修改包级错误变量
 func (e *OrderError) WithDetail(detail string) *OrderError {
-	e.Detail = detail
-	return e
+	clone := *e
+	clone.Detail = detail
+	return &clone
 }
 
 // 辅助函数
-func calculateTotal(items []CartItem) (float64, error) {
-	// 实现金额计算逻辑
-	return 0.0, nil
+
+// priceItems 通过订单领域服务定价，领域错误转为订单错误
+func priceItems(c context.Context, items []CartItem) ([]dal.OrderItemSnapshot, *OrderError) {
+	reqs := make([]orderService.LineRequest, 0, len(items))
+	for _, item := range items {
+		reqs = append(reqs, orderService.LineRequest{ProductID: item.ProductID, SkuID: item.SkuID, Quantity: item.Quantity})
+	}
+
+	lines, err := orderService.PriceLines(c, reqs)
+	if err != nil {
+		return nil, pricingError(err)
+	}
+	return lines, nil
 }
 
-func marshalItems(items []CartItem) string {
-	// 实现序列化逻辑
-	return ""
+// 定价错误转为订单错误
+func pricingError(err error) *OrderError {
+	switch {
+	case errors.Is(err, orderService.ErrEmptyItems),
+		errors.Is(err, orderService.ErrInvalidItem):
+		return ErrInvalidParams.WithCode(400).WithDetail(err.Error())
+	case errors.Is(err, orderService.ErrProductNotFound):
+		return ErrProductNotFound.WithCode(404).WithDetail(err.Error())
+	case errors.Is(err, orderService.ErrProductOffShelf):
+		return ErrProductOffShelf.WithCode(400).WithDetail(err.Error())
+	}
+	zap.L().Error("商品信息查询失败", zap.Error(err))
+	return NewOrderError("商品信息查询失败").WithCode(500)
+}
+
+// currentUserID 获取JWTAuth注入的用户ID
+func currentUserID(ctx *app.RequestContext) (uint, *OrderError) {
+	userIDVal, exists := ctx.Get("userID")
+	if !exists {
+		return 0, NewOrderError("用户未认证").WithCode(401)
+	}
+
+	userID, ok := userIDVal.(uint)
+	if !ok {
+		return 0, NewOrderError("用户认证信息异常").WithCode(401)
+	}
+	return userID, nil
+}
+
+// parseTimeParam 解析时间查询参数，支持RFC3339与日期格式
+// 日期格式作为结束时间时取次日零点，使结束日期当天包含在内。
+func parseTimeParam(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("时间格式错误: %s", value)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// 订单领域错误转为HTTP响应
+func respondOrderServiceError(ctx *app.RequestContext, err error) {
+	switch {
+	case errors.Is(err, orderService.ErrOrderNotFound):
+		respondError(ctx, 404, NewOrderError(err.Error()).WithCode(404))
+	case errors.Is(err, orderService.ErrInvalidStatus),
+		errors.Is(err, orderService.ErrInvalidCursor):
+		respondError(ctx, 400, ErrInvalidParams.WithCode(400).WithDetail(err.Error()))
+	case errors.Is(err, orderService.ErrInvalidTransition),
+		errors.Is(err, orderService.ErrStatusConflict),
+		errors.Is(err, orderService.ErrCancelNotAllowed):
+		respondError(ctx, 409, NewOrderError(err.Error()).WithCode(409))
+	default:
+		zap.L().Error("订单服务处理失败", zap.Error(err))
+		respondError(ctx, 500, NewOrderError("订单服务处理失败").WithCode(500))
+	}
 }
 
 // 统一错误响应方法
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/kitex/pkg/kerrors"
+)
+
+func TestStockError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		want     string
+	}{
+		{"商品不存在", kerrors.NewBizStatusError(404, "商品不存在"), 404, ErrProductNotFound.Message},
+		{"库存不足", kerrors.NewBizStatusError(409, "库存不足"), 409, ErrStockInsufficient.Message},
+		{"商品已下架", kerrors.NewBizStatusError(410, "商品已下架"), 400, ErrProductOffShelf.Message},
+		{"预扣已归还", kerrors.NewBizStatusError(422, "库存扣减请求已归还"), 409, ErrStockConflict.Message},
+		{"包装后的业务错误", fmt.Errorf("商品1（SKU 1）库存扣减失败: %w", kerrors.NewBizStatusError(422, "请求ID冲突")), 409, ErrStockConflict.Message},
+		{"其他错误", errors.New("连接超时"), 500, "库存扣减失败"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stockError(tt.err)
+			if got.Code != tt.wantCode || got.Message != tt.want {
+				t.Errorf("stockError() = %d %s，期望 %d %s", got.Code, got.Message, tt.wantCode, tt.want)
+			}
+			// 下单流程（checkout）中商品服务的业务错误走同一映射
+			if _, ok := kerrors.FromBizStatusError(tt.err); !ok {
+				return
+			}
+			if got := checkoutError(tt.err); got.Code != tt.wantCode || got.Message != tt.want {
+				t.Errorf("checkoutError() = %d %s，期望 %d %s", got.Code, got.Message, tt.wantCode, tt.want)
+			}
+		})
+	}
+}
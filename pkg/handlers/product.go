@@ -2,35 +2,391 @@ package handlers
 
 import (
 	"context"
-
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
-
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	productService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/product"
+	"go.uber.org/zap"
 )
 
-//商品查询
+// 商品查询
 func GetProduct(c context.Context, ctx *app.RequestContext) {
-    id := ctx.Param("id")
-    
-    var product dal.Product
-    if err := dal.DB.First(&product, id).Error; err != nil {
-        ctx.JSON(404, "商品不存在")
-        return
-    }
-    
-    ctx.JSON(200, product)
-}
-
-//创造商品
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, "商品ID格式错误")
+		return
+	}
+
+	detail, err := productService.GetDetail(c, uint(id))
+	if err != nil {
+		if errors.Is(err, productService.ErrProductNotFound) {
+			ctx.JSON(404, "商品不存在")
+			return
+		}
+		ctx.JSON(500, "商品查询失败")
+		return
+	}
+
+	ctx.JSON(200, detail.Product)
+}
+
+// SearchProducts 商品列表与搜索
+// @Param keyword query string false "匹配名称与描述"
+// @Param min_price query int false "最低价格（分）"
+// @Param max_price query int false "最高价格（分）"
+// @Param include_off_shelf query bool false "是否包含下架商品，默认false"
+// @Param category_id query int false "分类ID，包含所有下级分类的商品"
+// @Param attr.{code} query string false "按属性筛选，如 attr.color=red,blue、attr.size=40..42"
+// @Param sort query string false "created_desc（默认）/ price_asc / price_desc / sales_desc"
+// @Param cursor query string false "上一页返回的next_cursor"
+// @Param limit query int false "每页数量，默认20，最大100"
+// @Router /products [get]
+func SearchProducts(c context.Context, ctx *app.RequestContext) {
+	q := productService.SearchQuery{
+		Keyword: ctx.Query("keyword"),
+		Sort:    ctx.Query("sort"),
+		Cursor:  ctx.Query("cursor"),
+	}
+	var err error
+	if q.MinPriceCents, err = parsePriceParam(ctx.Query("min_price")); err != nil {
+		ctx.JSON(400, map[string]string{"error": "min_price格式错误"})
+		return
+	}
+	if q.MaxPriceCents, err = parsePriceParam(ctx.Query("max_price")); err != nil {
+		ctx.JSON(400, map[string]string{"error": "max_price格式错误"})
+		return
+	}
+	if v := ctx.Query("include_off_shelf"); v != "" {
+		if q.IncludeOffShelf, err = strconv.ParseBool(v); err != nil {
+			ctx.JSON(400, map[string]string{"error": "include_off_shelf格式错误"})
+			return
+		}
+	}
+	if v := ctx.Query("limit"); v != "" {
+		if q.Limit, err = strconv.Atoi(v); err != nil || q.Limit <= 0 {
+			ctx.JSON(400, map[string]string{"error": "limit格式错误"})
+			return
+		}
+	}
+	if v := ctx.Query("category_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			ctx.JSON(400, map[string]string{"error": "category_id格式错误"})
+			return
+		}
+		q.CategoryID = uint(id)
+	}
+	rawAttrs := map[string]string{}
+	ctx.QueryArgs().VisitAll(func(key, value []byte) {
+		if code, ok := strings.CutPrefix(string(key), "attr."); ok {
+			rawAttrs[code] = string(value)
+		}
+	})
+	if q.Attributes, err = productService.ParseAttributeFilters(rawAttrs); err != nil {
+		ctx.JSON(400, map[string]string{"error": err.Error()})
+		return
+	}
+
+	result, err := productService.Search(c, q)
+	if err != nil {
+		switch {
+		case errors.Is(err, productService.ErrInvalidParams),
+			errors.Is(err, productService.ErrInvalidCursor),
+			errors.Is(err, productService.ErrInvalidSort):
+			ctx.JSON(400, map[string]string{"error": err.Error()})
+		case errors.Is(err, productService.ErrCategoryNotFound):
+			ctx.JSON(404, map[string]string{"error": err.Error()})
+		default:
+			zap.L().Error("商品搜索失败", zap.Error(err))
+			ctx.JSON(500, map[string]string{"error": "商品查询失败"})
+		}
+		return
+	}
+
+	ctx.JSON(200, map[string]interface{}{
+		"products":    result.Products,
+		"next_cursor": result.NextCursor,
+		"has_more":    result.NextCursor != "",
+	})
+}
+
+func parsePriceParam(v string) (*int64, error) {
+	if v == "" {
+		return nil, nil
+	}
+	cents, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || cents < 0 {
+		return nil, errors.New("invalid price")
+	}
+	return &cents, nil
+}
+
+// 商品管理请求体，更新时为空的字段保持不变
+type productRequest struct {
+	Name        *string      `json:"name"`
+	Description *string      `json:"description"`
+	Price       *money.Money `json:"price"`
+	Stock       *int         `json:"stock"`
+	Status      *int         `json:"status"` // 1-上架 0-下架
+	SKUs        []skuRequest `json:"skus"`   // 仅创建时有效，为空时按price、stock创建单规格商品
+}
+
+// SKU请求体，更新时为空的字段保持不变
+type skuRequest struct {
+	Specs   map[string]string `json:"specs"` // 规格组合，如 {"color":"红色","size":"L"}
+	Price   *money.Money      `json:"price"`
+	Stock   *int              `json:"stock"`
+	Barcode *string           `json:"barcode"`
+	Status  *int              `json:"status"`
+}
+
+// CreateProduct 创建商品（商家/管理员）
 func CreateProduct(c context.Context, ctx *app.RequestContext) {
-    id := ctx.Param("id")
-    
-    var product dal.Product
-    if err := dal.DB.First(&product, id).Error; err != nil {
-        ctx.JSON(404, "商品不存在")
-        return
-    }
-    
-    ctx.JSON(200, product)
-}
\ No newline at end of file
+	var req productRequest
+	if err := ctx.BindJSON(&req); err != nil {
+		ctx.JSON(400, map[string]string{"error": "无效请求参数"})
+		return
+	}
+	if req.Name == nil {
+		ctx.JSON(400, map[string]string{"error": "商品名称必须填写"})
+		return
+	}
+
+	in := productService.CreateInput{
+		Name:   *req.Name,
+		Status: req.Status,
+	}
+	if len(req.SKUs) == 0 {
+		if req.Price == nil || req.Stock == nil {
+			ctx.JSON(400, map[string]string{"error": "商品价格和库存必须填写"})
+			return
+		}
+		in.Price, in.Stock = *req.Price, *req.Stock
+	}
+	for _, sku := range req.SKUs {
+		if sku.Price == nil || sku.Stock == nil {
+			ctx.JSON(400, map[string]string{"error": "SKU价格和库存必须填写"})
+			return
+		}
+		in.SKUs = append(in.SKUs, skuInput(sku))
+	}
+	if req.Description != nil {
+		in.Description = *req.Description
+	}
+	product, err := productService.Create(c, currentOperator(ctx), in)
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(201, product)
+}
+
+// UpdateProduct 部分更新商品，上下架通过status修改
+func UpdateProduct(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "商品ID格式错误"})
+		return
+	}
+	var req productRequest
+	if err := ctx.BindJSON(&req); err != nil {
+		ctx.JSON(400, map[string]string{"error": "无效请求参数"})
+		return
+	}
+
+	product, err := productService.Update(c, currentOperator(ctx), uint(id), productService.UpdateInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Price:       req.Price,
+		Stock:       req.Stock,
+		Status:      req.Status,
+	})
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(200, product)
+}
+
+// DeleteProduct 软删除商品
+func DeleteProduct(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "商品ID格式错误"})
+		return
+	}
+	if err := productService.Delete(c, currentOperator(ctx), uint(id)); err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(200, map[string]string{"status": "deleted"})
+}
+
+// ListProductAudits 查询商品变更记录
+func ListProductAudits(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "商品ID格式错误"})
+		return
+	}
+	audits, err := productService.ListAudits(c, currentOperator(ctx), uint(id))
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+
+	views := make([]map[string]interface{}, 0, len(audits))
+	for _, a := range audits {
+		views = append(views, map[string]interface{}{
+			"actor":      a.Actor,
+			"action":     a.Action,
+			"changes":    json.RawMessage(a.Changes),
+			"created_at": a.CreatedAt,
+		})
+	}
+	ctx.JSON(200, map[string]interface{}{"audits": views})
+}
+
+// currentOperator 操作人信息由JWTAuth与RequireRole写入上下文
+func currentOperator(ctx *app.RequestContext) productService.Operator {
+	return productService.Operator{
+		UserID: ctx.GetUint("userID"),
+		Role:   ctx.GetString("role"),
+	}
+}
+
+// 商品管理错误转HTTP响应
+func respondCatalogError(ctx *app.RequestContext, err error) {
+	switch {
+	case errors.Is(err, productService.ErrInvalidParams),
+		errors.Is(err, productService.ErrNoChanges):
+		ctx.JSON(400, map[string]string{"error": err.Error()})
+	case errors.Is(err, productService.ErrForbidden):
+		ctx.JSON(403, map[string]string{"error": err.Error()})
+	case errors.Is(err, productService.ErrCategoryInUse):
+		ctx.JSON(409, map[string]string{"error": err.Error()})
+	case errors.Is(err, productService.ErrProductNotFound),
+		errors.Is(err, productService.ErrCategoryNotFound):
+		ctx.JSON(404, map[string]string{"error": err.Error()})
+	default:
+		zap.L().Error("商品管理操作失败", zap.Error(err))
+		ctx.JSON(500, map[string]string{"error": "系统错误，商品操作失败"})
+	}
+}
+
+// ListProductSKUs 查询商品的SKU
+func ListProductSKUs(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "商品ID格式错误"})
+		return
+	}
+	skus, err := productService.ListSKUs(c, uint(id))
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+
+	views := make([]map[string]interface{}, 0, len(skus))
+	for i := range skus {
+		views = append(views, skuView(&skus[i]))
+	}
+	ctx.JSON(200, map[string]interface{}{"skus": views})
+}
+
+// CreateSKU 为商品新增SKU（商家/管理员）
+func CreateSKU(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "商品ID格式错误"})
+		return
+	}
+	var req skuRequest
+	if err := ctx.BindJSON(&req); err != nil {
+		ctx.JSON(400, map[string]string{"error": "无效请求参数"})
+		return
+	}
+	if req.Price == nil || req.Stock == nil {
+		ctx.JSON(400, map[string]string{"error": "SKU价格和库存必须填写"})
+		return
+	}
+
+	sku, err := productService.CreateSKU(c, currentOperator(ctx), uint(id), skuInput(req))
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(201, skuView(sku))
+}
+
+// UpdateSKU 部分更新SKU（商家/管理员）
+func UpdateSKU(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "SKU ID格式错误"})
+		return
+	}
+	var req skuRequest
+	if err := ctx.BindJSON(&req); err != nil {
+		ctx.JSON(400, map[string]string{"error": "无效请求参数"})
+		return
+	}
+
+	sku, err := productService.UpdateSKU(c, currentOperator(ctx), uint(id), productService.SKUUpdate{
+		Specs:   req.Specs,
+		Price:   req.Price,
+		Stock:   req.Stock,
+		Barcode: req.Barcode,
+		Status:  req.Status,
+	})
+	if err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(200, skuView(sku))
+}
+
+// DeleteSKU 删除SKU（商家/管理员）
+func DeleteSKU(c context.Context, ctx *app.RequestContext) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, map[string]string{"error": "SKU ID格式错误"})
+		return
+	}
+	if err := productService.DeleteSKU(c, currentOperator(ctx), uint(id)); err != nil {
+		respondCatalogError(ctx, err)
+		return
+	}
+	ctx.JSON(200, map[string]string{"status": "deleted"})
+}
+
+func skuInput(req skuRequest) productService.SKUInput {
+	in := productService.SKUInput{
+		Specs:  req.Specs,
+		Price:  *req.Price,
+		Stock:  *req.Stock,
+		Status: req.Status,
+	}
+	if req.Barcode != nil {
+		in.Barcode = *req.Barcode
+	}
+	return in
+}
+
+func skuView(s *dal.SKU) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         s.ID,
+		"product_id": s.ProductID,
+		"specs":      productService.SKUSpecs(s),
+		"price":      s.Price,
+		"stock":      s.Stock,
+		"sales":      s.Sales,
+		"barcode":    s.Barcode,
+		"status":     s.Status,
+	}
+}
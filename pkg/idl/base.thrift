@@ -0,0 +1,8 @@
+// pkg/idl/base.thrift
+namespace go base
+
+// 金额，以最小货币单位（分）表示
+struct Money {
+    1: required i64 cents
+    2: required string currency // ISO 4217，如CNY
+}
@@ -4,11 +4,13 @@ struct CartItem {
     1: i64 user_id
     2: i64 product_id
     3: i32 quantity
+    4: optional i64 sku_id // 购物车按SKU记录；只有一个SKU的商品可只传product_id
 }
 
 struct CartRequest {
     1: i64 user_id
     2: optional i64 product_id
+    3: optional i64 sku_id
 }
 
 struct CartResponse {
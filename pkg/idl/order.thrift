@@ -1,8 +1,10 @@
 namespace go order
 
 struct UpdateReq {
-    1: string orderID
+    1: string orderID           // 订单号（order_no）
     2: string status
+    3: optional string actor    // 操作方，用于状态流转记录
+    4: optional string reason   // 变更原因
 }
 
 service OrderService {
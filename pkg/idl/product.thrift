@@ -1,11 +1,31 @@
 // pkg/idl/product.thrift
 namespace go product
 
+include "base.thrift"
+
+// 商品的可售单元，价格与库存按SKU维护
+struct SkuInfo {
+    1: required i64 id
+    2: required i64 product_id
+    3: required string product_name
+    4: required map<string, string> specs // 规格组合，如 color: 红色、size: L；单规格商品为空
+    5: required base.Money price
+    6: required i32 stock
+    7: i32 status                         // 1-可售 0-不可售（SKU或商品已下架）
+    8: optional string barcode
+}
+
 struct ProductInfo {
     1: required i64 id
     2: required string name
-    3: required double price
-    4: required i32 stock
+    3: required double price // 已废弃：起售价（元），保留给旧客户端，新代码使用 price_money
+    4: required i32 stock        // 所有SKU的库存之和
+    5: i32 status // 1-上架 0-下架
+    6: optional string description
+    7: optional i32 sales
+    8: optional i64 category_id // 0表示未分类
+    9: optional list<SkuInfo> skus
+    10: optional base.Money price_money // 起售价（上架SKU的最低价）
 }
 
 struct GetProductReq {
@@ -15,9 +35,49 @@ struct GetProductReq {
 struct DecreaseStockReq {
     1: required i64 product_id
     2: required i32 quantity
+    3: required string request_id // 幂等键，重试时必须保持不变
+    4: optional i64 sku_id        // 扣减的SKU，只有一个SKU的商品可省略
+}
+
+// 归还一次扣减的库存（如订单取消），按原扣减的request_id幂等
+struct ReleaseStockReq {
+    1: required string request_id // 原DecreaseStockReq的request_id
+    2: required i64 product_id
+}
+
+// 商品搜索，未设置的条件不过滤；价格以分为单位
+struct SearchProductsReq {
+    1: optional string keyword        // 匹配名称与描述
+    2: optional i64 min_price_cents
+    3: optional i64 max_price_cents
+    4: optional bool include_off_shelf // 默认只返回上架商品
+    5: optional string sort            // created_desc（默认）/ price_asc / price_desc / sales_desc
+    6: optional string cursor          // 上一页返回的next_cursor
+    7: optional i32 limit              // 默认20，最大100
+    8: optional i64 category_id        // 包含所有下级分类的商品
+    9: optional map<string, string> attributes // 按属性编码筛选，值为逗号分隔的可选值或 min..max 数值范围
+}
+
+struct SearchProductsResp {
+    1: required list<ProductInfo> products
+    2: optional string next_cursor     // 为空表示没有更多数据
+}
+
+// 按ID批量查询商品，不存在的ID不返回
+struct ListProductsReq {
+    1: required list<i64> product_ids
+}
+
+// 按SKU ID批量查询，不存在或已删除的ID不返回
+struct ListSkusReq {
+    1: required list<i64> sku_ids
 }
 
 service ProductService {
     ProductInfo GetProduct(1: GetProductReq req)
+    list<ProductInfo> ListProducts(1: ListProductsReq req)
+    SearchProductsResp SearchProducts(1: SearchProductsReq req)
+    list<SkuInfo> ListSkus(1: ListSkusReq req)
     bool DecreaseStock(1: DecreaseStockReq req)
+    bool ReleaseStock(1: ReleaseStockReq req)
 }
\ No newline at end of file
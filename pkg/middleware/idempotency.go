@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	goredis "github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 128
+)
+
+var (
+	// 键仍为本请求的处理中记录时续期
+	refreshLockScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	// 以处理结果替换本请求的处理中记录，保存结果与释放锁是同一步操作
+	storeResultScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	return 1
+end
+return 0`)
+	// 删除本请求的处理中记录
+	releaseLockScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// 处理中状态的过期时间，默认1分钟
+// 处理期间定期续期，只在进程异常退出后过期，防止键被永久占用。
+func idempotencyLockTTL() time.Duration {
+	if s := config.Conf.Idempotency.LockTTLSeconds; s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return time.Minute
+}
+
+// 幂等记录，JSON存储在Redis中
+type idempotencyRecord struct {
+	State       string `json:"state"` // processing / done
+	Fingerprint string `json:"fingerprint"`
+	Token       string `json:"token,omitempty"` // 处理中记录的持有者，锁过期被其他请求重新获取后不再续期或覆盖
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Idempotency 基于 Idempotency-Key 请求头的幂等中间件（放在JWTAuth之后时按用户隔离）
+// 同一用户在ttl内使用相同的键重复请求时直接返回首次的响应；首次请求仍在处理中时返回409；
+// 相同的键携带不同请求体时返回422。未携带请求头的请求不受影响，5xx响应不会被记录以便客户端重试。
+// 处理中状态在处理期间定期续期，处理结果直接替换处理中状态保存，两者之间没有键不存在的窗口。
+func Idempotency(scope string, ttl time.Duration) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		idemKey := string(c.GetHeader(IdempotencyKeyHeader))
+		if idemKey == "" {
+			c.Next(ctx)
+			return
+		}
+		if len(idemKey) > maxIdempotencyKeyLen {
+			c.JSON(400, map[string]string{"error": "Idempotency-Key过长"})
+			c.Abort()
+			return
+		}
+
+		key := fmt.Sprintf("idempotency:%s:%d:%s", scope, c.GetUint("userID"), idemKey)
+		sum := sha256.Sum256(c.Request.Body())
+		fingerprint := hex.EncodeToString(sum[:])
+
+		token := make([]byte, 16)
+		_, _ = rand.Read(token)
+		lock, _ := json.Marshal(idempotencyRecord{State: "processing", Fingerprint: fingerprint, Token: hex.EncodeToString(token)})
+		lockTTL := idempotencyLockTTL()
+		acquired, err := redis.Client.SetNX(ctx, key, lock, lockTTL).Result()
+		if err != nil {
+			zap.L().Error("幂等键写入失败", zap.String("key", key), zap.Error(err))
+			c.JSON(503, map[string]string{"error": "服务繁忙，请稍后重试"})
+			c.Abort()
+			return
+		}
+
+		if !acquired {
+			replayIdempotent(ctx, c, key, fingerprint)
+			return
+		}
+
+		stop := keepIdempotencyLock(key, lock, lockTTL)
+		c.Next(ctx)
+		stop()
+
+		status := c.Response.StatusCode()
+		if status >= 500 {
+			// 服务端错误允许客户端使用同一个键重试
+			if err := releaseLockScript.Run(context.Background(), redis.Client, []string{key}, lock).Err(); err != nil {
+				zap.L().Warn("幂等键释放失败", zap.String("key", key), zap.Error(err))
+			}
+			return
+		}
+
+		record, _ := json.Marshal(idempotencyRecord{
+			State:       "done",
+			Fingerprint: fingerprint,
+			StatusCode:  status,
+			ContentType: string(c.Response.Header.ContentType()),
+			Body:        c.Response.Body(),
+		})
+		stored, err := storeResultScript.Run(context.Background(), redis.Client, []string{key},
+			lock, record, ttl.Milliseconds()).Int()
+		if err != nil {
+			zap.L().Error("幂等结果保存失败", zap.String("key", key), zap.Error(err))
+			return
+		}
+		if stored == 0 {
+			zap.L().Warn("幂等键已不再由本请求持有，结果未保存", zap.String("key", key))
+		}
+	}
+}
+
+// keepIdempotencyLock 请求处理期间定期续期处理中记录，返回的stop在续期协程退出后返回
+func keepIdempotencyLock(key string, lock []byte, lockTTL time.Duration) (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(lockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				n, err := refreshLockScript.Run(context.Background(), redis.Client, []string{key},
+					lock, lockTTL.Milliseconds()).Int()
+				if err != nil {
+					zap.L().Warn("幂等键续期失败", zap.String("key", key), zap.Error(err))
+					continue
+				}
+				if n == 0 {
+					zap.L().Warn("幂等键已过期或被其他请求持有", zap.String("key", key))
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
+
+// 重复请求：返回首次请求的结果
+func replayIdempotent(ctx context.Context, c *app.RequestContext, key, fingerprint string) {
+	defer c.Abort()
+
+	data, err := redis.Client.Get(ctx, key).Bytes()
+	if err == goredis.Nil {
+		// 首次请求恰好失败并释放了键
+		c.JSON(409, map[string]string{"error": "请求处理中，请稍后重试"})
+		return
+	}
+	if err != nil {
+		zap.L().Error("幂等记录读取失败", zap.String("key", key), zap.Error(err))
+		c.JSON(503, map[string]string{"error": "服务繁忙，请稍后重试"})
+		return
+	}
+
+	var record idempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		c.JSON(500, map[string]string{"error": "幂等记录损坏"})
+		return
+	}
+	if record.Fingerprint != fingerprint {
+		c.JSON(422, map[string]string{"error": "Idempotency-Key已用于其他请求"})
+		return
+	}
+	if record.State != "done" {
+		c.JSON(409, map[string]string{"error": "请求处理中，请稍后重试"})
+		return
+	}
+
+	c.Response.Header.Set("Idempotent-Replayed", "true")
+	c.Data(record.StatusCode, record.ContentType, record.Body)
+}
@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	appconfig "github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+// newIdempotencyEngine 注册一个经过幂等中间件的接口，返回调用次数计数
+// 登录用户由 X-User 请求头模拟，响应状态码由 status 查询参数指定。
+func newIdempotencyEngine(t *testing.T) (*route.Engine, *miniredis.Miniredis, *int) {
+	t.Helper()
+	mr := testutil.Redis(t)
+	testutil.Config(t, nil)
+
+	calls := 0
+	engine := route.NewEngine(config.NewOptions(nil))
+	engine.POST("/orders", func(ctx context.Context, c *app.RequestContext) {
+		if v := string(c.GetHeader("X-User")); v != "" {
+			userID, _ := strconv.Atoi(v)
+			c.Set("userID", uint(userID))
+		}
+		c.Next(ctx)
+	}, Idempotency("test", time.Hour), func(ctx context.Context, c *app.RequestContext) {
+		calls++
+		status := 200
+		if v := c.Query("status"); v != "" {
+			status, _ = strconv.Atoi(v)
+		}
+		c.JSON(status, map[string]int{"call": calls})
+	})
+	return engine, mr, &calls
+}
+
+func TestIdempotency(t *testing.T) {
+	engine, mr, calls := newIdempotencyEngine(t)
+	// 相同请求体的首次请求仍在处理中
+	sum := sha256.Sum256([]byte(`{"a":1}`))
+	lock, _ := json.Marshal(idempotencyRecord{State: "processing", Fingerprint: hex.EncodeToString(sum[:])})
+	mr.Set("idempotency:test:1:processing", string(lock))
+
+	tests := []struct {
+		name         string
+		user         string
+		key          string
+		body         string
+		query        string
+		wantStatus   int
+		wantCalls    int
+		wantReplayed bool
+		wantBody     string // 非空时校验响应体
+	}{
+		{"首次请求", "1", "k1", `{"a":1}`, "", 200, 1, false, `{"call":1}`},
+		{"重复请求返回首次结果", "1", "k1", `{"a":1}`, "", 200, 1, true, `{"call":1}`},
+		{"同一个键不同请求体", "1", "k1", `{"a":2}`, "", 422, 1, false, ""},
+		{"其他用户使用相同的键", "2", "k1", `{"a":1}`, "", 200, 2, false, `{"call":2}`},
+		{"未携带键", "1", "", `{"a":1}`, "", 200, 3, false, ""},
+		{"键过长", "1", strings.Repeat("k", maxIdempotencyKeyLen+1), `{"a":1}`, "", 400, 3, false, ""},
+		{"首次请求处理中", "1", "processing", `{"a":1}`, "", 409, 3, false, ""},
+		{"客户端错误也被记录", "1", "k400", `{"a":1}`, "status=400", 400, 4, false, ""},
+		{"客户端错误重复请求", "1", "k400", `{"a":1}`, "status=400", 400, 4, true, ""},
+		{"服务端错误", "1", "k500", `{"a":1}`, "status=500", 500, 5, false, ""},
+		{"服务端错误后允许重试", "1", "k500", `{"a":1}`, "", 200, 6, false, `{"call":6}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := []ut.Header{{Key: "X-User", Value: tt.user}, {Key: "Content-Type", Value: "application/json"}}
+			if tt.key != "" {
+				headers = append(headers, ut.Header{Key: IdempotencyKeyHeader, Value: tt.key})
+			}
+			url := "/orders"
+			if tt.query != "" {
+				url += "?" + tt.query
+			}
+			w := ut.PerformRequest(engine, "POST", url,
+				&ut.Body{Body: bytes.NewBufferString(tt.body), Len: len(tt.body)}, headers...)
+			resp := w.Result()
+
+			if resp.StatusCode() != tt.wantStatus {
+				t.Errorf("状态码 = %d，期望 %d（%s）", resp.StatusCode(), tt.wantStatus, resp.Body())
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("处理次数 = %d，期望 %d", *calls, tt.wantCalls)
+			}
+			if replayed := string(resp.Header.Peek("Idempotent-Replayed")) == "true"; replayed != tt.wantReplayed {
+				t.Errorf("重放标记 = %v，期望 %v", replayed, tt.wantReplayed)
+			}
+			if tt.wantBody != "" && string(resp.Body()) != tt.wantBody {
+				t.Errorf("响应体 = %s，期望 %s", resp.Body(), tt.wantBody)
+			}
+		})
+	}
+}
+
+// 处理时间超过锁过期时间的请求：处理中状态被续期，重复请求仍返回409而不会再次执行
+func TestIdempotencyLockRefresh(t *testing.T) {
+	mr := testutil.Redis(t)
+	testutil.Config(t, &appconfig.Config{Idempotency: appconfig.IdempotencyConfig{LockTTLSeconds: 1}})
+	const key = "idempotency:test:0:slow"
+
+	engine := route.NewEngine(config.NewOptions(nil))
+	var remaining time.Duration
+	var retryStatus int
+	engine.POST("/orders", Idempotency("test", time.Hour), func(ctx context.Context, c *app.RequestContext) {
+		// 锁即将过期，等待续期协程续期
+		mr.FastForward(900 * time.Millisecond)
+		time.Sleep(500 * time.Millisecond)
+		remaining = mr.TTL(key)
+		mr.FastForward(900 * time.Millisecond)
+
+		retry := ut.PerformRequest(engine, "POST", "/orders", nil, ut.Header{Key: IdempotencyKeyHeader, Value: "slow"})
+		retryStatus = retry.Result().StatusCode()
+		c.JSON(201, map[string]string{"order": "1"})
+	})
+
+	w := ut.PerformRequest(engine, "POST", "/orders", nil, ut.Header{Key: IdempotencyKeyHeader, Value: "slow"})
+	if w.Result().StatusCode() != 201 {
+		t.Fatalf("状态码 = %d，期望 201", w.Result().StatusCode())
+	}
+	if remaining <= 500*time.Millisecond {
+		t.Errorf("处理中状态剩余时间 = %v，期望已续期", remaining)
+	}
+	if retryStatus != 409 {
+		t.Errorf("处理中的重复请求状态码 = %d，期望 409", retryStatus)
+	}
+
+	// 处理结果替换处理中状态，按ttl保存
+	var record idempotencyRecord
+	data, _ := mr.Get(key)
+	if err := json.Unmarshal([]byte(data), &record); err != nil || record.State != "done" || record.StatusCode != 201 {
+		t.Errorf("幂等记录 = %s，期望保存201结果", data)
+	}
+	if ttl := mr.TTL(key); ttl != time.Hour {
+		t.Errorf("幂等记录过期时间 = %v，期望 1h", ttl)
+	}
+}
+
+// 锁已过期并被其他请求重新获取时，不覆盖对方的处理中状态
+func TestIdempotencyLockLost(t *testing.T) {
+	mr := testutil.Redis(t)
+	testutil.Config(t, nil)
+	const key = "idempotency:test:0:lost"
+	other, _ := json.Marshal(idempotencyRecord{State: "processing", Token: "other"})
+
+	engine := route.NewEngine(config.NewOptions(nil))
+	engine.POST("/orders", Idempotency("test", time.Hour), func(ctx context.Context, c *app.RequestContext) {
+		mr.Set(key, string(other))
+		c.JSON(200, map[string]string{"order": "1"})
+	})
+	ut.PerformRequest(engine, "POST", "/orders", nil, ut.Header{Key: IdempotencyKeyHeader, Value: "lost"})
+	if data, _ := mr.Get(key); data != string(other) {
+		t.Errorf("幂等记录 = %s，期望保留其他请求的处理中状态", data)
+	}
+
+	// 服务端错误同样只释放本请求持有的键
+	mr.Del(key)
+	engine.POST("/fail", Idempotency("test", time.Hour), func(ctx context.Context, c *app.RequestContext) {
+		mr.Set(key, string(other))
+		c.JSON(500, map[string]string{"error": "失败"})
+	})
+	if w := ut.PerformRequest(engine, "POST", "/fail", nil, ut.Header{Key: IdempotencyKeyHeader, Value: "lost"}); w.Result().StatusCode() != 500 {
+		t.Fatalf("状态码 = %d，期望 500", w.Result().StatusCode())
+	}
+	if data, _ := mr.Get(key); data != string(other) {
+		t.Errorf("幂等记录 = %s，期望保留其他请求的处理中状态", data)
+	}
+}
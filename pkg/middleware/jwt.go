@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"os"
+	"time"
 
 	
 	"gopkg.in/yaml.v3"
@@ -15,6 +17,12 @@ var (
 	initialized   = false  // 配置初始化标志
 )
 
+// 令牌解析错误
+var (
+	ErrTokenInvalid = errors.New("无效令牌")
+	ErrTokenClaims  = errors.New("令牌解析失败")
+)
+
 // 初始化时加载配置
 var (
 	WhitelistMap = make(map[string]bool)
@@ -74,32 +82,46 @@ func JWTAuth() app.HandlerFunc {
 			return
 		}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return JwtSecret, nil
-		})
-
-
-		if err != nil || !token.Valid {
-			c.JSON(401, map[string]string{"error": "无效令牌"})
+		userID, err := ParseToken(tokenString)
+		if err != nil {
+			c.JSON(401, map[string]string{"error": err.Error()})
 			c.Abort()
 			return
 		}
 
-		//JWT解析部分
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			if userID, exists := claims["userID"]; exists {
-				if uid, ok := userID.(float64); ok { // JWT数字默认解析为float64
-					c.Set("userID", uint(uid))//数值类型转换为uint
-					c.Next(ctx)
-					return
-				}
+		c.Set("userID", userID)
+		c.Next(ctx)
+	}
+}
+
+// GenerateToken 为用户签发JWT令牌（有效期2小时）
+func GenerateToken(userID uint) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"userID": userID,
+		"exp":    time.Now().Add(2 * time.Hour).Unix(),
+	})
+	return token.SignedString(JwtSecret)
+}
+
+// ParseToken 校验令牌并取出其中的用户ID
+func ParseToken(tokenString string) (uint, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return JwtSecret, nil
+	})
+	if err != nil || !token.Valid {
+		return 0, ErrTokenInvalid
+	}
+
+	//JWT解析部分
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		if userID, exists := claims["userID"]; exists {
+			if uid, ok := userID.(float64); ok { // JWT数字默认解析为float64
+				return uint(uid), nil //数值类型转换为uint
 			}
 		}
-		
-		c.JSON(401, map[string]string{"error": "令牌解析失败"})
-		c.Abort()
 	}
+	return 0, ErrTokenClaims
 }
\ No newline at end of file
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"gorm.io/gorm"
+)
+
+// RequireRole 只允许指定角色的用户访问（需放在JWTAuth之后）
+// 角色每次从数据库读取，调整角色后无需重新登录即可生效；通过后角色保存在上下文的role中。
+func RequireRole(roles ...string) app.HandlerFunc {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
+	return func(ctx context.Context, c *app.RequestContext) {
+		userID := c.GetUint("userID")
+		if userID == 0 {
+			c.JSON(401, map[string]string{"error": "未登录"})
+			c.Abort()
+			return
+		}
+
+		var u dal.User
+		if err := dal.DB.WithContext(ctx).Select("role").First(&u, userID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(401, map[string]string{"error": "用户不存在"})
+			} else {
+				c.JSON(500, map[string]string{"error": "权限校验失败"})
+			}
+			c.Abort()
+			return
+		}
+		if !allowed[u.Role] {
+			c.JSON(403, map[string]string{"error": "无权访问"})
+			c.Abort()
+			return
+		}
+
+		c.Set("role", u.Role)
+		c.Next(ctx)
+	}
+}
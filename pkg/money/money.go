@@ -0,0 +1,99 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/base"
+)
+
+// DefaultCurrency 默认币种（ISO 4217）
+const DefaultCurrency = "CNY"
+
+var ErrCurrencyMismatch = errors.New("币种不一致")
+
+// Money 金额，以最小货币单位（分）存储，避免浮点误差
+// 在GORM模型中以 embedded;embeddedPrefix:xxx_ 方式嵌入，对应 xxx_cents / xxx_currency 两列
+type Money struct {
+	Cents    int64  `gorm:"not null;default:0" json:"cents"`
+	Currency string `gorm:"type:char(3);not null;default:'CNY'" json:"currency"`
+}
+
+// New 创建指定币种的金额
+func New(cents int64, currency string) Money {
+	return Money{Cents: cents, Currency: currency}
+}
+
+// FromCents 创建默认币种的金额
+func FromCents(cents int64) Money {
+	return Money{Cents: cents, Currency: DefaultCurrency}
+}
+
+// Zero 返回指定币种的零值
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// Add 金额相加，币种必须一致
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s != %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return Money{Cents: m.Cents + other.Cents, Currency: m.Currency}, nil
+}
+
+// Sub 金额相减，币种必须一致
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s != %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return Money{Cents: m.Cents - other.Cents, Currency: m.Currency}, nil
+}
+
+// Mul 乘以数量
+func (m Money) Mul(quantity int64) Money {
+	return Money{Cents: m.Cents * quantity, Currency: m.Currency}
+}
+
+// Equal 金额与币种均相同
+func (m Money) Equal(other Money) bool {
+	return m.Cents == other.Cents && m.Currency == other.Currency
+}
+
+// IsZero 金额是否为零
+func (m Money) IsZero() bool {
+	return m.Cents == 0
+}
+
+// String 以"CNY 12.34"格式输出，用于日志与展示
+func (m Money) String() string {
+	sign := ""
+	cents := m.Cents
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s %s%d.%02d", m.Currency, sign, cents/100, cents%100)
+}
+
+// Yuan 以元为单位的浮点值，仅用于填充旧版本RPC的浮点金额字段，不能参与计算
+func (m Money) Yuan() float64 {
+	return float64(m.Cents) / 100
+}
+
+// FromIDL 从RPC结构转换，币种缺省时使用默认币种
+func FromIDL(m *base.Money) Money {
+	if m == nil {
+		return Zero(DefaultCurrency)
+	}
+	currency := m.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return Money{Cents: m.Cents, Currency: currency}
+}
+
+// ToIDL 转换为RPC结构
+func (m Money) ToIDL() *base.Money {
+	return &base.Money{Cents: m.Cents, Currency: m.Currency}
+}
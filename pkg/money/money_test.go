@@ -0,0 +1,107 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/base"
+)
+
+func TestArithmetic(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func() (Money, error)
+		want    Money
+		wantErr error
+	}{
+		{"相加", func() (Money, error) { return FromCents(1050).Add(FromCents(25)) }, FromCents(1075), nil},
+		{"相减为负", func() (Money, error) { return FromCents(100).Sub(FromCents(250)) }, FromCents(-150), nil},
+		{"乘以数量", func() (Money, error) { return FromCents(199).Mul(3), nil }, FromCents(597), nil},
+		{"相加币种不一致", func() (Money, error) { return FromCents(100).Add(New(100, "USD")) }, Money{}, ErrCurrencyMismatch},
+		{"相减币种不一致", func() (Money, error) { return New(100, "USD").Sub(FromCents(100)) }, Money{}, ErrCurrencyMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("错误 = %v，期望 %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("结果 = %v，期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{FromCents(1234), "CNY 12.34"},
+		{FromCents(5), "CNY 0.05"},
+		{FromCents(-5), "CNY -0.05"},
+		{FromCents(-1200), "CNY -12.00"},
+		{New(0, "USD"), "USD 0.00"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("Money{%d %s}.String() = %q，期望 %q", tt.m.Cents, tt.m.Currency, got, tt.want)
+		}
+	}
+}
+
+func TestFromIDL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *base.Money
+		want Money
+	}{
+		{"为空", nil, Zero(DefaultCurrency)},
+		{"缺省币种", &base.Money{Cents: 100}, FromCents(100)},
+		{"指定币种", &base.Money{Cents: 100, Currency: "USD"}, New(100, "USD")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromIDL(tt.in); !got.Equal(tt.want) {
+				t.Errorf("FromIDL() = %v，期望 %v", got, tt.want)
+			}
+			if got := FromIDL(tt.want.ToIDL()); !got.Equal(tt.want) {
+				t.Errorf("ToIDL往返 = %v，期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{"分与币种", `{"cents":1999,"currency":"CNY"}`, FromCents(1999), false},
+		{"负数", `{"cents":-1,"currency":"USD"}`, New(-1, "USD"), false},
+		{"浮点金额被拒绝", `{"cents":19.99,"currency":"CNY"}`, Money{}, true},
+		{"金额为字符串", `{"cents":"1999","currency":"CNY"}`, Money{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("json.Unmarshal() 错误 = %v，期望出错 %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("json.Unmarshal() = %v，期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYuan(t *testing.T) {
+	if got := FromCents(1999).Yuan(); got != 19.99 {
+		t.Errorf("Yuan() = %v，期望 19.99", got)
+	}
+}
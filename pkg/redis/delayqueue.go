@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 原子领取到期任务：取出score<=now的成员，并把它们的score推后一个租约时长。
+// 多个副本同时轮询时，同一成员在租约期内只会被一个副本领取；
+// 处理方崩溃未确认时，租约到期后任务会被重新领取。
+var claimScript = redis.NewScript(`
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[3])
+for _, member in ipairs(items) do
+	redis.call('ZADD', KEYS[1], ARGV[2], member)
+end
+return items
+`)
+
+// DelayQueue 基于有序集合的延迟队列，score为任务到期的Unix毫秒时间
+type DelayQueue struct {
+	key   string
+	lease time.Duration
+}
+
+// NewDelayQueue 创建延迟队列，lease为单次领取后的处理租约
+func NewDelayQueue(key string, lease time.Duration) *DelayQueue {
+	return &DelayQueue{key: key, lease: lease}
+}
+
+// Push 添加或重新调度任务
+func (q *DelayQueue) Push(ctx context.Context, member string, at time.Time) error {
+	return Client.ZAdd(ctx, q.key, &redis.Z{
+		Score:  float64(at.UnixMilli()),
+		Member: member,
+	}).Err()
+}
+
+// Poll 领取最多batch个到期任务，处理成功后需调用Ack
+func (q *DelayQueue) Poll(ctx context.Context, batch int) ([]string, error) {
+	now := time.Now()
+	return claimScript.Run(ctx, Client, []string{q.key},
+		strconv.FormatInt(now.UnixMilli(), 10),
+		strconv.FormatInt(now.Add(q.lease).UnixMilli(), 10),
+		batch,
+	).StringSlice()
+}
+
+// Ack 确认任务完成并从队列删除
+func (q *DelayQueue) Ack(ctx context.Context, member string) error {
+	return Client.ZRem(ctx, q.key, member).Err()
+}
@@ -0,0 +1,24 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/cart/cartservice"
+)
+
+// CartClient 购物车服务RPC客户端
+var CartClient cartservice.Client
+
+// InitCartClient 初始化购物车服务客户端（需先调用 config.Init()）
+func InitCartClient() error {
+	opts, err := commonOptions()
+	if err != nil {
+		return err
+	}
+
+	CartClient, err = cartservice.NewClient("cart.service", opts...)
+	if err != nil {
+		return fmt.Errorf("购物车服务客户端初始化失败: %w", err)
+	}
+	return nil
+}
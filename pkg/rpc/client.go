@@ -0,0 +1,27 @@
+package rpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/pkg/transmeta"
+	"github.com/cloudwego/kitex/transport"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	consul "github.com/kitex-contrib/registry-consul"
+)
+
+// 各服务客户端的公共配置：Consul服务发现 + TTHeader（用于透传业务错误码）
+func commonOptions() ([]client.Option, error) {
+	r, err := consul.NewConsulResolver(config.Conf.Consul.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Consul初始化失败: %w", err)
+	}
+
+	return []client.Option{
+		client.WithResolver(r),
+		client.WithRPCTimeout(3 * time.Second),
+		client.WithTransportProtocol(transport.TTHeader),
+		client.WithMetaHandler(transmeta.ClientTTHeaderHandler),
+	}, nil
+}
@@ -0,0 +1,24 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/order/orderservice"
+)
+
+// OrderClient 订单服务RPC客户端
+var OrderClient orderservice.Client
+
+// InitOrderClient 初始化订单服务客户端（需先调用 config.Init()）
+func InitOrderClient() error {
+	opts, err := commonOptions()
+	if err != nil {
+		return err
+	}
+
+	OrderClient, err = orderservice.NewClient("order.service", opts...)
+	if err != nil {
+		return fmt.Errorf("订单服务客户端初始化失败: %w", err)
+	}
+	return nil
+}
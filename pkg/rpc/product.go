@@ -0,0 +1,24 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/product/productservice"
+)
+
+// ProductClient 商品服务RPC客户端
+var ProductClient productservice.Client
+
+// InitProductClient 初始化商品服务客户端（需先调用 config.Init()）
+func InitProductClient() error {
+	opts, err := commonOptions()
+	if err != nil {
+		return err
+	}
+
+	ProductClient, err = productservice.NewClient("product.service", opts...)
+	if err != nil {
+		return fmt.Errorf("商品服务客户端初始化失败: %w", err)
+	}
+	return nil
+}
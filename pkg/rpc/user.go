@@ -0,0 +1,24 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/user/userservice"
+)
+
+// UserClient 用户服务RPC客户端
+var UserClient userservice.Client
+
+// InitUserClient 初始化用户服务客户端（需先调用 config.Init()）
+func InitUserClient() error {
+	opts, err := commonOptions()
+	if err != nil {
+		return err
+	}
+
+	UserClient, err = userservice.NewClient("user.service", opts...)
+	if err != nil {
+		return fmt.Errorf("用户服务客户端初始化失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/product"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/rpc"
+	goredis "github.com/go-redis/redis/v8"
+)
+
+// 购物车相关错误定义（HTTP与RPC共用）
+var (
+	ErrInvalidParams     = errors.New("参数错误")
+	ErrProductNotFound   = errors.New("商品不存在")
+	ErrProductOffShelf   = errors.New("商品已下架")
+	ErrStockInsufficient = errors.New("库存不足")
+	ErrExceedLimit       = errors.New("超过限购数量")
+	ErrSKURequired       = errors.New("该商品有多个规格，需选择SKU")
+)
+
+// 批量查询SKU时每次RPC的最大数量，与商品服务的限制一致
+const lookupBatchSize = 100
+
+// 加购的上限校验与增加在同一脚本内完成，避免并发加购都通过校验后越过上限
+// 上限为库存与限购数量中的较小者，超过上限时返回 {0, 当前数量}，否则返回 {1, 增加后的数量}
+var addItemScript = goredis.NewScript(`
+local current = tonumber(redis.call("HGET", KEYS[1], ARGV[1]) or "0")
+local quantity = tonumber(ARGV[2])
+if current + quantity > tonumber(ARGV[3]) then
+	return {0, current}
+end
+return {1, redis.call("HINCRBY", KEYS[1], ARGV[1], quantity)}`)
+
+// ValidationError 商品校验失败的详细信息，可用errors.Is匹配具体错误类型
+type ValidationError struct {
+	Err       error `json:"-"`
+	ProductID uint  `json:"product_id,omitempty"`
+	SkuID     uint  `json:"sku_id,omitempty"`
+	Requested int   `json:"requested"`           // 加购后购物车中的数量
+	Available int   `json:"available,omitempty"` // 当前库存或限购数量
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: productID=%d, skuID=%d, requested=%d, available=%d",
+		e.Err.Error(), e.ProductID, e.SkuID, e.Requested, e.Available)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Item 购物车条目
+type Item struct {
+	SkuID    uint `json:"sku_id"`
+	Quantity int  `json:"quantity"`
+}
+
+// Key 购物车在Redis中的键，使用HSET存储 {SKU ID:数量}
+// 引入SKU前的条目以商品ID为键，与商品默认SKU的ID相同，无需迁移。
+func Key(userID uint) string {
+	return fmt.Sprintf("cart:%d", userID)
+}
+
+// ResolveSKU 确定加购的SKU：指定了skuID时直接使用，否则要求商品只有一个SKU
+func ResolveSKU(ctx context.Context, productID, skuID uint) (uint, error) {
+	if skuID != 0 {
+		return skuID, nil
+	}
+	if productID == 0 {
+		return 0, ErrInvalidParams
+	}
+
+	info, err := rpc.ProductClient.GetProduct(ctx, &product.GetProductReq{ProductId: int64(productID)})
+	if err != nil {
+		if bizErr, ok := kerrors.FromBizStatusError(err); ok && bizErr.BizStatusCode() == 404 {
+			return 0, &ValidationError{Err: ErrProductNotFound, ProductID: productID}
+		}
+		return 0, fmt.Errorf("商品信息查询失败: %w", err)
+	}
+	if len(info.Skus) != 1 {
+		return 0, &ValidationError{Err: ErrSKURequired, ProductID: productID}
+	}
+	return uint(info.Skus[0].Id), nil
+}
+
+// LookupSKUs 通过商品服务批量查询SKU信息，不存在或商品已删除的SKU不在结果中
+func LookupSKUs(ctx context.Context, skuIDs []uint) (map[uint]*product.SkuInfo, error) {
+	result := make(map[uint]*product.SkuInfo, len(skuIDs))
+	for start := 0; start < len(skuIDs); start += lookupBatchSize {
+		end := start + lookupBatchSize
+		if end > len(skuIDs) {
+			end = len(skuIDs)
+		}
+		ids := make([]int64, 0, end-start)
+		for _, id := range skuIDs[start:end] {
+			ids = append(ids, int64(id))
+		}
+
+		skus, err := rpc.ProductClient.ListSkus(ctx, &product.ListSkusReq{SkuIds: ids})
+		if err != nil {
+			return nil, fmt.Errorf("SKU信息查询失败: %w", err)
+		}
+		for _, sku := range skus {
+			result[uint(sku.Id)] = sku
+		}
+	}
+	return result, nil
+}
+
+// AddItem 增加SKU数量，返回增加后的数量
+func AddItem(ctx context.Context, userID, skuID uint, quantity int) (int64, error) {
+	if userID == 0 || skuID == 0 || quantity <= 0 {
+		return 0, ErrInvalidParams
+	}
+
+	info, err := lookupSKU(ctx, skuID)
+	if err != nil {
+		return 0, err
+	}
+	// SKU不存在或已下架时上限为-1，脚本拒绝加购并返回当前数量用于生成错误
+	upper := -1
+	if info != nil && info.Status == 1 {
+		upper = int(info.Stock)
+		if limit := config.Conf.Cart.MaxItemQuantity; limit > 0 && limit < upper {
+			upper = limit
+		}
+	}
+
+	res, err := addItemScript.Run(ctx, redis.Client, []string{Key(userID)}, field(skuID), quantity, upper).Int64Slice()
+	if err != nil {
+		return 0, fmt.Errorf("购物车操作失败: %w", err)
+	}
+	if res[0] == 0 {
+		return 0, checkSKU(skuID, info, int(res[1])+quantity)
+	}
+	return res[1], nil
+}
+
+// RemoveItem 从购物车移除SKU
+func RemoveItem(ctx context.Context, userID, skuID uint) error {
+	if userID == 0 || skuID == 0 {
+		return ErrInvalidParams
+	}
+
+	if err := redis.Client.HDel(ctx, Key(userID), field(skuID)).Err(); err != nil {
+		return fmt.Errorf("购物车操作失败: %w", err)
+	}
+	return nil
+}
+
+// RemoveItems 从购物车批量移除SKU，不存在的SKU忽略
+func RemoveItems(ctx context.Context, userID uint, skuIDs []uint) error {
+	if userID == 0 {
+		return ErrInvalidParams
+	}
+	if len(skuIDs) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(skuIDs))
+	for _, skuID := range skuIDs {
+		fields = append(fields, field(skuID))
+	}
+	if err := redis.Client.HDel(ctx, Key(userID), fields...).Err(); err != nil {
+		return fmt.Errorf("购物车操作失败: %w", err)
+	}
+	return nil
+}
+
+// SetQuantity 设置SKU的精确数量，数量为0时移除该SKU
+func SetQuantity(ctx context.Context, userID, skuID uint, quantity int) error {
+	if quantity < 0 {
+		return ErrInvalidParams
+	}
+	if quantity == 0 {
+		return RemoveItem(ctx, userID, skuID)
+	}
+	if userID == 0 || skuID == 0 {
+		return ErrInvalidParams
+	}
+	if err := validateSKU(ctx, skuID, quantity); err != nil {
+		return err
+	}
+
+	if err := redis.Client.HSet(ctx, Key(userID), field(skuID), quantity).Err(); err != nil {
+		return fmt.Errorf("购物车操作失败: %w", err)
+	}
+	return nil
+}
+
+// GetItems 获取购物车全部条目
+func GetItems(ctx context.Context, userID uint) ([]Item, error) {
+	if userID == 0 {
+		return nil, ErrInvalidParams
+	}
+
+	fields, err := redis.Client.HGetAll(ctx, Key(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("购物车查询失败: %w", err)
+	}
+
+	items := make([]Item, 0, len(fields))
+	for f, value := range fields {
+		skuID, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			continue // 跳过脏数据
+		}
+		quantity, err := strconv.Atoi(value)
+		if err != nil || quantity <= 0 {
+			continue
+		}
+		items = append(items, Item{SkuID: uint(skuID), Quantity: quantity})
+	}
+
+	// Redis哈希无序，按SKU ID排序保证输出稳定
+	sort.Slice(items, func(i, j int) bool { return items[i].SkuID < items[j].SkuID })
+	return items, nil
+}
+
+// Clear 清空购物车
+func Clear(ctx context.Context, userID uint) error {
+	if err := redis.Client.Del(ctx, Key(userID)).Err(); err != nil {
+		return fmt.Errorf("清空购物车失败: %w", err)
+	}
+	return nil
+}
+
+func field(skuID uint) string {
+	return strconv.FormatUint(uint64(skuID), 10)
+}
+
+// 通过商品服务校验SKU可购买：存在、已上架、数量不超过库存与限购
+func validateSKU(ctx context.Context, skuID uint, quantity int) error {
+	info, err := lookupSKU(ctx, skuID)
+	if err != nil {
+		return err
+	}
+	return checkSKU(skuID, info, quantity)
+}
+
+// lookupSKU 通过商品服务查询单个SKU，不存在时返回nil
+func lookupSKU(ctx context.Context, skuID uint) (*product.SkuInfo, error) {
+	skus, err := LookupSKUs(ctx, []uint{skuID})
+	if err != nil {
+		return nil, err
+	}
+	return skus[skuID], nil
+}
+
+// checkSKU 校验SKU存在、已上架且数量不超过限购与库存，info为nil表示SKU不存在
+func checkSKU(skuID uint, info *product.SkuInfo, quantity int) error {
+	if info == nil {
+		return &ValidationError{Err: ErrProductNotFound, SkuID: skuID, Requested: quantity}
+	}
+
+	productID := uint(info.ProductId)
+	if info.Status != 1 {
+		return &ValidationError{Err: ErrProductOffShelf, ProductID: productID, SkuID: skuID, Requested: quantity}
+	}
+	if limit := config.Conf.Cart.MaxItemQuantity; limit > 0 && quantity > limit {
+		return &ValidationError{Err: ErrExceedLimit, ProductID: productID, SkuID: skuID, Requested: quantity, Available: limit}
+	}
+	if quantity > int(info.Stock) {
+		return &ValidationError{Err: ErrStockInsufficient, ProductID: productID, SkuID: skuID, Requested: quantity, Available: int(info.Stock)}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/product"
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/product/productservice"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/rpc"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+// fakeProductClient 只实现 ListSkus 的商品服务客户端
+type fakeProductClient struct {
+	productservice.Client
+	skus map[int64]*product.SkuInfo
+}
+
+func (f *fakeProductClient) ListSkus(ctx context.Context, req *product.ListSkusReq, callOptions ...callopt.Option) ([]*product.SkuInfo, error) {
+	var result []*product.SkuInfo
+	for _, id := range req.SkuIds {
+		if sku, ok := f.skus[id]; ok {
+			result = append(result, sku)
+		}
+	}
+	return result, nil
+}
+
+// setupCart 使用内存Redis与只包含给定SKU的商品服务
+func setupCart(t *testing.T, maxQuantity int, skus ...*product.SkuInfo) {
+	t.Helper()
+	testutil.Redis(t)
+	testutil.Config(t, &config.Config{Cart: config.CartConfig{MaxItemQuantity: maxQuantity}})
+
+	client := &fakeProductClient{skus: map[int64]*product.SkuInfo{}}
+	for _, sku := range skus {
+		client.skus[sku.Id] = sku
+	}
+	prev := rpc.ProductClient
+	rpc.ProductClient = client
+	t.Cleanup(func() { rpc.ProductClient = prev })
+}
+
+func TestAddItem(t *testing.T) {
+	setupCart(t, 5,
+		&product.SkuInfo{Id: 1, ProductId: 1, Stock: 10, Status: 1},
+		&product.SkuInfo{Id: 2, ProductId: 2, Stock: 3, Status: 1},
+		&product.SkuInfo{Id: 3, ProductId: 3, Stock: 10, Status: 0},
+	)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		skuID uint
+		add   int
+		want  int64
+		err   error
+	}{
+		{"首次加购", 1, 2, 2, nil},
+		{"累加数量", 1, 3, 5, nil},
+		{"超过限购数量", 1, 1, 0, ErrExceedLimit},
+		{"超过库存", 2, 4, 0, ErrStockInsufficient},
+		{"已下架", 3, 1, 0, ErrProductOffShelf},
+		{"SKU不存在", 4, 1, 0, ErrProductNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddItem(ctx, 1, tt.skuID, tt.add)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("AddItem() 错误 = %v，期望 %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("AddItem() = %d，期望 %d", got, tt.want)
+			}
+		})
+	}
+
+	// 超限时错误中的数量为加购后的数量
+	var verr *ValidationError
+	if _, err := AddItem(ctx, 1, 1, 2); !errors.As(err, &verr) || verr.Requested != 7 || verr.Available != 5 {
+		t.Errorf("AddItem() 错误 = %v，期望请求7件、限购5件", err)
+	}
+	if items := cartOf(t, 1); items["1"] != "5" || len(items) != 1 {
+		t.Errorf("购物车 = %v，期望只有SKU 1 共5件", items)
+	}
+}
+
+func TestAddItemConcurrentLimit(t *testing.T) {
+	setupCart(t, 5, &product.SkuInfo{Id: 1, ProductId: 1, Stock: 100, Status: 1})
+	ctx := context.Background()
+
+	// 并发加购共10件，最多只能成功5件
+	const callers = 10
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := AddItem(ctx, 1, 1, 1); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			} else if !errors.Is(err, ErrExceedLimit) {
+				t.Errorf("AddItem() 错误 = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 5 {
+		t.Errorf("成功次数 = %d，期望 5", succeeded)
+	}
+	if items := cartOf(t, 1); items["1"] != "5" {
+		t.Errorf("购物车数量 = %s，期望不超过限购数量 5", items["1"])
+	}
+}
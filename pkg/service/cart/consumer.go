@@ -0,0 +1,79 @@
+package cart
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	clearConsumerGroup = "cart-clear"
+	clearRetryAttempts = 3
+	// 已处理订单的去重标记保留时间，需长于事件可能被重复投递的时间窗口
+	clearedMarkTTL = 7 * 24 * time.Hour
+)
+
+// StartConsumers 订阅下单事件，下单后从购物车移除已购买的商品
+func StartConsumers(ctx context.Context, broker event.Broker) error {
+	return broker.Subscribe(ctx, clearConsumerGroup,
+		event.WithRetry(clearConsumerGroup, clearRetryAttempts, handleOrderCreated))
+}
+
+// handleOrderCreated 只移除订单中的SKU，购物车中的其他商品保留
+// 事件可能重复投递：按订单号记录处理标记，避免用户下单后重新加入购物车的同款商品被再次移除。
+func handleOrderCreated(ctx context.Context, msg *event.Message) error {
+	if msg.Type != event.OrderCreated {
+		return nil
+	}
+
+	var evt event.OrderEvent
+	if err := msg.Decode(&evt); err != nil {
+		// 内容无法解析，重试也不会成功
+		zap.L().Error("下单事件解析失败", zap.String("event_id", msg.ID), zap.Error(err))
+		return nil
+	}
+
+	markKey := fmt.Sprintf("cart:cleared:%s", evt.OrderNo)
+	done, err := redis.Client.Exists(ctx, markKey).Result()
+	if err != nil {
+		return fmt.Errorf("处理标记查询失败: %w", err)
+	}
+	if done > 0 {
+		return nil
+	}
+
+	// 订单在事件投递前已取消（如结算流程回滚并恢复了购物车），不再清理
+	var o dal.Order
+	err = dal.DB.WithContext(ctx).Select("status").Where("order_no = ?", evt.OrderNo).First(&o).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return fmt.Errorf("订单查询失败: %w", err)
+	}
+	if err == nil && o.Status == dal.OrderStatusCanceled {
+		zap.L().Info("订单已取消，跳过购物车清理", zap.String("order_no", evt.OrderNo))
+		return nil
+	}
+
+	// 引入SKU前的事件只有商品ID，与商品默认SKU的ID相同
+	skuIDs := evt.SkuIDs
+	if len(skuIDs) == 0 {
+		skuIDs = evt.ProductIDs
+	}
+	if err := RemoveItems(ctx, evt.UserID, skuIDs); err != nil {
+		return err
+	}
+	if err := redis.Client.Set(ctx, markKey, 1, clearedMarkTTL).Err(); err != nil {
+		zap.L().Warn("处理标记保存失败", zap.String("order_no", evt.OrderNo), zap.Error(err))
+	}
+
+	zap.L().Info("已从购物车移除下单商品",
+		zap.String("order_no", evt.OrderNo),
+		zap.Uint("userID", evt.UserID),
+		zap.Uints("sku_ids", skuIDs))
+	return nil
+}
@@ -0,0 +1,97 @@
+package cart
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+func orderCreatedMessage(t *testing.T, evt event.OrderEvent) *event.Message {
+	t.Helper()
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		t.Fatalf("事件序列化失败: %v", err)
+	}
+	return &event.Message{ID: "evt-" + evt.OrderNo, Type: event.OrderCreated, AggregateID: evt.OrderNo, Payload: payload}
+}
+
+// cartOf 返回购物车中各商品的数量
+func cartOf(t *testing.T, userID uint) map[string]string {
+	t.Helper()
+	items, err := redis.Client.HGetAll(context.Background(), Key(userID)).Result()
+	if err != nil {
+		t.Fatalf("读取购物车失败: %v", err)
+	}
+	return items
+}
+
+func TestOrderCreatedClearsPurchasedItems(t *testing.T) {
+	testutil.DB(t, &dal.Order{}, &dal.DeadLetterEvent{})
+	testutil.Redis(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	redis.Client.HSet(ctx, Key(1), "101", 2, "102", 1, "103", 5)
+	broker := event.NewMemoryBroker()
+	if err := StartConsumers(ctx, broker); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+	msg := orderCreatedMessage(t, event.OrderEvent{OrderNo: "T1001", UserID: 1, ProductIDs: []uint{1, 2}, SkuIDs: []uint{101, 102}})
+	broker.Publish(ctx, msg)
+
+	deadline := time.Now().Add(time.Second)
+	for len(cartOf(t, 1)) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("购物车 = %v，期望只剩SKU 103", cartOf(t, 1))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// 用户下单后重新加入同款商品，重复投递的事件不会再次移除
+	redis.Client.HSet(ctx, Key(1), "101", 1)
+	if err := handleOrderCreated(ctx, msg); err != nil {
+		t.Fatalf("处理重复事件失败: %v", err)
+	}
+	if items := cartOf(t, 1); items["101"] != "1" || items["103"] != "5" || len(items) != 2 {
+		t.Errorf("购物车 = %v，期望保留重新加入的SKU 101与SKU 103", items)
+	}
+}
+
+func TestOrderCreatedSkipsCanceledOrder(t *testing.T) {
+	testutil.DB(t, &dal.Order{}, &dal.DeadLetterEvent{})
+	testutil.Redis(t)
+	ctx := context.Background()
+
+	dal.DB.Create(&dal.Order{UserID: 1, OrderNo: "T1002", Items: "{}", Status: dal.OrderStatusCanceled})
+	redis.Client.HSet(ctx, Key(1), "101", 2)
+
+	msg := orderCreatedMessage(t, event.OrderEvent{OrderNo: "T1002", UserID: 1, ProductIDs: []uint{101}})
+	if err := handleOrderCreated(ctx, msg); err != nil {
+		t.Fatalf("处理事件失败: %v", err)
+	}
+	if items := cartOf(t, 1); items["101"] != "2" {
+		t.Errorf("购物车 = %v，已取消订单的商品应保留", items)
+	}
+}
+
+func TestOrderCreatedWithoutSKUs(t *testing.T) {
+	testutil.DB(t, &dal.Order{}, &dal.DeadLetterEvent{})
+	testutil.Redis(t)
+	ctx := context.Background()
+
+	// 引入SKU前的事件只有商品ID，按默认SKU（ID与商品相同）移除
+	redis.Client.HSet(ctx, Key(1), "101", 2, "102", 1)
+	msg := orderCreatedMessage(t, event.OrderEvent{OrderNo: "T1003", UserID: 1, ProductIDs: []uint{101}})
+	if err := handleOrderCreated(ctx, msg); err != nil {
+		t.Fatalf("处理事件失败: %v", err)
+	}
+	if items := cartOf(t, 1); len(items) != 1 || items["102"] != "1" {
+		t.Errorf("购物车 = %v，期望只剩SKU 102", items)
+	}
+}
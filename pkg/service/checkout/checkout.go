@@ -0,0 +1,282 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/cart"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/rpc"
+	orderService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/order"
+	paymentService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/payment"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/util"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var ErrCartEmpty = errors.New("购物车中没有可结算的商品")
+
+// 补偿操作记录的操作方
+const sagaActor = "checkout-saga"
+
+var orderNoGen = util.NewSonyflakeGenerator()
+
+// 下单流程：
+//
+//	load_cart → clear_cart → reserve_stock → create_order → create_payment
+//
+// 先清理购物车再预扣库存：补偿逆序执行，恢复购物车时预扣的库存已经归还，
+// 购物车的库存校验不会因本流程自身的预扣而失败。
+var steps = []step{
+	{name: "load_cart", action: loadCart},
+	{name: "clear_cart", action: clearCart, compensate: restoreCart},
+	{name: "reserve_stock", action: reserveStock, compensate: releaseStock},
+	{name: "create_order", action: createOrder, compensate: cancelOrder},
+	{name: "create_payment", action: createPayment, compensate: cancelPayment},
+}
+
+// CartLine 参与结算的购物车条目
+type CartLine struct {
+	ProductID uint `json:"product_id"`
+	SkuID     uint `json:"sku_id"`
+	Quantity  int  `json:"quantity"`
+}
+
+// sku 引入SKU前持久化的流程数据只有商品ID，与商品默认SKU的ID相同
+func (l CartLine) sku() uint {
+	if l.SkuID != 0 {
+		return l.SkuID
+	}
+	return l.ProductID
+}
+
+// Selection 指定结算的购物车条目，商品ID选中该商品在购物车中的所有SKU；都为空表示整个购物车
+type Selection struct {
+	ProductIDs []uint
+	SkuIDs     []uint
+}
+
+// sagaData 流程数据，随流程状态一起持久化
+type sagaData struct {
+	ProductIDs []uint                  `json:"product_ids,omitempty"` // 指定结算的商品
+	SkuIDs     []uint                  `json:"sku_ids,omitempty"`     // 指定结算的SKU
+	Cart       []CartLine              `json:"cart"`
+	Lines      []dal.OrderItemSnapshot `json:"lines"`
+	PaymentID  string                  `json:"payment_id,omitempty"`
+	PaymentURL string                  `json:"payment_url,omitempty"`
+}
+
+// Result 下单结果
+type Result struct {
+	SagaID     string      `json:"saga_id"`
+	OrderNo    string      `json:"order_no"`
+	PaymentID  string      `json:"payment_id"`
+	PaymentURL string      `json:"payment_url"`
+	Amount     money.Money `json:"amount"`
+}
+
+// Checkout 结算购物车：清理购物车、预扣库存、创建订单与支付记录
+// selection为空时结算整个购物车。任一步骤失败时已执行的步骤会被补偿，
+// 补偿失败或进程崩溃时由 StartRecovery 启动的任务继续回滚。
+func Checkout(ctx context.Context, userID uint, selection Selection) (*Result, error) {
+	s := &saga{
+		record: &dal.CheckoutSaga{
+			SagaID:  uuid.New().String(),
+			UserID:  userID,
+			OrderNo: orderNoGen.Generate(),
+			Status:  dal.SagaStatusRunning,
+			Owner:   uuid.New().String(),
+		},
+		data: sagaData{ProductIDs: selection.ProductIDs, SkuIDs: selection.SkuIDs},
+	}
+	if err := s.save(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.run(ctx); err != nil {
+		return nil, err
+	}
+
+	total, err := orderService.Total(s.data.Lines)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		SagaID:     s.record.SagaID,
+		OrderNo:    s.record.OrderNo,
+		PaymentID:  s.data.PaymentID,
+		PaymentURL: s.data.PaymentURL,
+		Amount:     total,
+	}, nil
+}
+
+func loadCart(ctx context.Context, s *saga) error {
+	resp, err := rpc.CartClient.GetCart(ctx, &cart.CartRequest{UserId: int64(s.record.UserID)})
+	if err != nil {
+		return fmt.Errorf("购物车查询失败: %w", err)
+	}
+
+	selectedProducts := make(map[uint]bool, len(s.data.ProductIDs))
+	for _, id := range s.data.ProductIDs {
+		selectedProducts[id] = true
+	}
+	selectedSKUs := make(map[uint]bool, len(s.data.SkuIDs))
+	for _, id := range s.data.SkuIDs {
+		selectedSKUs[id] = true
+	}
+
+	s.data.Cart = s.data.Cart[:0]
+	for _, item := range resp.Items {
+		line := CartLine{ProductID: uint(item.ProductId), SkuID: uint(item.GetSkuId()), Quantity: int(item.Quantity)}
+		if (len(selectedProducts) > 0 || len(selectedSKUs) > 0) &&
+			!selectedProducts[line.ProductID] && !selectedSKUs[line.SkuID] {
+			continue
+		}
+		s.data.Cart = append(s.data.Cart, line)
+	}
+	if len(s.data.Cart) == 0 {
+		return ErrCartEmpty
+	}
+	return nil
+}
+
+func clearCart(ctx context.Context, s *saga) error {
+	for _, line := range s.data.Cart {
+		skuID := int64(line.sku())
+		_, err := rpc.CartClient.RemoveItem(ctx, &cart.CartItem{
+			UserId:    int64(s.record.UserID),
+			ProductId: int64(line.ProductID),
+			SkuId:     &skuID,
+		})
+		if err != nil {
+			return fmt.Errorf("购物车SKU %d移除失败: %w", skuID, err)
+		}
+	}
+	return nil
+}
+
+// restoreCart 把结算的SKU放回购物车
+// 只恢复购物车中已不存在的SKU，重复执行不会叠加数量，也不会覆盖用户之后的修改。
+func restoreCart(ctx context.Context, s *saga) error {
+	userID := int64(s.record.UserID)
+	for _, line := range s.data.Cart {
+		skuID := int64(line.sku())
+		resp, err := rpc.CartClient.GetCart(ctx, &cart.CartRequest{UserId: userID, SkuId: &skuID})
+		if err != nil {
+			return fmt.Errorf("购物车查询失败: %w", err)
+		}
+		if len(resp.Items) > 0 {
+			continue
+		}
+
+		_, err = rpc.CartClient.AddItem(ctx, &cart.CartItem{
+			UserId:    userID,
+			ProductId: int64(line.ProductID),
+			Quantity:  int32(line.Quantity),
+			SkuId:     &skuID,
+		})
+		if err != nil {
+			if _, ok := kerrors.FromBizStatusError(err); ok {
+				// 商品已下架或超过限购等，无法放回购物车，不阻塞回滚
+				zap.L().Warn("购物车商品无法恢复",
+					zap.String("saga_id", s.record.SagaID),
+					zap.Int64("sku_id", skuID),
+					zap.Error(err))
+				continue
+			}
+			return fmt.Errorf("购物车SKU %d恢复失败: %w", skuID, err)
+		}
+	}
+	return nil
+}
+
+// reserveStock 定价并预扣库存
+// 预扣前先持久化订单行，保证崩溃后补偿能按同一幂等键归还库存。
+func reserveStock(ctx context.Context, s *saga) error {
+	reqs := make([]orderService.LineRequest, 0, len(s.data.Cart))
+	for _, line := range s.data.Cart {
+		reqs = append(reqs, orderService.LineRequest{ProductID: line.ProductID, SkuID: line.sku(), Quantity: line.Quantity})
+	}
+
+	lines, err := orderService.PriceLines(ctx, reqs)
+	if err != nil {
+		return err
+	}
+	s.data.Lines = lines
+	if err := s.save(ctx); err != nil {
+		return err
+	}
+
+	return orderService.ReserveStock(ctx, s.record.OrderNo, s.data.Lines)
+}
+
+func releaseStock(ctx context.Context, s *saga) error {
+	if len(s.data.Lines) == 0 {
+		return nil
+	}
+	return orderService.ReleaseStock(ctx, s.record.OrderNo, s.data.Lines)
+}
+
+func createOrder(ctx context.Context, s *saga) error {
+	order, err := orderService.NewOrder(s.record.UserID, s.record.OrderNo, s.data.Lines)
+	if err != nil {
+		return err
+	}
+	// 订单号唯一，重复执行时忽略
+	err = dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := orderService.Insert(tx, order, s.data.Lines)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := orderService.ScheduleTimeout(ctx, order.OrderNo, order.CreatedAt); err != nil {
+		zap.L().Warn("订单超时任务登记失败",
+			zap.String("order_no", order.OrderNo),
+			zap.Error(err))
+	}
+	return nil
+}
+
+// cancelOrder 取消本流程创建的订单，订单不存在或已取消时视为成功
+func cancelOrder(ctx context.Context, s *saga) error {
+	_, err := orderService.Transition(ctx, dal.DB, s.record.OrderNo, dal.OrderStatusCanceled, sagaActor, "下单流程回滚")
+	if err == nil || errors.Is(err, orderService.ErrOrderNotFound) {
+		return nil
+	}
+	if errors.Is(err, orderService.ErrInvalidTransition) {
+		var o dal.Order
+		if dbErr := dal.DB.WithContext(ctx).Where("order_no = ?", s.record.OrderNo).First(&o).Error; dbErr == nil &&
+			o.Status == dal.OrderStatusCanceled {
+			return nil
+		}
+	}
+	return err
+}
+
+func createPayment(ctx context.Context, s *saga) error {
+	total, err := orderService.Total(s.data.Lines)
+	if err != nil {
+		return err
+	}
+	record, err := paymentService.Create(ctx, s.record.OrderNo, total)
+	if err != nil {
+		return err
+	}
+	payURL, err := paymentService.PayURL(ctx, record)
+	if err != nil {
+		return err
+	}
+	s.data.PaymentID = record.PaymentID
+	s.data.PaymentURL = payURL
+	return nil
+}
+
+func cancelPayment(ctx context.Context, s *saga) error {
+	return paymentService.Cancel(ctx, s.record.OrderNo)
+}
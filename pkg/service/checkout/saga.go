@@ -0,0 +1,368 @@
+package checkout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	paymentService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/payment"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	compensateTimeout = 30 * time.Second
+	recoverInterval   = 30 * time.Second
+	// 超过该时长未更新的流程视为执行方已崩溃，由恢复任务接管
+	recoverStaleAfter = time.Minute
+	// 执行期间刷新 updated_at 的间隔，需明显小于 recoverStaleAfter
+	heartbeatInterval = recoverStaleAfter / 4
+	recoverBatchSize  = 100
+	maxErrorLen       = 500
+)
+
+// ErrClaimLost 流程已被恢复任务接管，原执行方不能再写入
+var ErrClaimLost = errors.New("下单流程已被其他执行方接管")
+
+// step 下单流程中的一步，compensate为nil表示该步骤无需补偿
+// action与compensate都必须可重复执行：崩溃恢复时无法确定最后一步是否已生效。
+type step struct {
+	name       string
+	action     func(ctx context.Context, s *saga) error
+	compensate func(ctx context.Context, s *saga) error
+}
+
+// saga 一次下单流程的运行时状态
+type saga struct {
+	record *dal.CheckoutSaga
+	data   sagaData
+	lost   atomic.Bool // 心跳发现流程已被接管
+}
+
+// save 持久化流程状态与数据
+// 首次保存时创建记录；之后只有 owner 仍为本执行方时才能写入（条件更新），
+// 防止恢复任务接管后原执行方覆盖其状态，被接管时返回 ErrClaimLost。
+func (s *saga) save(ctx context.Context) error {
+	data, err := json.Marshal(s.data)
+	if err != nil {
+		return fmt.Errorf("下单流程数据序列化失败: %w", err)
+	}
+	s.record.Data = string(data)
+	if s.record.ID == 0 {
+		if err := dal.DB.WithContext(ctx).Create(s.record).Error; err != nil {
+			return fmt.Errorf("下单流程状态保存失败: %w", err)
+		}
+		return nil
+	}
+
+	return s.update(ctx, map[string]interface{}{
+		"status":     s.record.Status,
+		"step":       s.record.Step,
+		"data":       s.record.Data,
+		"last_error": s.record.LastError,
+	})
+}
+
+// touch 刷新 updated_at，表示执行方仍在运行
+func (s *saga) touch(ctx context.Context) error {
+	return s.update(ctx, map[string]interface{}{})
+}
+
+// update 以 owner 为条件更新流程记录，version 每次递增，保证匹配的行一定被修改
+func (s *saga) update(ctx context.Context, columns map[string]interface{}) error {
+	now := time.Now()
+	columns["version"] = gorm.Expr("version + 1")
+	columns["updated_at"] = now
+	result := dal.DB.WithContext(ctx).Model(&dal.CheckoutSaga{}).
+		Where("id = ? AND owner = ?", s.record.ID, s.record.Owner).
+		Updates(columns)
+	if result.Error != nil {
+		return fmt.Errorf("下单流程状态保存失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrClaimLost
+	}
+	return nil
+}
+
+// keepAlive 执行期间定期刷新 updated_at，使恢复任务不会接管仍在运行的流程
+// 心跳发现流程已被接管时调用onLost（通常是取消执行中的步骤）。返回的函数用于停止心跳。
+func (s *saga) keepAlive(onLost func()) func() {
+	ctx, stop := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := s.touch(ctx)
+				if errors.Is(err, ErrClaimLost) {
+					s.lost.Store(true)
+					onLost()
+					return
+				}
+				if err != nil && ctx.Err() == nil {
+					zap.L().Warn("下单流程心跳失败",
+						zap.String("saga_id", s.record.SagaID),
+						zap.Error(err))
+				}
+			}
+		}
+	}()
+	return stop
+}
+
+// claimLost 判断错误是否由流程被接管引起
+func (s *saga) claimLost(err error) bool {
+	return errors.Is(err, ErrClaimLost) || s.lost.Load()
+}
+
+// abandon 流程已被接管：停止执行，并补偿正在执行的步骤
+// 接管方可能在该步骤生效前就完成了补偿；补偿是幂等的，重复执行没有副作用。
+func (s *saga) abandon(st step, cause error) error {
+	zap.L().Warn("下单流程已被接管，停止执行",
+		zap.String("saga_id", s.record.SagaID),
+		zap.String("step", st.name),
+		zap.Error(cause))
+	if st.compensate != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), compensateTimeout)
+		defer cancel()
+		if err := st.compensate(ctx, s); err != nil {
+			zap.L().Error("被接管流程的步骤补偿失败",
+				zap.String("saga_id", s.record.SagaID),
+				zap.String("step", st.name),
+				zap.Error(err))
+		}
+	}
+	return fmt.Errorf("下单失败(%s): %w", st.name, ErrClaimLost)
+}
+
+// run 从当前步骤开始执行，任一步骤失败时逆序补偿已执行的步骤
+func (s *saga) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := s.keepAlive(cancel)
+	defer stop()
+
+	for s.record.Step < len(steps) {
+		st := steps[s.record.Step]
+		if err := st.action(ctx, s); err != nil {
+			if s.claimLost(err) {
+				return s.abandon(st, err)
+			}
+			s.record.LastError = truncate(fmt.Sprintf("%s: %v", st.name, err))
+			zap.L().Warn("下单流程步骤失败，开始补偿",
+				zap.String("saga_id", s.record.SagaID),
+				zap.String("step", st.name),
+				zap.Error(err))
+
+			// 补偿不受请求上下文取消的影响
+			compCtx, cancel := context.WithTimeout(context.Background(), compensateTimeout)
+			defer cancel()
+			if compErr := s.compensate(compCtx); compErr != nil {
+				zap.L().Error("下单流程补偿失败，等待恢复任务重试",
+					zap.String("saga_id", s.record.SagaID),
+					zap.Error(compErr))
+			}
+			return fmt.Errorf("下单失败(%s): %w", st.name, err)
+		}
+
+		s.record.Step++
+		if err := s.save(ctx); err != nil {
+			if s.claimLost(err) {
+				return s.abandon(st, err)
+			}
+			// 状态未能持久化，恢复任务会按旧的步骤重新补偿（补偿是幂等的）
+			return err
+		}
+	}
+
+	s.record.Status = dal.SagaStatusCompleted
+	return s.save(ctx)
+}
+
+// compensate 逆序执行补偿
+// 失败的步骤可能已部分生效，因此从该步骤本身开始补偿；每完成一步补偿就持久化一次。
+func (s *saga) compensate(ctx context.Context) error {
+	s.record.Status = dal.SagaStatusCompensating
+	if err := s.save(ctx); err != nil {
+		return err
+	}
+
+	i := s.record.Step
+	if i >= len(steps) {
+		i = len(steps) - 1
+	}
+	for ; i >= 0; i-- {
+		if comp := steps[i].compensate; comp != nil {
+			if err := comp(ctx, s); err != nil {
+				s.record.LastError = truncate(fmt.Sprintf("compensate %s: %v", steps[i].name, err))
+				if saveErr := s.save(ctx); saveErr != nil {
+					zap.L().Error("下单流程状态保存失败", zap.Error(saveErr))
+				}
+				return fmt.Errorf("补偿步骤%s失败: %w", steps[i].name, err)
+			}
+		}
+		s.record.Step = i
+		if err := s.save(ctx); err != nil {
+			return err
+		}
+	}
+
+	s.record.Status = dal.SagaStatusCompensated
+	if err := s.save(ctx); err != nil {
+		return err
+	}
+	zap.L().Info("下单流程已回滚",
+		zap.String("saga_id", s.record.SagaID),
+		zap.String("order_no", s.record.OrderNo))
+	return nil
+}
+
+// StartRecovery 启动崩溃恢复任务，ctx结束时退出
+// 服务重启后，执行中断的流程回滚：请求方已经拿不到结果，回滚后可安全重新下单；
+// 但订单已支付成功时（买家可能拿到了之前返回的支付链接）继续完成流程，不取消订单。
+// 执行方运行期间持续刷新 updated_at；超时未刷新的流程通过更换 owner 抢占，
+// 同一流程只会被一个副本接管，原执行方之后的写入都会失败。
+func StartRecovery(ctx context.Context) {
+	go func() {
+		recoverStale(ctx)
+
+		ticker := time.NewTicker(recoverInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				recoverStale(ctx)
+			}
+		}
+	}()
+}
+
+func recoverStale(ctx context.Context) {
+	var records []dal.CheckoutSaga
+	err := dal.DB.WithContext(ctx).
+		Where("status IN ? AND updated_at < ?",
+			[]dal.SagaStatus{dal.SagaStatusRunning, dal.SagaStatusCompensating},
+			time.Now().Add(-recoverStaleAfter)).
+		Order("id").
+		Limit(recoverBatchSize).
+		Find(&records).Error
+	if err != nil {
+		zap.L().Error("中断的下单流程查询失败", zap.Error(err))
+		return
+	}
+
+	for i := range records {
+		record := &records[i]
+		if !claim(ctx, record) {
+			continue
+		}
+
+		s := &saga{record: record}
+		if err := json.Unmarshal([]byte(record.Data), &s.data); err != nil {
+			zap.L().Error("下单流程数据解析失败",
+				zap.String("saga_id", record.SagaID),
+				zap.Error(err))
+			continue
+		}
+
+		if record.Status == dal.SagaStatusRunning && record.Step >= len(steps) {
+			// 所有步骤已完成，仅最终状态未保存
+			record.Status = dal.SagaStatusCompleted
+			if err := s.save(ctx); err != nil {
+				zap.L().Error("下单流程状态保存失败", zap.Error(err))
+			}
+			continue
+		}
+
+		if paid, err := rollForward(ctx, s); err != nil {
+			zap.L().Error("中断的下单流程支付状态查询失败",
+				zap.String("saga_id", record.SagaID),
+				zap.Error(err))
+			continue
+		} else if paid {
+			continue
+		}
+
+		zap.L().Warn("回滚中断的下单流程",
+			zap.String("saga_id", record.SagaID),
+			zap.String("status", string(record.Status)),
+			zap.Int("step", record.Step))
+		compCtx, cancel := context.WithCancel(ctx)
+		stop := s.keepAlive(cancel)
+		if err := s.compensate(compCtx); err != nil {
+			zap.L().Error("中断的下单流程回滚失败",
+				zap.String("saga_id", record.SagaID),
+				zap.Error(err))
+		}
+		stop()
+		cancel()
+	}
+}
+
+// rollForward 订单已支付成功时把中断的流程标记为完成，返回是否已处理
+// 只在取消订单的补偿尚未执行时检查：create_payment 之前没有支付记录，
+// 订单被补偿取消后到达的支付由支付服务转为待退款。
+func rollForward(ctx context.Context, s *saga) (bool, error) {
+	if s.record.Step < len(steps)-1 {
+		return false, nil
+	}
+	payment, err := paymentService.Paid(ctx, s.record.OrderNo)
+	if err != nil || payment == nil {
+		return false, err
+	}
+
+	zap.L().Warn("中断的下单流程已支付，继续完成",
+		zap.String("saga_id", s.record.SagaID),
+		zap.String("order_no", s.record.OrderNo),
+		zap.String("status", string(s.record.Status)),
+		zap.Int("step", s.record.Step))
+	s.data.PaymentID = payment.PaymentID
+	s.record.Step = len(steps)
+	s.record.Status = dal.SagaStatusCompleted
+	if err := s.save(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// claim 更换 owner 抢占超时未刷新的流程，防止多个副本同时恢复同一流程，
+// 也使仍在运行的原执行方（如长时间阻塞后恢复）之后的写入失败
+func claim(ctx context.Context, record *dal.CheckoutSaga) bool {
+	owner := uuid.New().String()
+	result := dal.DB.WithContext(ctx).Model(&dal.CheckoutSaga{}).
+		Where("id = ? AND owner = ? AND updated_at < ?", record.ID, record.Owner, time.Now().Add(-recoverStaleAfter)).
+		Updates(map[string]interface{}{
+			"owner":      owner,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		zap.L().Error("下单流程抢占失败",
+			zap.String("saga_id", record.SagaID),
+			zap.Error(result.Error))
+		return false
+	}
+	if result.RowsAffected == 0 {
+		return false
+	}
+	record.Owner = owner
+	return true
+}
+
+func truncate(s string) string {
+	if r := []rune(s); len(r) > maxErrorLen {
+		return string(r[:maxErrorLen])
+	}
+	return s
+}
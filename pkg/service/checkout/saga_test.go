@@ -0,0 +1,126 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	paymentService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/payment"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+	"github.com/google/uuid"
+)
+
+func TestClaimFencesPreviousOwner(t *testing.T) {
+	testutil.DB(t, &dal.CheckoutSaga{})
+	ctx := context.Background()
+
+	s := &saga{record: &dal.CheckoutSaga{
+		SagaID:  uuid.New().String(),
+		OrderNo: "T1001",
+		Status:  dal.SagaStatusRunning,
+		Owner:   uuid.New().String(),
+	}}
+	if err := s.save(ctx); err != nil {
+		t.Fatalf("创建流程失败: %v", err)
+	}
+
+	var stale dal.CheckoutSaga
+	dal.DB.First(&stale, s.record.ID)
+	if claim(ctx, &stale) {
+		t.Fatal("仍在刷新的流程不应被接管")
+	}
+
+	// 模拟执行方长时间阻塞，超过接管时间未刷新
+	dal.DB.Model(&dal.CheckoutSaga{}).Where("id = ?", s.record.ID).
+		UpdateColumn("updated_at", time.Now().Add(-2*recoverStaleAfter))
+	dal.DB.First(&stale, s.record.ID)
+	if !claim(ctx, &stale) {
+		t.Fatal("超时未刷新的流程应被接管")
+	}
+	again := stale
+	again.Owner = s.record.Owner
+	if claim(ctx, &again) {
+		t.Error("同一流程不应被重复接管")
+	}
+
+	// 原执行方恢复后的写入与心跳都被拒绝，不会覆盖接管方的状态
+	s.record.Step = 3
+	s.record.Status = dal.SagaStatusCompleted
+	if err := s.save(ctx); !errors.Is(err, ErrClaimLost) {
+		t.Errorf("save() 错误 = %v，期望 %v", err, ErrClaimLost)
+	}
+	if err := s.touch(ctx); !errors.Is(err, ErrClaimLost) {
+		t.Errorf("touch() 错误 = %v，期望 %v", err, ErrClaimLost)
+	}
+
+	recovered := &saga{record: &stale}
+	recovered.record.Status = dal.SagaStatusCompensating
+	if err := recovered.save(ctx); err != nil {
+		t.Fatalf("接管方保存失败: %v", err)
+	}
+	var saved dal.CheckoutSaga
+	dal.DB.First(&saved, s.record.ID)
+	if saved.Status != dal.SagaStatusCompensating || saved.Step != 0 {
+		t.Errorf("流程状态 = %s/%d，期望 %s/0", saved.Status, saved.Step, dal.SagaStatusCompensating)
+	}
+}
+
+func TestRecoverStaleRollsForwardPaidOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        dal.SagaStatus
+		step          int
+		orderStatus   dal.OrderStatus
+		paymentStatus string
+		wantSaga      dal.SagaStatus
+		wantOrder     dal.OrderStatus
+		wantPayment   string
+	}{
+		{"创建支付时中断且已支付", dal.SagaStatusRunning, len(steps) - 1, dal.OrderStatusPaid, paymentService.StatusSuccess,
+			dal.SagaStatusCompleted, dal.OrderStatusPaid, paymentService.StatusSuccess},
+		{"补偿中断且已支付", dal.SagaStatusCompensating, len(steps) - 1, dal.OrderStatusPaid, paymentService.StatusSuccess,
+			dal.SagaStatusCompleted, dal.OrderStatusPaid, paymentService.StatusSuccess},
+		{"未支付时回滚", dal.SagaStatusRunning, len(steps) - 1, dal.OrderStatusUnpaid, paymentService.StatusPending,
+			dal.SagaStatusCompensated, dal.OrderStatusCanceled, paymentService.StatusCanceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.DB(t, &dal.CheckoutSaga{}, &dal.Order{}, &dal.OrderStatusHistory{}, &dal.OutboxEvent{}, &dal.PaymentRecord{})
+			testutil.Redis(t)
+			ctx := context.Background()
+
+			const orderNo = "T2001"
+			dal.DB.Create(&dal.Order{UserID: 1, OrderNo: orderNo, Amount: money.FromCents(100), Status: tt.orderStatus})
+			dal.DB.Create(&dal.PaymentRecord{OrderID: orderNo, PaymentID: "pay-1", Amount: money.FromCents(100), Status: tt.paymentStatus, UserID: 1})
+			s := &saga{record: &dal.CheckoutSaga{
+				SagaID:  uuid.New().String(),
+				UserID:  1,
+				OrderNo: orderNo,
+				Status:  tt.status,
+				Step:    tt.step,
+				Owner:   uuid.New().String(),
+			}}
+			if err := s.save(ctx); err != nil {
+				t.Fatalf("创建流程失败: %v", err)
+			}
+			dal.DB.Model(&dal.CheckoutSaga{}).Where("id = ?", s.record.ID).
+				UpdateColumn("updated_at", time.Now().Add(-2*recoverStaleAfter))
+
+			recoverStale(ctx)
+
+			var saved dal.CheckoutSaga
+			dal.DB.First(&saved, s.record.ID)
+			var o dal.Order
+			dal.DB.Where("order_no = ?", orderNo).First(&o)
+			var p dal.PaymentRecord
+			dal.DB.Where("order_id = ?", orderNo).First(&p)
+			if saved.Status != tt.wantSaga || o.Status != tt.wantOrder || p.Status != tt.wantPayment {
+				t.Errorf("流程/订单/支付 = %s/%s/%s，期望 %s/%s/%s",
+					saved.Status, o.Status, p.Status, tt.wantSaga, tt.wantOrder, tt.wantPayment)
+			}
+		})
+	}
+}
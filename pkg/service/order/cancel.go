@@ -0,0 +1,103 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var ErrCancelNotAllowed = errors.New("当前订单状态不可取消")
+
+// Cancel 用户取消订单
+// 未支付订单直接取消；开启 allow_cancel_paid 时，已支付未发货的订单转入退款中。
+// 与支付回调并发时由 Transition 的乐观锁保证只有一方成功：
+// 取消先成功则回调的 unpaid→paid 流转被拒绝，回调先成功则此处返回 ErrStatusConflict。
+func Cancel(ctx context.Context, userID uint, orderNo, reason string) (*dal.Order, error) {
+	if reason == "" {
+		reason = "用户取消订单"
+	}
+	return cancel(ctx, dal.DB.WithContext(ctx).Where("order_no = ? AND user_id = ?", orderNo, userID),
+		fmt.Sprintf("user:%d", userID), reason)
+}
+
+// CancelByOperator 管理员/商家取消订单，规则与用户取消相同（含库存归还与取消事件）
+func CancelByOperator(ctx context.Context, orderNo, actor, reason string) (*dal.Order, error) {
+	if reason == "" {
+		reason = "后台取消订单"
+	}
+	return cancel(ctx, dal.DB.WithContext(ctx).Where("order_no = ?", orderNo), actor, reason)
+}
+
+func cancel(ctx context.Context, query *gorm.DB, actor, reason string) (*dal.Order, error) {
+	var o dal.Order
+	if err := query.First(&o).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("订单查询失败: %w", err)
+	}
+
+	var to dal.OrderStatus
+	switch {
+	case o.Status == dal.OrderStatusUnpaid:
+		to = dal.OrderStatusCanceled
+	case o.Status == dal.OrderStatusPaid && config.Conf.Order.AllowCancelPaid:
+		to = dal.OrderStatusRefunding
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrCancelNotAllowed, o.Status)
+	}
+
+	var canceled *dal.Order
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if canceled, err = Transition(ctx, tx, o.OrderNo, to, actor, reason); err != nil {
+			return err
+		}
+		// 已支付订单转入退款中，同样视为取消，供退款等下游处理
+		if to == dal.OrderStatusRefunding {
+			return event.Add(tx, event.OrderCanceled, o.OrderNo, newOrderEvent(canceled, o.Status, actor, reason))
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidTransition) {
+			// 读取状态后订单已被其他流程修改
+			return nil, ErrStatusConflict
+		}
+		return nil, err
+	}
+
+	releaseCanceledStock(ctx, canceled)
+	return canceled, nil
+}
+
+// 订单状态已变更，库存归还失败不回滚取消操作：
+// 已取消的订单交给超时队列重试归还；转入退款的订单记录错误等待人工处理。
+func releaseCanceledStock(ctx context.Context, o *dal.Order) {
+	lines, err := Items(o)
+	if err == nil {
+		err = ReleaseStock(ctx, o.OrderNo, lines)
+	}
+	if err == nil {
+		return
+	}
+
+	zap.L().Error("取消订单库存归还失败",
+		zap.String("order_no", o.OrderNo),
+		zap.String("status", string(o.Status)),
+		zap.Error(err))
+	if o.Status == dal.OrderStatusCanceled {
+		if err := timeoutQueue.Push(ctx, o.OrderNo, time.Now()); err != nil {
+			zap.L().Error("库存归还重试登记失败",
+				zap.String("order_no", o.OrderNo),
+				zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	refundConsumerGroup = "order-refund"
+	refundRetryAttempts = 3
+	refundActor         = "payment-service"
+)
+
+// StartConsumers 订阅退款事件，全额退款时订单流转为退款中/已退款
+func StartConsumers(ctx context.Context, broker event.Broker) error {
+	return broker.Subscribe(ctx, refundConsumerGroup,
+		event.WithRetry(refundConsumerGroup, refundRetryAttempts, handleRefundEvent))
+}
+
+// handleRefundEvent 部分退款不改变订单状态
+// 事件可能重复或乱序投递：已处于目标状态时跳过，退款成功先于退款申请到达时补齐退款中状态。
+func handleRefundEvent(ctx context.Context, msg *event.Message) error {
+	if msg.Type != event.RefundRequested && msg.Type != event.RefundSucceeded {
+		return nil
+	}
+
+	var evt event.RefundEvent
+	if err := msg.Decode(&evt); err != nil {
+		zap.L().Error("退款事件解析失败", zap.String("event_id", msg.ID), zap.Error(err))
+		return nil
+	}
+	if !evt.Full {
+		return nil
+	}
+
+	var o dal.Order
+	err := dal.DB.WithContext(ctx).Select("status").Where("order_no = ?", evt.OrderNo).First(&o).Error
+	if err == gorm.ErrRecordNotFound {
+		zap.L().Warn("退款订单不存在", zap.String("order_no", evt.OrderNo))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("订单查询失败: %w", err)
+	}
+
+	steps := []dal.OrderStatus{dal.OrderStatusRefunding}
+	if msg.Type == event.RefundSucceeded {
+		steps = append(steps, dal.OrderStatusRefunded)
+	}
+	for _, to := range steps {
+		if o.Status == to || o.Status == dal.OrderStatusRefunded {
+			continue
+		}
+		reason := evt.Reason
+		if to == dal.OrderStatusRefunded {
+			reason = "退款成功: " + evt.RefundID
+		}
+		updated, err := Transition(ctx, dal.DB, evt.OrderNo, to, refundActor, reason)
+		if errors.Is(err, ErrInvalidTransition) {
+			// 如订单在支付完成前已取消，退款与订单状态无关
+			zap.L().Warn("订单当前状态不随退款变更",
+				zap.String("order_no", evt.OrderNo),
+				zap.String("status", string(o.Status)))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		o.Status = updated.Status
+	}
+	return nil
+}
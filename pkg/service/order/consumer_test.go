@@ -0,0 +1,85 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+func refundMessage(t *testing.T, id, typ string, evt event.RefundEvent) *event.Message {
+	t.Helper()
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		t.Fatalf("事件序列化失败: %v", err)
+	}
+	return &event.Message{ID: id, Type: typ, AggregateID: evt.OrderNo, Payload: payload}
+}
+
+// historyOf 返回订单依次流转到的状态
+func historyOf(orderNo string) []dal.OrderStatus {
+	var statuses []dal.OrderStatus
+	dal.DB.Model(&dal.OrderStatusHistory{}).Where("order_no = ?", orderNo).Order("id").Pluck("to_status", &statuses)
+	return statuses
+}
+
+func TestRefundEventsMoveOrderToRefunded(t *testing.T) {
+	testutil.DB(t, &dal.Order{}, &dal.OrderStatusHistory{}, &dal.OutboxEvent{}, &dal.DeadLetterEvent{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dal.DB.Create(&dal.Order{UserID: 1, OrderNo: "T6001", Amount: money.FromCents(1000), Items: "{}", Status: dal.OrderStatusPaid})
+	broker := event.NewMemoryBroker()
+	if err := StartConsumers(ctx, broker); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+
+	// 退款成功先于退款申请到达，补齐退款中状态；随后到达的申请与重复投递均被跳过
+	evt := event.RefundEvent{RefundID: "r-1", OrderNo: "T6001", UserID: 1, Amount: money.FromCents(1000), Full: true}
+	broker.Publish(ctx, refundMessage(t, "evt-2", event.RefundSucceeded, evt))
+	broker.Publish(ctx, refundMessage(t, "evt-1", event.RefundRequested, evt))
+	broker.Publish(ctx, refundMessage(t, "evt-2", event.RefundSucceeded, evt))
+
+	want := []dal.OrderStatus{dal.OrderStatusRefunding, dal.OrderStatusRefunded}
+	deadline := time.Now().Add(3 * time.Second)
+	for len(historyOf("T6001")) < len(want) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := historyOf("T6001"); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("状态流转 = %v，期望 %v", got, want)
+	}
+}
+
+func TestHandleRefundEventSkips(t *testing.T) {
+	tests := []struct {
+		name   string
+		status dal.OrderStatus
+		full   bool
+	}{
+		{"部分退款", dal.OrderStatusPaid, false},
+		{"订单已取消", dal.OrderStatusCanceled, true},
+		{"已退款", dal.OrderStatusRefunded, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.DB(t, &dal.Order{}, &dal.OrderStatusHistory{}, &dal.OutboxEvent{})
+			dal.DB.Create(&dal.Order{UserID: 1, OrderNo: "T6002", Amount: money.FromCents(1000), Items: "{}", Status: tt.status})
+
+			evt := event.RefundEvent{RefundID: "r-2", OrderNo: "T6002", Amount: money.FromCents(300), Full: tt.full}
+			if err := handleRefundEvent(context.Background(), refundMessage(t, "evt-3", event.RefundSucceeded, evt)); err != nil {
+				t.Fatalf("handleRefundEvent() 错误 = %v", err)
+			}
+			var o dal.Order
+			dal.DB.Where("order_no = ?", "T6002").First(&o)
+			if o.Status != tt.status || len(historyOf("T6002")) != 0 {
+				t.Errorf("订单状态 = %s，期望保持 %s 且无流转记录", o.Status, tt.status)
+			}
+		})
+	}
+}
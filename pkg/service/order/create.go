@@ -0,0 +1,187 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/product"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/rpc"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 下单相关错误定义
+var (
+	ErrEmptyItems      = errors.New("订单商品不能为空")
+	ErrInvalidItem     = errors.New("订单商品参数错误")
+	ErrProductNotFound = errors.New("商品不存在")
+	ErrProductOffShelf = errors.New("商品已下架")
+)
+
+// 单个订单的最大SKU数，与商品服务批量查询的限制一致
+const maxOrderLines = 100
+
+// LineRequest 下单请求中的一行商品
+type LineRequest struct {
+	ProductID uint
+	SkuID     uint // 为0时使用商品唯一的SKU
+	Quantity  int
+}
+
+// PriceLines 从商品服务获取SKU当前价格、商品名称与规格生成订单行，金额完全由服务端计算
+// 重复的SKU会被合并，保持首次出现的顺序。
+func PriceLines(ctx context.Context, reqs []LineRequest) ([]dal.OrderItemSnapshot, error) {
+	if len(reqs) == 0 {
+		return nil, ErrEmptyItems
+	}
+
+	quantities := make(map[uint]int, len(reqs))
+	expectedProduct := make(map[uint]uint, len(reqs))
+	skuIDs := make([]int64, 0, len(reqs))
+	for _, req := range reqs {
+		if (req.ProductID == 0 && req.SkuID == 0) || req.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: productID: %d, skuID: %d, quantity: %d",
+				ErrInvalidItem, req.ProductID, req.SkuID, req.Quantity)
+		}
+		skuID := req.SkuID
+		if skuID == 0 {
+			var err error
+			if skuID, err = defaultSKU(ctx, req.ProductID); err != nil {
+				return nil, err
+			}
+		}
+		if _, ok := quantities[skuID]; !ok {
+			skuIDs = append(skuIDs, int64(skuID))
+		}
+		quantities[skuID] += req.Quantity
+		if req.ProductID != 0 {
+			expectedProduct[skuID] = req.ProductID
+		}
+	}
+	if len(skuIDs) > maxOrderLines {
+		return nil, fmt.Errorf("%w: 单个订单最多%d种商品", ErrInvalidItem, maxOrderLines)
+	}
+
+	skus, err := rpc.ProductClient.ListSkus(ctx, &product.ListSkusReq{SkuIds: skuIDs})
+	if err != nil {
+		return nil, fmt.Errorf("SKU信息查询失败: %w", err)
+	}
+	infos := make(map[uint]*product.SkuInfo, len(skus))
+	for _, sku := range skus {
+		infos[uint(sku.Id)] = sku
+	}
+
+	lines := make([]dal.OrderItemSnapshot, 0, len(skuIDs))
+	for _, id := range skuIDs {
+		skuID := uint(id)
+		info, ok := infos[skuID]
+		if !ok {
+			return nil, fmt.Errorf("%w: skuID: %d", ErrProductNotFound, skuID)
+		}
+		productID := uint(info.ProductId)
+		if expected, ok := expectedProduct[skuID]; ok && expected != productID {
+			return nil, fmt.Errorf("%w: SKU %d 不属于商品 %d", ErrInvalidItem, skuID, expected)
+		}
+		if info.Status != 1 {
+			return nil, fmt.Errorf("%w: productID: %d, skuID: %d", ErrProductOffShelf, productID, skuID)
+		}
+
+		quantity := quantities[skuID]
+		unitPrice := money.FromIDL(info.Price)
+		line := dal.OrderItemSnapshot{
+			ProductID: productID,
+			SkuID:     skuID,
+			Name:      info.ProductName,
+			UnitPrice: unitPrice,
+			Quantity:  quantity,
+			Subtotal:  unitPrice.Mul(int64(quantity)),
+		}
+		if len(info.Specs) > 0 {
+			line.Specs = info.Specs
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// defaultSKU 未指定SKU时使用商品唯一的SKU，多规格商品必须指定
+func defaultSKU(ctx context.Context, productID uint) (uint, error) {
+	info, err := rpc.ProductClient.GetProduct(ctx, &product.GetProductReq{ProductId: int64(productID)})
+	if err != nil {
+		if bizErr, ok := kerrors.FromBizStatusError(err); ok && bizErr.BizStatusCode() == 404 {
+			return 0, fmt.Errorf("%w: productID: %d", ErrProductNotFound, productID)
+		}
+		return 0, fmt.Errorf("商品%d信息查询失败: %w", productID, err)
+	}
+	if len(info.Skus) != 1 {
+		return 0, fmt.Errorf("%w: 商品%d有多个规格，需指定SKU", ErrInvalidItem, productID)
+	}
+	return uint(info.Skus[0].Id), nil
+}
+
+// Total 汇总订单行小计，所有订单行币种必须一致
+func Total(lines []dal.OrderItemSnapshot) (money.Money, error) {
+	if len(lines) == 0 {
+		return money.Money{}, ErrEmptyItems
+	}
+	total := money.Zero(lines[0].Subtotal.Currency)
+	for _, line := range lines {
+		var err error
+		if total, err = total.Add(line.Subtotal); err != nil {
+			return money.Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// NewOrder 根据订单行构造待支付订单（未入库）
+func NewOrder(userID uint, orderNo string, lines []dal.OrderItemSnapshot) (*dal.Order, error) {
+	total, err := Total(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := json.Marshal(dal.OrderItemsSnapshot{
+		Version: dal.OrderItemsSnapshotVersion,
+		Items:   lines,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("商品快照生成失败: %w", err)
+	}
+
+	return &dal.Order{
+		UserID:  userID,
+		OrderNo: orderNo,
+		Status:  dal.OrderStatusUnpaid,
+		Amount:  total,
+		Items:   string(snapshot),
+	}, nil
+}
+
+// Insert 在事务中写入订单及 OrderCreated 事件，可重复调用
+// 订单号已存在时不做任何写入并返回false。
+func Insert(tx *gorm.DB, o *dal.Order, lines []dal.OrderItemSnapshot) (bool, error) {
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(o)
+	if result.Error != nil {
+		return false, fmt.Errorf("订单写入失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	payload := newOrderEvent(o, "", "", "")
+	for _, line := range lines {
+		payload.ProductIDs = append(payload.ProductIDs, line.ProductID)
+		payload.SkuIDs = append(payload.SkuIDs, line.SkuID)
+	}
+	if err := event.Add(tx, event.OrderCreated, o.OrderNo, payload); err != nil {
+		return false, err
+	}
+	return true, nil
+}
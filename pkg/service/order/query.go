@@ -0,0 +1,138 @@
+package order
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var ErrInvalidCursor = errors.New("无效的分页游标")
+
+// ListQuery 订单列表查询条件，零值字段表示不过滤
+type ListQuery struct {
+	UserID uint
+	Status dal.OrderStatus
+	Start  time.Time // 创建时间 >= Start
+	End    time.Time // 创建时间 < End
+	Cursor string    // 上一页返回的NextCursor
+	Limit  int
+}
+
+// ListResult 订单列表分页结果，NextCursor为空表示没有更多数据
+type ListResult struct {
+	Orders     []dal.Order
+	NextCursor string
+}
+
+// Detail 订单详情：订单头、商品行与状态流转历史
+type Detail struct {
+	Order   dal.Order
+	Items   []dal.OrderItemSnapshot
+	History []dal.OrderStatusHistory
+}
+
+// ListOrders 按创建时间倒序分页查询用户订单
+// 游标基于自增ID（与创建时间同序），翻页期间新增订单不会导致重复或遗漏。
+func ListOrders(ctx context.Context, q ListQuery) (*ListResult, error) {
+	if q.UserID == 0 {
+		return nil, ErrOrderNotFound
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	db := dal.DB.WithContext(ctx).Where("user_id = ?", q.UserID)
+	if q.Status != "" {
+		if _, err := ParseStatus(string(q.Status)); err != nil {
+			return nil, err
+		}
+		db = db.Where("status = ?", q.Status)
+	}
+	if !q.Start.IsZero() {
+		db = db.Where("created_at >= ?", q.Start)
+	}
+	if !q.End.IsZero() {
+		db = db.Where("created_at < ?", q.End)
+	}
+	if q.Cursor != "" {
+		lastID, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("id < ?", lastID)
+	}
+
+	// 多查一条用于判断是否还有下一页
+	var orders []dal.Order
+	if err := db.Order("id DESC").Limit(limit + 1).Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("订单查询失败: %w", err)
+	}
+
+	result := &ListResult{Orders: orders}
+	if len(orders) > limit {
+		result.Orders = orders[:limit]
+		result.NextCursor = encodeCursor(orders[limit-1].ID)
+	}
+	return result, nil
+}
+
+// GetDetail 查询订单详情，只能查询自己的订单
+// 他人订单与不存在的订单同样返回 ErrOrderNotFound，避免泄露订单是否存在。
+func GetDetail(ctx context.Context, userID uint, orderNo string) (*Detail, error) {
+	var o dal.Order
+	err := dal.DB.WithContext(ctx).
+		Where("order_no = ? AND user_id = ?", orderNo, userID).
+		First(&o).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("订单查询失败: %w", err)
+	}
+
+	items, err := Items(&o)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []dal.OrderStatusHistory
+	if err := dal.DB.WithContext(ctx).
+		Where("order_no = ?", orderNo).
+		Order("id").
+		Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("状态流转记录查询失败: %w", err)
+	}
+
+	return &Detail{Order: o, Items: items, History: history}, nil
+}
+
+func encodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+func decodeCursor(cursor string) (uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil || id == 0 {
+		return 0, ErrInvalidCursor
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,34 @@
+package order
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	for _, id := range []uint{1, 42, 1 << 40} {
+		got, err := decodeCursor(encodeCursor(id))
+		if err != nil || got != id {
+			t.Errorf("decodeCursor(encodeCursor(%d)) = %d, %v", id, got, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"不是base64", "!!!"},
+		{"不是数字", base64.RawURLEncoding.EncodeToString([]byte("abc"))},
+		{"ID为零", base64.RawURLEncoding.EncodeToString([]byte("0"))},
+		{"负数", base64.RawURLEncoding.EncodeToString([]byte("-1"))},
+		{"带填充的base64", base64.URLEncoding.EncodeToString([]byte("1"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeCursor(%q) 错误 = %v，期望 %v", tt.cursor, err, ErrInvalidCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 订单状态相关错误定义（HTTP与RPC共用）
+var (
+	ErrOrderNotFound     = errors.New("订单不存在")
+	ErrInvalidStatus     = errors.New("无效的订单状态")
+	ErrInvalidTransition = errors.New("不允许的订单状态变更")
+	ErrStatusConflict    = errors.New("订单状态已被修改，请重试")
+	ErrForbidden         = errors.New("无权操作该订单")
+)
+
+// 订单状态机：key为当前状态，value为允许流转到的状态
+//
+//	unpaid → paid → shipped → delivered → completed
+//	   ↓       ↓        ↓          ↓           ↓
+//	canceled   └────────┴── refunding ─────────┘ → refunded
+var transitions = map[dal.OrderStatus][]dal.OrderStatus{
+	dal.OrderStatusUnpaid:    {dal.OrderStatusPaid, dal.OrderStatusCanceled},
+	dal.OrderStatusPaid:      {dal.OrderStatusShipped, dal.OrderStatusRefunding},
+	dal.OrderStatusShipped:   {dal.OrderStatusDelivered, dal.OrderStatusRefunding},
+	dal.OrderStatusDelivered: {dal.OrderStatusCompleted, dal.OrderStatusRefunding},
+	dal.OrderStatusCompleted: {dal.OrderStatusRefunding},
+	dal.OrderStatusRefunding: {dal.OrderStatusRefunded},
+	dal.OrderStatusCanceled:  {},
+	dal.OrderStatusRefunded:  {},
+}
+
+// 进入这些状态时发布对应的领域事件（与状态变更在同一事务中写入发件箱）
+var statusEvents = map[dal.OrderStatus]string{
+	dal.OrderStatusPaid:     event.OrderPaid,
+	dal.OrderStatusCanceled: event.OrderCanceled,
+}
+
+// ParseStatus 校验并转换状态字符串
+func ParseStatus(s string) (dal.OrderStatus, error) {
+	status := dal.OrderStatus(s)
+	if _, ok := transitions[status]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidStatus, s)
+	}
+	return status, nil
+}
+
+// CanTransition 判断状态流转是否合法
+func CanTransition(from, to dal.OrderStatus) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckMerchant 校验商家能否变更订单状态：订单中的每个商品都必须属于该商家
+// 包含平台商品或其他商家商品的订单只能由管理员处理；已删除的商品仍按原归属判断。
+func CheckMerchant(ctx context.Context, orderNo string, merchantID uint) error {
+	var o dal.Order
+	if err := dal.DB.WithContext(ctx).Select("order_no", "items").Where("order_no = ?", orderNo).First(&o).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrOrderNotFound
+		}
+		return fmt.Errorf("订单查询失败: %w", err)
+	}
+	items, err := Items(&o)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[uint]bool, len(items))
+	productIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+	if merchantID == 0 || len(productIDs) == 0 {
+		return ErrForbidden
+	}
+
+	var owned int64
+	if err := dal.DB.WithContext(ctx).Unscoped().Model(&dal.Product{}).
+		Where("id IN ? AND merchant_id = ?", productIDs, merchantID).
+		Count(&owned).Error; err != nil {
+		return fmt.Errorf("商品查询失败: %w", err)
+	}
+	if int(owned) != len(productIDs) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Transition 按状态机变更订单状态并记录流转历史
+// 使用乐观并发控制（WHERE status = 当前状态），并发修改时返回 ErrStatusConflict。
+// db可以是外部事务，以便与其他写操作保持原子性。
+func Transition(ctx context.Context, db *gorm.DB, orderNo string, to dal.OrderStatus, actor, reason string) (*dal.Order, error) {
+	if _, ok := transitions[to]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStatus, to)
+	}
+
+	var o dal.Order
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("order_no = ?", orderNo).First(&o).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrOrderNotFound
+			}
+			return fmt.Errorf("订单查询失败: %w", err)
+		}
+
+		from := o.Status
+		if !CanTransition(from, to) {
+			return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+		}
+
+		result := tx.Model(&dal.Order{}).
+			Where("order_no = ? AND status = ?", orderNo, from).
+			Update("status", to)
+		if result.Error != nil {
+			return fmt.Errorf("订单状态更新失败: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrStatusConflict
+		}
+
+		history := &dal.OrderStatusHistory{
+			OrderNo:    orderNo,
+			FromStatus: from,
+			ToStatus:   to,
+			Actor:      actor,
+			Reason:     reason,
+		}
+		if err := tx.Create(history).Error; err != nil {
+			return fmt.Errorf("状态流转记录失败: %w", err)
+		}
+
+		o.Status = to
+		if eventType, ok := statusEvents[to]; ok {
+			if err := event.Add(tx, eventType, orderNo, newOrderEvent(&o, from, actor, reason)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zap.L().Info("订单状态已变更",
+		zap.String("order_no", orderNo),
+		zap.String("status", string(to)),
+		zap.String("actor", actor),
+		zap.String("reason", reason))
+	return &o, nil
+}
+
+// newOrderEvent 构造订单事件内容
+func newOrderEvent(o *dal.Order, from dal.OrderStatus, actor, reason string) event.OrderEvent {
+	return event.OrderEvent{
+		OrderNo:    o.OrderNo,
+		UserID:     o.UserID,
+		Status:     string(o.Status),
+		FromStatus: string(from),
+		Amount:     o.Amount,
+		Actor:      actor,
+		Reason:     reason,
+	}
+}
@@ -0,0 +1,146 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to dal.OrderStatus
+		want     bool
+	}{
+		{dal.OrderStatusUnpaid, dal.OrderStatusPaid, true},
+		{dal.OrderStatusUnpaid, dal.OrderStatusCanceled, true},
+		{dal.OrderStatusUnpaid, dal.OrderStatusShipped, false},
+		{dal.OrderStatusUnpaid, dal.OrderStatusRefunding, false},
+		{dal.OrderStatusPaid, dal.OrderStatusShipped, true},
+		{dal.OrderStatusPaid, dal.OrderStatusRefunding, true},
+		{dal.OrderStatusPaid, dal.OrderStatusCanceled, false},
+		{dal.OrderStatusPaid, dal.OrderStatusUnpaid, false},
+		{dal.OrderStatusShipped, dal.OrderStatusDelivered, true},
+		{dal.OrderStatusShipped, dal.OrderStatusCompleted, false},
+		{dal.OrderStatusDelivered, dal.OrderStatusCompleted, true},
+		{dal.OrderStatusCompleted, dal.OrderStatusRefunding, true},
+		{dal.OrderStatusRefunding, dal.OrderStatusRefunded, true},
+		{dal.OrderStatusRefunding, dal.OrderStatusPaid, false},
+		{dal.OrderStatusCanceled, dal.OrderStatusPaid, false},
+		{dal.OrderStatusRefunded, dal.OrderStatusRefunding, false},
+		{dal.OrderStatusPaid, dal.OrderStatusPaid, false},
+		{"unknown", dal.OrderStatusPaid, false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v，期望 %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	for _, s := range []string{"unpaid", "paid", "shipped", "delivered", "completed", "canceled", "refunding", "refunded"} {
+		if got, err := ParseStatus(s); err != nil || string(got) != s {
+			t.Errorf("ParseStatus(%q) = %q, %v，期望 %q", s, got, err, s)
+		}
+	}
+	for _, s := range []string{"", "PAID", "cancelled"} {
+		if _, err := ParseStatus(s); !errors.Is(err, ErrInvalidStatus) {
+			t.Errorf("ParseStatus(%q) 错误 = %v，期望 %v", s, err, ErrInvalidStatus)
+		}
+	}
+}
+
+func TestTransition(t *testing.T) {
+	testutil.DB(t, &dal.Order{}, &dal.OrderStatusHistory{}, &dal.OutboxEvent{})
+	ctx := context.Background()
+
+	o := &dal.Order{UserID: 1, OrderNo: "T4001", Amount: money.FromCents(100), Items: "{}", Status: dal.OrderStatusUnpaid}
+	if err := dal.DB.Create(o).Error; err != nil {
+		t.Fatalf("创建订单失败: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		to   dal.OrderStatus
+		want error
+	}{
+		{"支付", dal.OrderStatusPaid, nil},
+		{"重复支付", dal.OrderStatusPaid, ErrInvalidTransition},
+		{"已支付不能直接取消", dal.OrderStatusCanceled, ErrInvalidTransition},
+		{"未知状态", "lost", ErrInvalidStatus},
+		{"发货", dal.OrderStatusShipped, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Transition(ctx, dal.DB, o.OrderNo, tt.to, "tester", ""); !errors.Is(err, tt.want) {
+				t.Errorf("Transition(%s) 错误 = %v，期望 %v", tt.to, err, tt.want)
+			}
+		})
+	}
+	if _, err := Transition(ctx, dal.DB, "missing", dal.OrderStatusPaid, "tester", ""); !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("Transition() 错误 = %v，期望 %v", err, ErrOrderNotFound)
+	}
+
+	var history []dal.OrderStatusHistory
+	dal.DB.Where("order_no = ?", o.OrderNo).Order("id").Find(&history)
+	if len(history) != 2 || history[0].ToStatus != dal.OrderStatusPaid || history[1].ToStatus != dal.OrderStatusShipped {
+		t.Errorf("流转历史 = %+v，期望 paid、shipped 两条", history)
+	}
+}
+
+func TestCheckMerchant(t *testing.T) {
+	testutil.DB(t, &dal.Order{}, &dal.Product{})
+	ctx := context.Background()
+
+	const merchantA, merchantB = 1, 2
+	products := []*dal.Product{
+		{Name: "A的商品", MerchantID: merchantA},
+		{Name: "A的另一商品", MerchantID: merchantA},
+		{Name: "B的商品", MerchantID: merchantB},
+		{Name: "平台商品"},
+	}
+	for _, p := range products {
+		dal.DB.Create(p)
+	}
+	// 已删除的商品仍按原归属判断
+	dal.DB.Delete(products[1])
+
+	order := func(orderNo string, products ...*dal.Product) {
+		snapshot := dal.OrderItemsSnapshot{Version: dal.OrderItemsSnapshotVersion}
+		for _, p := range products {
+			snapshot.Items = append(snapshot.Items, dal.OrderItemSnapshot{ProductID: p.ID, SkuID: p.ID, Quantity: 1})
+		}
+		items, _ := json.Marshal(snapshot)
+		dal.DB.Create(&dal.Order{UserID: 9, OrderNo: orderNo, Items: string(items), Status: dal.OrderStatusPaid})
+	}
+	order("T8001", products[0], products[1], products[0])
+	order("T8002", products[2])
+	order("T8003", products[0], products[2])
+	order("T8004", products[3])
+
+	tests := []struct {
+		name     string
+		orderNo  string
+		merchant uint
+		want     error
+	}{
+		{"商家处理自己的订单", "T8001", merchantA, nil},
+		{"商家A处理商家B的订单", "T8002", merchantA, ErrForbidden},
+		{"商家B处理商家A的订单", "T8001", merchantB, ErrForbidden},
+		{"订单含其他商家的商品", "T8003", merchantA, ErrForbidden},
+		{"平台商品订单", "T8004", merchantA, ErrForbidden},
+		{"订单不存在", "missing", merchantA, ErrOrderNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckMerchant(ctx, tt.orderNo, tt.merchant); !errors.Is(err, tt.want) {
+				t.Errorf("CheckMerchant() 错误 = %v，期望 %v", err, tt.want)
+			}
+		})
+	}
+}
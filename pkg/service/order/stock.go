@@ -0,0 +1,84 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/kitex_gen/product"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/rpc"
+	"go.uber.org/zap"
+)
+
+// StockRequestID 订单行扣减库存的幂等键，归还时使用同一个键
+// 引入SKU前的订单行没有SKU，使用商品ID（与商品默认SKU的ID相同，幂等键保持不变）。
+func StockRequestID(orderNo string, line dal.OrderItemSnapshot) string {
+	id := line.SkuID
+	if id == 0 {
+		id = line.ProductID
+	}
+	return fmt.Sprintf("%s:%d", orderNo, id)
+}
+
+// Items 解析订单的商品快照
+func Items(o *dal.Order) ([]dal.OrderItemSnapshot, error) {
+	var snapshot dal.OrderItemsSnapshot
+	if err := json.Unmarshal([]byte(o.Items), &snapshot); err != nil {
+		return nil, fmt.Errorf("订单%s商品快照解析失败: %w", o.OrderNo, err)
+	}
+	switch snapshot.Version {
+	case dal.OrderItemsSnapshotVersion:
+	case 2:
+		// 滚动升级期间旧版本服务仍可能写入v2快照，订单行补为商品的默认SKU
+		for i := range snapshot.Items {
+			snapshot.Items[i].SkuID = snapshot.Items[i].ProductID
+		}
+	default:
+		return nil, fmt.Errorf("订单%s商品快照版本不支持: %d", o.OrderNo, snapshot.Version)
+	}
+	return snapshot.Items, nil
+}
+
+// ReserveStock 通过商品服务为订单预扣库存
+// 任一商品扣减失败时归还本订单已扣减的库存，返回的error保留商品服务的业务错误码。
+func ReserveStock(ctx context.Context, orderNo string, lines []dal.OrderItemSnapshot) error {
+	for _, line := range lines {
+		req := &product.DecreaseStockReq{
+			ProductId: int64(line.ProductID),
+			Quantity:  int32(line.Quantity),
+			RequestId: StockRequestID(orderNo, line),
+		}
+		if line.SkuID != 0 {
+			skuID := int64(line.SkuID)
+			req.SkuId = &skuID
+		}
+		_, err := rpc.ProductClient.DecreaseStock(ctx, req)
+		if err != nil {
+			// 失败的这一行也可能已在服务端扣减成功（如超时），归还是幂等的，统一全部归还
+			if releaseErr := ReleaseStock(ctx, orderNo, lines); releaseErr != nil {
+				zap.L().Error("预扣库存回滚失败",
+					zap.String("order_no", orderNo),
+					zap.Error(releaseErr))
+			}
+			return fmt.Errorf("商品%d（SKU %d）库存扣减失败: %w", line.ProductID, line.SkuID, err)
+		}
+	}
+	return nil
+}
+
+// ReleaseStock 归还订单预扣的库存，可重复调用
+func ReleaseStock(ctx context.Context, orderNo string, lines []dal.OrderItemSnapshot) error {
+	var errs []error
+	for _, line := range lines {
+		_, err := rpc.ProductClient.ReleaseStock(ctx, &product.ReleaseStockReq{
+			RequestId: StockRequestID(orderNo, line),
+			ProductId: int64(line.ProductID),
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("商品%d（SKU %d）库存归还失败: %w", line.ProductID, line.SkuID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
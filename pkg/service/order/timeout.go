@@ -0,0 +1,159 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 未支付订单超时队列，成员为订单号
+var timeoutQueue = redis.NewDelayQueue("order:pay_timeout", time.Minute)
+
+// errRescheduled 订单未到截止时间，已按新的截止时间重新登记，队列成员不能再确认删除
+var errRescheduled = errors.New("订单已重新登记超时任务")
+
+const (
+	timeoutPollInterval  = time.Second
+	timeoutSweepInterval = time.Minute
+	timeoutBatchSize     = 100
+)
+
+// PayTimeout 未支付订单的自动取消时间，默认15分钟
+func PayTimeout() time.Duration {
+	if m := config.Conf.Order.PayTimeoutMinutes; m > 0 {
+		return time.Duration(m) * time.Minute
+	}
+	return 15 * time.Minute
+}
+
+// ScheduleTimeout 登记订单的超时取消任务
+func ScheduleTimeout(ctx context.Context, orderNo string, createdAt time.Time) error {
+	return timeoutQueue.Push(ctx, orderNo, createdAt.Add(PayTimeout()))
+}
+
+// StartTimeoutWorker 启动超时取消任务，ctx结束时退出
+// 队列领取带租约，多个订单服务副本可同时运行；另有定时扫表兜底，
+// 处理Redis数据丢失或功能上线前创建的订单。
+func StartTimeoutWorker(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(timeoutPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pollTimeouts(ctx)
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(timeoutSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sweepExpired(ctx)
+			}
+		}
+	}()
+}
+
+func pollTimeouts(ctx context.Context) {
+	orderNos, err := timeoutQueue.Poll(ctx, timeoutBatchSize)
+	if err != nil {
+		zap.L().Error("超时订单领取失败", zap.Error(err))
+		return
+	}
+
+	for _, orderNo := range orderNos {
+		err := CancelExpired(ctx, orderNo)
+		if errors.Is(err, errRescheduled) {
+			// 重新登记与领取的是同一成员，确认会删除新的超时任务
+			continue
+		}
+		if err != nil {
+			// 不确认，租约到期后重试
+			zap.L().Error("超时订单取消失败",
+				zap.String("order_no", orderNo),
+				zap.Error(err))
+			continue
+		}
+		if err := timeoutQueue.Ack(ctx, orderNo); err != nil {
+			zap.L().Warn("超时任务确认失败",
+				zap.String("order_no", orderNo),
+				zap.Error(err))
+		}
+	}
+}
+
+func sweepExpired(ctx context.Context) {
+	var orderNos []string
+	err := dal.DB.WithContext(ctx).Model(&dal.Order{}).
+		Where("status = ? AND created_at < ?", dal.OrderStatusUnpaid, time.Now().Add(-PayTimeout())).
+		Order("id").
+		Limit(timeoutBatchSize).
+		Pluck("order_no", &orderNos).Error
+	if err != nil {
+		zap.L().Error("超时订单扫描失败", zap.Error(err))
+		return
+	}
+
+	for _, orderNo := range orderNos {
+		if err := CancelExpired(ctx, orderNo); err != nil && !errors.Is(err, errRescheduled) {
+			zap.L().Error("超时订单取消失败",
+				zap.String("order_no", orderNo),
+				zap.Error(err))
+		}
+	}
+}
+
+// CancelExpired 取消超时未支付的订单并归还库存，可重复执行
+// 已支付的订单保持不变；已取消的订单会再次尝试归还库存（归还本身是幂等的）；
+// 未到截止时间的订单重新登记超时任务并返回 errRescheduled。
+func CancelExpired(ctx context.Context, orderNo string) error {
+	var o dal.Order
+	if err := dal.DB.WithContext(ctx).Where("order_no = ?", orderNo).First(&o).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+
+	switch o.Status {
+	case dal.OrderStatusUnpaid:
+		if deadline := o.CreatedAt.Add(PayTimeout()); time.Now().Before(deadline) {
+			// 超时时间被调长等情况，按新的截止时间重新登记
+			if err := timeoutQueue.Push(ctx, orderNo, deadline); err != nil {
+				return err
+			}
+			return errRescheduled
+		}
+		canceled, err := Transition(ctx, dal.DB, orderNo, dal.OrderStatusCanceled, "system", "支付超时自动取消")
+		if err != nil {
+			if errors.Is(err, ErrInvalidTransition) {
+				return nil // 并发支付成功
+			}
+			return err
+		}
+		o = *canceled
+	case dal.OrderStatusCanceled:
+	default:
+		return nil
+	}
+
+	lines, err := Items(&o)
+	if err != nil {
+		return err
+	}
+	return ReleaseStock(ctx, orderNo, lines)
+}
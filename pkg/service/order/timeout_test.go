@@ -0,0 +1,42 @@
+package order
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+func TestPollTimeoutsKeepsRescheduledOrder(t *testing.T) {
+	testutil.DB(t, &dal.Order{}, &dal.OrderStatusHistory{}, &dal.OutboxEvent{})
+	testutil.Config(t, nil)
+	mr := testutil.Redis(t)
+	ctx := context.Background()
+
+	// 订单在超时时间调长前登记，领取时尚未到新的截止时间
+	o := &dal.Order{UserID: 1, OrderNo: "T3001", Amount: money.FromCents(100), Items: "{}", Status: dal.OrderStatusUnpaid}
+	if err := dal.DB.Create(o).Error; err != nil {
+		t.Fatalf("创建订单失败: %v", err)
+	}
+	if err := timeoutQueue.Push(ctx, o.OrderNo, time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("登记超时任务失败: %v", err)
+	}
+
+	pollTimeouts(ctx)
+
+	score, err := mr.ZScore("order:pay_timeout", o.OrderNo)
+	if err != nil {
+		t.Fatalf("重新登记的超时任务被删除: %v", err)
+	}
+	if want := float64(o.CreatedAt.Add(PayTimeout()).UnixMilli()); score != want {
+		t.Errorf("超时任务时间 = %.0f，期望 %.0f", score, want)
+	}
+	var saved dal.Order
+	dal.DB.First(&saved, o.ID)
+	if saved.Status != dal.OrderStatusUnpaid {
+		t.Errorf("订单状态 = %s，期望 %s", saved.Status, dal.OrderStatusUnpaid)
+	}
+}
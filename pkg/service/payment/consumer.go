@@ -0,0 +1,108 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"go.uber.org/zap"
+)
+
+const (
+	cancelRefundConsumerGroup = "payment-cancel-refund"
+	cancelRefundRetryAttempts = 3
+)
+
+// StartConsumers 订阅订单取消与待退款事件，已支付订单取消后全额退款
+// 退款成功后 RefundSucceeded 事件由订单服务消费，订单从退款中流转为已退款。
+func StartConsumers(ctx context.Context, broker event.Broker) error {
+	return broker.Subscribe(ctx, cancelRefundConsumerGroup,
+		event.WithRetry(cancelRefundConsumerGroup, cancelRefundRetryAttempts, handleCancelRefund))
+}
+
+func handleCancelRefund(ctx context.Context, msg *event.Message) error {
+	switch msg.Type {
+	case event.OrderCanceled:
+		return handleOrderCanceled(ctx, msg)
+	case event.PaymentRefundPending:
+		return handleRefundPending(ctx, msg)
+	}
+	return nil
+}
+
+// handleOrderCanceled 只处理转入退款中的取消（已支付订单），未支付订单取消时没有需要退的款
+func handleOrderCanceled(ctx context.Context, msg *event.Message) error {
+	var evt event.OrderEvent
+	if err := msg.Decode(&evt); err != nil {
+		zap.L().Error("订单取消事件解析失败", zap.String("event_id", msg.ID), zap.Error(err))
+		return nil
+	}
+	if evt.Status != string(dal.OrderStatusRefunding) {
+		return nil
+	}
+
+	reason := evt.Reason
+	if reason == "" {
+		reason = "订单取消"
+	}
+	return refundCanceledOrder(ctx, evt.OrderNo, reason)
+}
+
+// handleRefundPending 支付回调晚于订单取消（或对账发现）时，支付成功的记录转为待退款后全额退款
+func handleRefundPending(ctx context.Context, msg *event.Message) error {
+	var evt event.PaymentEvent
+	if err := msg.Decode(&evt); err != nil {
+		zap.L().Error("待退款事件解析失败", zap.String("event_id", msg.ID), zap.Error(err))
+		return nil
+	}
+	return refundCanceledOrder(ctx, evt.OrderNo, "订单已取消，支付成功后自动退款")
+}
+
+// refundCanceledOrder 全额退款，事件重复投递时不会重复登记退款
+// 已有处理中的退款（上次渠道结果未知）时重新提交该退款；已有成功的退款时视为已处理。
+func refundCanceledOrder(ctx context.Context, orderNo, reason string) error {
+	refunds, err := ListRefunds(ctx, orderNo)
+	if err != nil {
+		return err
+	}
+	for i := range refunds {
+		switch refunds[i].Status {
+		case RefundStatusPending:
+			refund, err := RetryRefund(ctx, refunds[i].RefundID)
+			if err != nil {
+				return err
+			}
+			return checkRefundResult(refund)
+		case RefundStatusSuccess:
+			return nil
+		}
+	}
+
+	refund, err := Refund(ctx, orderNo, nil, reason)
+	switch {
+	case errors.Is(err, ErrPaymentNotFound):
+		zap.L().Warn("取消的订单没有支付记录，无需退款", zap.String("order_id", orderNo))
+		return nil
+	case errors.Is(err, ErrRefundExceeded), errors.Is(err, ErrPaymentNotRefundable):
+		// 已全额退款或支付记录已关闭
+		zap.L().Info("取消的订单无可退金额", zap.String("order_id", orderNo), zap.Error(err))
+		return nil
+	case err != nil:
+		return err
+	}
+	return checkRefundResult(refund)
+}
+
+// checkRefundResult 渠道拒绝退款时返回错误，重试后仍失败的事件进入死信等待人工处理
+func checkRefundResult(refund *dal.RefundRecord) error {
+	if refund.Status == RefundStatusFailed {
+		return fmt.Errorf("订单%s退款失败: %s", refund.OrderID, refund.LastError)
+	}
+	zap.L().Info("取消的订单已发起退款",
+		zap.String("order_id", refund.OrderID),
+		zap.String("refund_id", refund.RefundID),
+		zap.String("status", refund.Status))
+	return nil
+}
@@ -0,0 +1,154 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+)
+
+func orderCanceledMessage(t *testing.T, evt event.OrderEvent) *event.Message {
+	t.Helper()
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		t.Fatalf("事件序列化失败: %v", err)
+	}
+	return &event.Message{ID: "evt-" + evt.OrderNo, Type: event.OrderCanceled, AggregateID: evt.OrderNo, Payload: payload}
+}
+
+func TestCanceledPaidOrderIsRefunded(t *testing.T) {
+	setupStore(t)
+	ctx := context.Background()
+
+	amount := money.FromCents(2500)
+	record := &dal.PaymentRecord{OrderID: "T2001", PaymentID: "pay-2001", Amount: amount, Status: StatusSuccess, UserID: 7}
+	if err := dal.DB.Create(record).Error; err != nil {
+		t.Fatalf("创建支付记录失败: %v", err)
+	}
+	// 模拟网关中已支付成功的交易
+	redis.Client.HSet(ctx, mockKey(record.PaymentID),
+		"order_no", record.OrderID, "amount_cents", amount.Cents, "currency", amount.Currency, "status", mockStatusSuccess)
+
+	msg := orderCanceledMessage(t, event.OrderEvent{
+		OrderNo:    record.OrderID,
+		UserID:     7,
+		Status:     string(dal.OrderStatusRefunding),
+		FromStatus: string(dal.OrderStatusPaid),
+		Amount:     amount,
+	})
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	broker := event.NewMemoryBroker()
+	if err := StartConsumers(ctx, broker); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+	broker.Publish(ctx, msg)
+
+	var refunds []dal.RefundRecord
+	deadline := time.Now().Add(time.Second)
+	for len(refunds) == 0 || refunds[0].Status == RefundStatusPending {
+		if time.Now().After(deadline) {
+			t.Fatalf("退款记录 = %+v，期望消费取消事件后完成退款", refunds)
+		}
+		time.Sleep(10 * time.Millisecond)
+		refunds, _ = ListRefunds(ctx, record.OrderID)
+	}
+
+	// 重复投递不会重复退款
+	if err := handleOrderCanceled(ctx, msg); err != nil {
+		t.Fatalf("重复处理取消事件失败: %v", err)
+	}
+	refunds, _ = ListRefunds(ctx, record.OrderID)
+	if len(refunds) != 1 || refunds[0].Status != RefundStatusSuccess || !refunds[0].Amount.Equal(amount) {
+		t.Fatalf("退款记录 = %+v，期望一笔 %s 的成功退款", refunds, amount)
+	}
+	var saved dal.PaymentRecord
+	dal.DB.First(&saved, record.ID)
+	if saved.Status != StatusRefunded {
+		t.Errorf("支付记录状态 = %s，期望 %s", saved.Status, StatusRefunded)
+	}
+	// 订单服务消费全额退款成功事件，把订单流转为已退款
+	var full event.RefundEvent
+	var outbox dal.OutboxEvent
+	dal.DB.Where("type = ?", event.RefundSucceeded).First(&outbox)
+	if err := json.Unmarshal([]byte(outbox.Payload), &full); err != nil || !full.Full {
+		t.Errorf("RefundSucceeded事件 = %s，期望全额退款", outbox.Payload)
+	}
+}
+
+func TestCanceledUnpaidOrderIsIgnored(t *testing.T) {
+	setupStore(t)
+	ctx := context.Background()
+
+	record := &dal.PaymentRecord{OrderID: "T2002", PaymentID: "pay-2002", Amount: money.FromCents(100), Status: StatusCanceled}
+	dal.DB.Create(record)
+
+	msg := orderCanceledMessage(t, event.OrderEvent{
+		OrderNo:    record.OrderID,
+		Status:     string(dal.OrderStatusCanceled),
+		FromStatus: string(dal.OrderStatusUnpaid),
+	})
+	if err := handleOrderCanceled(ctx, msg); err != nil {
+		t.Fatalf("处理取消事件失败: %v", err)
+	}
+	if refunds, _ := ListRefunds(ctx, record.OrderID); len(refunds) != 0 {
+		t.Errorf("未支付订单不应退款，退款记录 = %+v", refunds)
+	}
+}
+
+func TestLateNotificationForCanceledOrderIsRefunded(t *testing.T) {
+	setupStore(t)
+	ctx := context.Background()
+
+	// 用户先取消了订单，之后支付回调才把支付记录置为成功
+	amount := money.FromCents(1800)
+	record := &dal.PaymentRecord{OrderID: "T2003", PaymentID: "pay-2003", Amount: amount, Status: StatusSuccess, UserID: 7}
+	dal.DB.Create(record)
+	redis.Client.HSet(ctx, mockKey(record.PaymentID),
+		"order_no", record.OrderID, "amount_cents", amount.Cents, "currency", amount.Currency, "status", mockStatusSuccess)
+
+	for i := 0; i < 2; i++ {
+		if err := MarkRefundPending(ctx, dal.DB, record.OrderID); err != nil {
+			t.Fatalf("第%d次标记待退款失败: %v", i+1, err)
+		}
+	}
+	var outbox []dal.OutboxEvent
+	dal.DB.Where("type = ?", event.PaymentRefundPending).Find(&outbox)
+	if len(outbox) != 1 {
+		t.Fatalf("PaymentRefundPending事件数 = %d，期望 1", len(outbox))
+	}
+	msg := &event.Message{ID: "evt-refund-pending", Type: outbox[0].Type,
+		AggregateID: outbox[0].AggregateID, Payload: json.RawMessage(outbox[0].Payload)}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	broker := event.NewMemoryBroker()
+	if err := StartConsumers(ctx, broker); err != nil {
+		t.Fatalf("订阅失败: %v", err)
+	}
+	broker.Publish(ctx, msg)
+
+	var saved dal.PaymentRecord
+	deadline := time.Now().Add(time.Second)
+	for saved.Status != StatusRefunded {
+		if time.Now().After(deadline) {
+			t.Fatalf("支付记录状态 = %s，期望消费待退款事件后为 %s", saved.Status, StatusRefunded)
+		}
+		time.Sleep(10 * time.Millisecond)
+		dal.DB.First(&saved, record.ID)
+	}
+
+	// 重复投递不会重复退款
+	if err := handleCancelRefund(ctx, msg); err != nil {
+		t.Fatalf("重复处理待退款事件失败: %v", err)
+	}
+	refunds, _ := ListRefunds(ctx, record.OrderID)
+	if len(refunds) != 1 || refunds[0].Status != RefundStatusSuccess || !refunds[0].Amount.Equal(amount) {
+		t.Errorf("退款记录 = %+v，期望一笔 %s 的成功退款", refunds, amount)
+	}
+}
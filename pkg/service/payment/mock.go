@@ -0,0 +1,331 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	hclient "github.com/cloudwego/hertz/pkg/app/client"
+	"github.com/cloudwego/hertz/pkg/protocol"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// 模拟网关的支付结果选项
+const (
+	MockOutcomeSuccess = "success"
+	MockOutcomeFail    = "fail"
+	MockOutcomeTimeout = "timeout" // 不发送通知，模拟渠道超时
+)
+
+// 模拟网关的交易状态
+const (
+	mockStatusPending = "pending"
+	mockStatusSuccess = "success"
+	mockStatusFailed  = "failed"
+	mockStatusTimeout = "timeout"
+)
+
+const (
+	mockTxTTL          = 24 * time.Hour
+	mockNotifyAttempts = 3
+	mockNotifyTimeout  = 5 * time.Second
+)
+
+var (
+	ErrMockTxNotFound     = errors.New("模拟交易不存在")
+	ErrMockTxClosed       = errors.New("模拟交易已结束")
+	ErrMockInvalidOutcome = errors.New("无效的模拟支付结果")
+)
+
+// 交易状态只能从pending变更一次
+var mockFinishScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[1], 'status') ~= 'pending' then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'status', ARGV[1], 'trade_no', ARGV[2])
+return 1
+`)
+
+// 退款按退款单号去重，已退合计不能超过交易金额
+// 返回 {1, 渠道退款单号} 或 {0, 失败原因}
+var mockRefundScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[1], 'status') ~= 'success' then
+	return {0, 'transaction not refundable'}
+end
+if redis.call('HGET', KEYS[1], 'currency') ~= ARGV[4] then
+	return {0, 'currency mismatch'}
+end
+local field = 'refund:' .. ARGV[1]
+local tradeNo = redis.call('HGET', KEYS[1], field)
+if tradeNo then
+	return {1, tradeNo}
+end
+local refunded = tonumber(redis.call('HGET', KEYS[1], 'refunded_cents') or '0')
+if refunded + tonumber(ARGV[2]) > tonumber(redis.call('HGET', KEYS[1], 'amount_cents')) then
+	return {0, 'refund amount exceeds transaction amount'}
+end
+redis.call('HINCRBY', KEYS[1], 'refunded_cents', ARGV[2])
+redis.call('HSET', KEYS[1], field, ARGV[3])
+return {1, ARGV[3]}
+`)
+
+// MockTransaction 模拟网关中的交易
+type MockTransaction struct {
+	PaymentID string      `json:"payment_id"`
+	OrderNo   string      `json:"order_no"`
+	Amount    money.Money `json:"amount"`
+	Status    string      `json:"status"`
+	TradeNo   string      `json:"trade_no,omitempty"`
+	Refunded  money.Money `json:"refunded"`
+}
+
+// MockProvider 本地模拟支付网关
+// 交易保存在Redis中，支付页面由支付服务提供（/payment/confirm）；
+// 用户确认后按指定结果异步发送签名通知到回调地址，用于本地端到端联调。
+type MockProvider struct {
+	gatewayURL  string
+	callbackURL string
+	secret      []byte
+	client      *hclient.Client
+}
+
+func NewMockProvider(gatewayURL, callbackURL, secret string) (*MockProvider, error) {
+	client, err := hclient.NewClient(hclient.WithDialTimeout(3 * time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("模拟网关HTTP客户端创建失败: %w", err)
+	}
+	return &MockProvider{
+		gatewayURL:  gatewayURL,
+		callbackURL: callbackURL,
+		secret:      []byte(secret),
+		client:      client,
+	}, nil
+}
+
+func (p *MockProvider) Name() string { return "mock" }
+
+// Pay 登记待支付交易，交易已存在时保留原状态
+func (p *MockProvider) Pay(ctx context.Context, record *dal.PaymentRecord) (string, error) {
+	key := mockKey(record.PaymentID)
+	pipe := redis.Client.TxPipeline()
+	pipe.HSetNX(ctx, key, "order_no", record.OrderID)
+	pipe.HSetNX(ctx, key, "amount_cents", record.Amount.Cents)
+	pipe.HSetNX(ctx, key, "currency", record.Amount.Currency)
+	pipe.HSetNX(ctx, key, "status", mockStatusPending)
+	pipe.Expire(ctx, key, mockTxTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("模拟交易登记失败: %w", err)
+	}
+	return fmt.Sprintf("%s/payment/confirm?payment_id=%s", p.gatewayURL, record.PaymentID), nil
+}
+
+// ParseNotification 校验签名与时间戳并解析通知
+func (p *MockProvider) ParseNotification(header func(key string) string, body []byte) (*Notification, error) {
+	nonce, err := verifySignature(p.secret, header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var n Notification
+	if err := json.Unmarshal(body, &n); err != nil {
+		return nil, fmt.Errorf("回调内容解析失败: %w", err)
+	}
+	n.Nonce = nonce
+	return &n, nil
+}
+
+// Refund 同步完成模拟退款，交易不存在或未支付成功时退款失败
+func (p *MockProvider) Refund(ctx context.Context, refund *dal.RefundRecord) (*RefundResult, error) {
+	tradeNo := fmt.Sprintf("MOCKR%d", time.Now().UnixNano())
+	values, err := mockRefundScript.Run(ctx, redis.Client, []string{mockKey(refund.PaymentID)},
+		refund.RefundID, refund.Amount.Cents, tradeNo, refund.Amount.Currency).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("模拟退款失败: %w", err)
+	}
+	ok, _ := values[0].(int64)
+	msg, _ := values[1].(string)
+	if ok == 0 {
+		return &RefundResult{Status: RefundStatusFailed, Reason: msg}, nil
+	}
+	return &RefundResult{Status: RefundStatusSuccess, TradeNo: msg}, nil
+}
+
+// Close 关闭待支付的模拟交易，关闭后用户无法再确认支付
+func (p *MockProvider) Close(ctx context.Context, paymentID string) error {
+	_, err := p.Confirm(ctx, paymentID, MockOutcomeTimeout)
+	switch {
+	case err == nil, errors.Is(err, ErrMockTxNotFound):
+		return nil
+	case errors.Is(err, ErrMockTxClosed):
+		tx, queryErr := p.Transaction(ctx, paymentID)
+		if queryErr != nil {
+			return queryErr
+		}
+		if tx.Status == mockStatusTimeout {
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ErrTradeFinished, tx.Status)
+	default:
+		return err
+	}
+}
+
+// Transaction 查询模拟交易
+func (p *MockProvider) Transaction(ctx context.Context, paymentID string) (*MockTransaction, error) {
+	fields, err := redis.Client.HGetAll(ctx, mockKey(paymentID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("模拟交易查询失败: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, ErrMockTxNotFound
+	}
+
+	cents, _ := strconv.ParseInt(fields["amount_cents"], 10, 64)
+	refunded, _ := strconv.ParseInt(fields["refunded_cents"], 10, 64)
+	return &MockTransaction{
+		PaymentID: paymentID,
+		OrderNo:   fields["order_no"],
+		Amount:    money.New(cents, fields["currency"]),
+		Status:    fields["status"],
+		TradeNo:   fields["trade_no"],
+		Refunded:  money.New(refunded, fields["currency"]),
+	}, nil
+}
+
+// Query 查询模拟交易，timeout 对应渠道关闭的交易
+func (p *MockProvider) Query(ctx context.Context, paymentID string) (*Trade, error) {
+	tx, err := p.Transaction(ctx, paymentID)
+	if err != nil {
+		if errors.Is(err, ErrMockTxNotFound) {
+			return nil, ErrTradeNotFound
+		}
+		return nil, err
+	}
+
+	status := TradePending
+	switch tx.Status {
+	case mockStatusSuccess:
+		status = TradeSuccess
+	case mockStatusFailed:
+		status = TradeFailed
+	case mockStatusTimeout:
+		status = TradeClosed
+	}
+	return &Trade{
+		PaymentID: tx.PaymentID,
+		OrderNo:   tx.OrderNo,
+		Status:    status,
+		Amount:    tx.Amount,
+		Refunded:  tx.Refunded,
+		TradeNo:   tx.TradeNo,
+	}, nil
+}
+
+// Confirm 按指定结果完成模拟交易
+// success/fail 会异步发送签名通知；timeout 只关闭交易、不发送通知。
+func (p *MockProvider) Confirm(ctx context.Context, paymentID, outcome string) (*MockTransaction, error) {
+	var status string
+	switch outcome {
+	case MockOutcomeSuccess:
+		status = mockStatusSuccess
+	case MockOutcomeFail:
+		status = mockStatusFailed
+	case MockOutcomeTimeout:
+		status = mockStatusTimeout
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrMockInvalidOutcome, outcome)
+	}
+
+	tx, err := p.Transaction(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	tradeNo := fmt.Sprintf("MOCK%d", time.Now().UnixNano())
+	changed, err := mockFinishScript.Run(ctx, redis.Client, []string{mockKey(paymentID)}, status, tradeNo).Int()
+	if err != nil {
+		return nil, fmt.Errorf("模拟交易更新失败: %w", err)
+	}
+	if changed == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMockTxClosed, tx.Status)
+	}
+	tx.Status = status
+	tx.TradeNo = tradeNo
+
+	if status != mockStatusTimeout {
+		notifyStatus := NotifySuccess
+		if status == mockStatusFailed {
+			notifyStatus = NotifyFailed
+		}
+		go p.notify(&Notification{
+			PaymentID: tx.PaymentID,
+			OrderNo:   tx.OrderNo,
+			Status:    notifyStatus,
+			Amount:    tx.Amount,
+			TradeNo:   tradeNo,
+		})
+	}
+	return tx, nil
+}
+
+// notify 发送异步通知，失败时按退避重试，模拟真实渠道的通知行为
+func (p *MockProvider) notify(n *Notification) {
+	body, err := json.Marshal(n)
+	if err != nil {
+		zap.L().Error("模拟通知序列化失败", zap.Error(err))
+		return
+	}
+
+	delay := time.Second
+	for i := 1; i <= mockNotifyAttempts; i++ {
+		err = p.post(body)
+		if err == nil {
+			return
+		}
+		zap.L().Warn("模拟支付通知发送失败",
+			zap.String("payment_id", n.PaymentID),
+			zap.Int("attempt", i),
+			zap.Error(err))
+		time.Sleep(delay)
+		delay *= 2
+	}
+	zap.L().Error("模拟支付通知最终失败", zap.String("payment_id", n.PaymentID), zap.Error(err))
+}
+
+func (p *MockProvider) post(body []byte) error {
+	req := protocol.AcquireRequest()
+	resp := protocol.AcquireResponse()
+	defer protocol.ReleaseRequest(req)
+	defer protocol.ReleaseResponse(resp)
+
+	req.SetMethod("POST")
+	req.SetRequestURI(p.callbackURL)
+	// 每次发送（含重试）使用新的时间戳与nonce重新签名
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce := uuid.New().String()
+	req.Header.SetContentTypeBytes([]byte("application/json"))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(NonceHeader, nonce)
+	req.Header.Set(SignatureHeader, Sign(p.secret, timestamp, nonce, body))
+	req.SetBody(body)
+
+	if err := p.client.DoTimeout(context.Background(), req, resp, mockNotifyTimeout); err != nil {
+		return err
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("回调返回状态码 %d: %s", resp.StatusCode(), resp.Body())
+	}
+	return nil
+}
+
+func mockKey(paymentID string) string {
+	return "mockpay:" + paymentID
+}
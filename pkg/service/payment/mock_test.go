@@ -0,0 +1,156 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+const testSecret = "test-secret"
+
+// setupStore 使用测试库、内存Redis与模拟网关替换全局实例
+func setupStore(t *testing.T) *MockProvider {
+	t.Helper()
+	testutil.DB(t, &dal.Order{}, &dal.OrderStatusHistory{}, &dal.PaymentRecord{}, &dal.RefundRecord{}, &dal.OutboxEvent{})
+	testutil.Redis(t)
+	return useMockProvider(t, "http://callback.test")
+}
+
+func useMockProvider(t *testing.T, callbackURL string) *MockProvider {
+	t.Helper()
+	provider, err := NewMockProvider("http://gateway.test", callbackURL, testSecret)
+	if err != nil {
+		t.Fatalf("创建模拟网关失败: %v", err)
+	}
+	prev := DefaultProvider
+	DefaultProvider = provider
+	t.Cleanup(func() { DefaultProvider = prev })
+	return provider
+}
+
+// callbackServer 接收模拟网关发送的通知
+func callbackServer(t *testing.T) (string, <-chan *http.Request, <-chan []byte) {
+	t.Helper()
+	reqs, bodies := make(chan *http.Request, 4), make(chan []byte, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		reqs <- r
+		bodies <- body
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, reqs, bodies
+}
+
+func mockRecord(paymentID string) *dal.PaymentRecord {
+	return &dal.PaymentRecord{OrderID: "T1001", PaymentID: paymentID, Amount: money.FromCents(1999), Status: StatusPending}
+}
+
+func TestMockPayKeepsExistingTransaction(t *testing.T) {
+	provider := setupStore(t)
+	ctx := context.Background()
+
+	url, err := provider.Pay(ctx, mockRecord("pay-1"))
+	if err != nil {
+		t.Fatalf("Pay() 错误 = %v", err)
+	}
+	if want := "http://gateway.test/payment/confirm?payment_id=pay-1"; url != want {
+		t.Errorf("支付链接 = %q，期望 %q", url, want)
+	}
+	if _, err := provider.Confirm(ctx, "pay-1", MockOutcomeTimeout); err != nil {
+		t.Fatalf("Confirm() 错误 = %v", err)
+	}
+
+	// 重复登记不覆盖已结束的交易
+	if _, err := provider.Pay(ctx, mockRecord("pay-1")); err != nil {
+		t.Fatalf("重复 Pay() 错误 = %v", err)
+	}
+	tx, err := provider.Transaction(ctx, "pay-1")
+	if err != nil {
+		t.Fatalf("Transaction() 错误 = %v", err)
+	}
+	if tx.Status != mockStatusTimeout || tx.OrderNo != "T1001" || !tx.Amount.Equal(money.FromCents(1999)) {
+		t.Errorf("交易 = %+v，期望保留超时状态与原金额", tx)
+	}
+}
+
+func TestMockConfirm(t *testing.T) {
+	tests := []struct {
+		name       string
+		outcome    string
+		wantStatus string
+		wantNotify string // 空值表示不发送通知
+	}{
+		{"支付成功", MockOutcomeSuccess, mockStatusSuccess, NotifySuccess},
+		{"支付失败", MockOutcomeFail, mockStatusFailed, NotifyFailed},
+		{"渠道超时", MockOutcomeTimeout, mockStatusTimeout, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupStore(t)
+			callbackURL, reqs, bodies := callbackServer(t)
+			provider := useMockProvider(t, callbackURL)
+			ctx := context.Background()
+
+			if _, err := provider.Pay(ctx, mockRecord("pay-1")); err != nil {
+				t.Fatalf("Pay() 错误 = %v", err)
+			}
+			tx, err := provider.Confirm(ctx, "pay-1", tt.outcome)
+			if err != nil {
+				t.Fatalf("Confirm() 错误 = %v", err)
+			}
+			if tx.Status != tt.wantStatus || tx.TradeNo == "" {
+				t.Errorf("交易 = %+v，期望状态 %s 且有渠道交易号", tx, tt.wantStatus)
+			}
+
+			// 交易只能结束一次，重复确认被拒绝
+			if _, err := provider.Confirm(ctx, "pay-1", MockOutcomeSuccess); !errors.Is(err, ErrMockTxClosed) {
+				t.Errorf("重复 Confirm() 错误 = %v，期望 %v", err, ErrMockTxClosed)
+			}
+
+			if tt.wantNotify == "" {
+				select {
+				case <-reqs:
+					t.Fatal("超时结果不应发送通知")
+				case <-time.After(100 * time.Millisecond):
+				}
+				return
+			}
+			var req *http.Request
+			select {
+			case req = <-reqs:
+			case <-time.After(3 * time.Second):
+				t.Fatal("未收到支付通知")
+			}
+			n, err := provider.ParseNotification(req.Header.Get, <-bodies)
+			if err != nil {
+				t.Fatalf("ParseNotification() 错误 = %v", err)
+			}
+			if n.PaymentID != "pay-1" || n.OrderNo != "T1001" || n.Status != tt.wantNotify || n.TradeNo != tx.TradeNo || n.Nonce == "" {
+				t.Errorf("通知 = %+v，期望 %s 结果与交易一致", n, tt.wantNotify)
+			}
+		})
+	}
+}
+
+func TestMockConfirmRejects(t *testing.T) {
+	provider := setupStore(t)
+	ctx := context.Background()
+	if _, err := provider.Pay(ctx, mockRecord("pay-1")); err != nil {
+		t.Fatalf("Pay() 错误 = %v", err)
+	}
+
+	if _, err := provider.Confirm(ctx, "pay-1", "refund"); !errors.Is(err, ErrMockInvalidOutcome) {
+		t.Errorf("无效结果 Confirm() 错误 = %v，期望 %v", err, ErrMockInvalidOutcome)
+	}
+	if _, err := provider.Confirm(ctx, "pay-2", MockOutcomeSuccess); !errors.Is(err, ErrMockTxNotFound) {
+		t.Errorf("未登记交易 Confirm() 错误 = %v，期望 %v", err, ErrMockTxNotFound)
+	}
+}
@@ -0,0 +1,89 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+)
+
+// 异步通知签名相关请求头
+const (
+	SignatureHeader = "X-Payment-Signature"
+	TimestampHeader = "X-Payment-Timestamp" // Unix秒
+	NonceHeader     = "X-Payment-Nonce"
+)
+
+const (
+	// 通知时间戳与本地时间允许的最大偏差
+	notifyMaxSkew = 5 * time.Minute
+	// nonce保留时间需覆盖时间戳的有效窗口，窗口外的重放由时间戳校验拒绝
+	nonceTTL = 2 * notifyMaxSkew
+)
+
+var (
+	ErrNotificationExpired  = errors.New("回调时间戳无效或已过期")
+	ErrNotificationReplayed = errors.New("重复的回调请求")
+	ErrNotificationMismatch = errors.New("回调内容与支付记录不一致")
+)
+
+// Sign 计算通知签名：HMAC-SHA256(secret, timestamp + "\n" + nonce + "\n" + body)，十六进制编码
+func Sign(secret []byte, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("\n"))
+	mac.Write([]byte(nonce))
+	mac.Write([]byte("\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifySignature 校验签名与时间戳，返回nonce
+func verifySignature(secret []byte, header func(key string) string, body []byte) (string, error) {
+	timestamp, nonce := header(TimestampHeader), header(NonceHeader)
+	if nonce == "" {
+		return "", ErrInvalidSignature
+	}
+
+	expected, err := hex.DecodeString(Sign(secret, timestamp, nonce, body))
+	if err != nil {
+		return "", err
+	}
+	signature, err := hex.DecodeString(header(SignatureHeader))
+	if err != nil || !hmac.Equal(signature, expected) {
+		return "", ErrInvalidSignature
+	}
+
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", ErrNotificationExpired
+	}
+	if skew := time.Since(time.Unix(sec, 0)); skew > notifyMaxSkew || skew < -notifyMaxSkew {
+		return "", ErrNotificationExpired
+	}
+	return nonce, nil
+}
+
+// VerifyNotification 校验异步通知的签名、时间戳与nonce，防止伪造与重放
+// 同一nonce只能使用一次；渠道重试通知时会生成新的nonce与签名。
+func VerifyNotification(ctx context.Context, header func(key string) string, body []byte) (*Notification, error) {
+	n, err := DefaultProvider.ParseNotification(header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := redis.Client.SetNX(ctx, "payment:nonce:"+n.Nonce, 1, nonceTTL).Result()
+	if err != nil {
+		return nil, fmt.Errorf("回调nonce记录失败: %w", err)
+	}
+	if !ok {
+		return nil, ErrNotificationReplayed
+	}
+	return n, nil
+}
@@ -0,0 +1,82 @@
+package payment
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// signedHeader 生成带签名、时间戳与nonce的通知请求头
+func signedHeader(body []byte, timestamp time.Time, nonce string) func(string) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	headers := map[string]string{
+		TimestampHeader: ts,
+		NonceHeader:     nonce,
+		SignatureHeader: Sign([]byte(testSecret), ts, nonce, body),
+	}
+	return func(key string) string { return headers[key] }
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"payment_id":"pay-1","status":"success"}`)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		secret  string
+		body    []byte
+		headers map[string]string // 覆盖 signedHeader 生成的请求头，空值表示缺失
+		ts      time.Time
+		want    error
+	}{
+		{"签名正确", testSecret, body, nil, now, nil},
+		{"允许的时钟偏差内", testSecret, body, nil, now.Add(-notifyMaxSkew + time.Minute), nil},
+		{"请求体被篡改", testSecret, []byte(`{"payment_id":"pay-2","status":"success"}`), nil, now, ErrInvalidSignature},
+		{"密钥不一致", "other-secret", body, nil, now, ErrInvalidSignature},
+		{"缺少nonce", testSecret, body, map[string]string{NonceHeader: ""}, now, ErrInvalidSignature},
+		{"nonce被替换", testSecret, body, map[string]string{NonceHeader: "nonce-2"}, now, ErrInvalidSignature},
+		{"签名不是十六进制", testSecret, body, map[string]string{SignatureHeader: "not-hex"}, now, ErrInvalidSignature},
+		{"时间戳过期", testSecret, body, nil, now.Add(-notifyMaxSkew - time.Minute), ErrNotificationExpired},
+		{"时间戳超前", testSecret, body, nil, now.Add(notifyMaxSkew + time.Minute), ErrNotificationExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed := signedHeader(body, tt.ts, "nonce-1")
+			header := func(key string) string {
+				if v, ok := tt.headers[key]; ok {
+					return v
+				}
+				return signed(key)
+			}
+
+			nonce, err := verifySignature([]byte(tt.secret), header, tt.body)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("verifySignature() 错误 = %v，期望 %v", err, tt.want)
+			}
+			if err == nil && nonce != "nonce-1" {
+				t.Errorf("nonce = %q，期望 %q", nonce, "nonce-1")
+			}
+		})
+	}
+}
+
+func TestVerifySignatureRejectsMalformedTimestamp(t *testing.T) {
+	body := []byte(`{}`)
+	const ts, nonce = "yesterday", "nonce-1"
+	headers := map[string]string{
+		TimestampHeader: ts,
+		NonceHeader:     nonce,
+		SignatureHeader: Sign([]byte(testSecret), ts, nonce, body),
+	}
+	header := func(key string) string { return headers[key] }
+
+	if _, err := verifySignature([]byte(testSecret), header, body); !errors.Is(err, ErrNotificationExpired) {
+		t.Errorf("verifySignature() 错误 = %v，期望 %v", err, ErrNotificationExpired)
+	}
+	// 签名覆盖时间戳，改写时间戳后签名失效
+	headers[TimestampHeader] = strconv.FormatInt(time.Now().Unix(), 10)
+	if _, err := verifySignature([]byte(testSecret), header, body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("verifySignature() 错误 = %v，期望 %v", err, ErrInvalidSignature)
+	}
+}
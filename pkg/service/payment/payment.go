@@ -0,0 +1,274 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 支付记录状态
+const (
+	StatusPending       = "pending"
+	StatusSuccess       = "success"
+	StatusFailed        = "failed"
+	StatusCanceled      = "canceled"       // 下单流程回滚时关闭
+	StatusRefundPending = "refund_pending" // 订单已取消但支付成功，待退款
+	StatusPartRefunded  = "partially_refunded"
+	StatusRefunded      = "refunded"
+)
+
+// 支付相关错误定义（HTTP与下单流程共用）
+var (
+	ErrOrderNotFound   = errors.New("订单不存在")
+	ErrAmountMismatch  = errors.New("支付金额与订单金额不一致")
+	ErrOrderNotPayable = errors.New("订单当前状态不可支付")
+	ErrPaymentNotFound = errors.New("支付记录不存在")
+)
+
+// Create 为待支付订单创建支付记录，可重复调用
+// 每个订单只有一条支付记录（order_id唯一），重复创建时返回已有的待支付记录。
+// 金额必须与订单金额完全一致（整数分比较，无浮点误差）。
+func Create(ctx context.Context, orderNo string, amount money.Money) (*dal.PaymentRecord, error) {
+	var o dal.Order
+	if err := dal.DB.WithContext(ctx).Where("order_no = ?", orderNo).First(&o).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("订单查询失败: %w", err)
+	}
+	if !o.Amount.Equal(amount) {
+		zap.L().Warn("支付金额与订单金额不一致",
+			zap.String("order_id", orderNo),
+			zap.Stringer("order_amount", o.Amount),
+			zap.Stringer("pay_amount", amount))
+		return nil, ErrAmountMismatch
+	}
+	if o.Status != dal.OrderStatusUnpaid {
+		return nil, fmt.Errorf("%w: %s", ErrOrderNotPayable, o.Status)
+	}
+
+	if existing, err := findByOrder(ctx, orderNo); err != nil {
+		return nil, err
+	} else if existing != nil {
+		switch existing.Status {
+		case StatusPending:
+			return existing, nil
+		case StatusFailed:
+			// 上次支付失败，使用新的支付单号重新发起
+			return retry(ctx, existing)
+		default:
+			return nil, fmt.Errorf("%w: 支付记录状态 %s", ErrOrderNotPayable, existing.Status)
+		}
+	}
+
+	record := &dal.PaymentRecord{
+		OrderID:   orderNo,
+		PaymentID: uuid.New().String(),
+		Amount:    o.Amount,
+		Status:    StatusPending,
+		UserID:    o.UserID,
+	}
+	if err := dal.DB.WithContext(ctx).Create(record).Error; err != nil {
+		// 并发创建时唯一索引冲突，返回另一方创建的记录
+		if existing, findErr := findByOrder(ctx, orderNo); findErr == nil && existing != nil {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("支付记录创建失败: %w", err)
+	}
+	return record, nil
+}
+
+// Cancel 关闭订单的待支付记录，记录不存在或已不是待支付状态时不做处理
+func Cancel(ctx context.Context, orderNo string) error {
+	return dal.DB.WithContext(ctx).Model(&dal.PaymentRecord{}).
+		Where("order_id = ? AND status = ?", orderNo, StatusPending).
+		Update("status", StatusCanceled).Error
+}
+
+// Paid 返回订单已支付成功的支付记录（含之后已退款的），未支付成功时返回nil
+// 待退款的记录不算：订单已在支付成功前取消。
+func Paid(ctx context.Context, orderNo string) (*dal.PaymentRecord, error) {
+	record, err := findByOrder(ctx, orderNo)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	switch record.Status {
+	case StatusSuccess, StatusPartRefunded, StatusRefunded:
+		return record, nil
+	}
+	return nil, nil
+}
+
+// MarkSucceeded 支付成功：支付记录置为成功，并在同一事务中写入 PaymentSucceeded 事件
+// 通知中的支付单号与金额必须与支付记录、订单一致；
+// 重复回调时记录已不是待支付状态，直接返回当前记录，不再写入事件；
+// 下单流程回滚时关闭的记录同样置为成功，后续由订单状态冲突处理转为待退款。
+// db 可以是调用方的事务，与订单状态的更新一起提交。
+func MarkSucceeded(ctx context.Context, db *gorm.DB, n *Notification) (*dal.PaymentRecord, error) {
+	var record dal.PaymentRecord
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockForNotification(tx, n, &record); err != nil {
+			return err
+		}
+		if record.Status != StatusPending && record.Status != StatusCanceled {
+			return nil
+		}
+
+		if err := tx.Model(&record).Update("status", StatusSuccess).Error; err != nil {
+			return fmt.Errorf("支付记录更新失败: %w", err)
+		}
+		return event.Add(tx, event.PaymentSucceeded, n.OrderNo, event.PaymentEvent{
+			PaymentID: record.PaymentID,
+			OrderNo:   n.OrderNo,
+			UserID:    record.UserID,
+			Amount:    record.Amount,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// MarkFailed 支付失败：待支付记录置为失败，订单保持未支付，用户可重新发起支付
+func MarkFailed(ctx context.Context, n *Notification) error {
+	return dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var record dal.PaymentRecord
+		if err := lockForNotification(tx, n, &record); err != nil {
+			return err
+		}
+		if record.Status != StatusPending {
+			return nil
+		}
+		if err := tx.Model(&record).Update("status", StatusFailed).Error; err != nil {
+			return fmt.Errorf("支付记录更新失败: %w", err)
+		}
+		return nil
+	})
+}
+
+// lockForNotification 锁定通知对应的支付记录，并校验支付单号与金额
+func lockForNotification(tx *gorm.DB, n *Notification, record *dal.PaymentRecord) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ?", n.OrderNo).
+		First(record).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrPaymentNotFound
+		}
+		return fmt.Errorf("支付记录查询失败: %w", err)
+	}
+	if record.PaymentID != n.PaymentID {
+		// 失败后重新发起支付会更换支付单号，旧单号的通知不再处理
+		return fmt.Errorf("%w: 支付单号 %s，当前 %s", ErrNotificationMismatch, n.PaymentID, record.PaymentID)
+	}
+
+	var o dal.Order
+	if err := tx.Select("amount_cents", "amount_currency").Where("order_no = ?", n.OrderNo).First(&o).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrOrderNotFound
+		}
+		return fmt.Errorf("订单查询失败: %w", err)
+	}
+	if !n.Amount.Equal(record.Amount) || !n.Amount.Equal(o.Amount) {
+		zap.L().Warn("回调金额与支付记录不一致",
+			zap.String("order_id", n.OrderNo),
+			zap.Stringer("notify_amount", n.Amount),
+			zap.Stringer("record_amount", record.Amount),
+			zap.Stringer("order_amount", o.Amount))
+		return fmt.Errorf("%w: 金额 %s", ErrNotificationMismatch, n.Amount)
+	}
+	return nil
+}
+
+// Expire 关闭超时未支付的记录：订单已取消时置为已关闭，否则置为失败以便重新发起支付
+// 只处理仍是指定支付单号的待支付记录，返回记录是否被关闭。
+func Expire(ctx context.Context, record *dal.PaymentRecord) (bool, error) {
+	var o dal.Order
+	if err := dal.DB.WithContext(ctx).Select("status").Where("order_no = ?", record.OrderID).First(&o).Error; err != nil && err != gorm.ErrRecordNotFound {
+		return false, fmt.Errorf("订单查询失败: %w", err)
+	}
+	status := StatusFailed
+	if o.Status == dal.OrderStatusCanceled {
+		status = StatusCanceled
+	}
+
+	result := dal.DB.WithContext(ctx).Model(&dal.PaymentRecord{}).
+		Where("order_id = ? AND payment_id = ? AND status = ?", record.OrderID, record.PaymentID, StatusPending).
+		Update("status", status)
+	if result.Error != nil {
+		return false, fmt.Errorf("支付记录更新失败: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MarkRefundPending 订单已取消但支付成功：成功的支付记录标记为待退款，并在同一事务中写入 PaymentRefundPending 事件
+// 事件由本服务的消费者发起全额退款；记录已是待退款或已退款时不做处理。db可以是外部事务。
+func MarkRefundPending(ctx context.Context, db *gorm.DB, orderNo string) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var record dal.PaymentRecord
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ?", orderNo).
+			First(&record).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrPaymentNotFound
+			}
+			return fmt.Errorf("支付记录查询失败: %w", err)
+		}
+		if record.Status != StatusSuccess {
+			return nil
+		}
+
+		if err := tx.Model(&record).Update("status", StatusRefundPending).Error; err != nil {
+			return fmt.Errorf("支付记录更新失败: %w", err)
+		}
+		return event.Add(tx, event.PaymentRefundPending, orderNo, event.PaymentEvent{
+			PaymentID: record.PaymentID,
+			OrderNo:   orderNo,
+			UserID:    record.UserID,
+			Amount:    record.Amount,
+		})
+	})
+}
+
+// PayURL 在支付渠道登记交易并返回支付链接
+func PayURL(ctx context.Context, record *dal.PaymentRecord) (string, error) {
+	return DefaultProvider.Pay(ctx, record)
+}
+
+// retry 失败的支付记录换新支付单号后重新置为待支付
+func retry(ctx context.Context, record *dal.PaymentRecord) (*dal.PaymentRecord, error) {
+	paymentID := uuid.New().String()
+	result := dal.DB.WithContext(ctx).Model(record).
+		Where("status = ?", StatusFailed).
+		Updates(map[string]interface{}{"payment_id": paymentID, "status": StatusPending})
+	if result.Error != nil {
+		return nil, fmt.Errorf("支付记录更新失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// 并发重试，返回另一方更新后的记录
+		return findByOrder(ctx, record.OrderID)
+	}
+	record.PaymentID = paymentID
+	record.Status = StatusPending
+	return record, nil
+}
+
+func findByOrder(ctx context.Context, orderNo string) (*dal.PaymentRecord, error) {
+	var record dal.PaymentRecord
+	err := dal.DB.WithContext(ctx).Where("order_id = ?", orderNo).First(&record).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("支付记录查询失败: %w", err)
+	}
+	return &record, nil
+}
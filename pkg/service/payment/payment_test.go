@@ -0,0 +1,122 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	orderService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/order"
+)
+
+func TestNotificationMarksOrderPaid(t *testing.T) {
+	setupStore(t)
+	ctx := context.Background()
+
+	amount := money.FromCents(1999)
+	o := &dal.Order{UserID: 7, OrderNo: "T1001", Amount: amount, Items: "{}", Status: dal.OrderStatusUnpaid}
+	if err := dal.DB.Create(o).Error; err != nil {
+		t.Fatalf("创建订单失败: %v", err)
+	}
+	record, err := Create(ctx, o.OrderNo, amount)
+	if err != nil {
+		t.Fatalf("创建支付记录失败: %v", err)
+	}
+
+	body, _ := json.Marshal(&Notification{
+		PaymentID: record.PaymentID,
+		OrderNo:   o.OrderNo,
+		Status:    NotifySuccess,
+		Amount:    amount,
+		TradeNo:   "MOCK1",
+	})
+	header := signedHeader(body, time.Now(), "nonce-1")
+
+	n, err := VerifyNotification(ctx, header, body)
+	if err != nil {
+		t.Fatalf("校验通知失败: %v", err)
+	}
+	if _, err := MarkSucceeded(ctx, dal.DB, n); err != nil {
+		t.Fatalf("支付记录更新失败: %v", err)
+	}
+	// 支付服务通过订单RPC完成的流转
+	if _, err := orderService.Transition(ctx, dal.DB, o.OrderNo, dal.OrderStatusPaid, "payment-service", ""); err != nil {
+		t.Fatalf("订单流转为已支付失败: %v", err)
+	}
+
+	var saved dal.PaymentRecord
+	dal.DB.Where("order_id = ?", o.OrderNo).First(&saved)
+	if saved.Status != StatusSuccess {
+		t.Errorf("支付记录状态 = %s，期望 %s", saved.Status, StatusSuccess)
+	}
+	var paid dal.Order
+	dal.DB.Where("order_no = ?", o.OrderNo).First(&paid)
+	if paid.Status != dal.OrderStatusPaid {
+		t.Errorf("订单状态 = %s，期望 %s", paid.Status, dal.OrderStatusPaid)
+	}
+	var events []string
+	dal.DB.Model(&dal.OutboxEvent{}).Order("id").Pluck("type", &events)
+	if len(events) != 2 || events[0] != event.PaymentSucceeded || events[1] != event.OrderPaid {
+		t.Errorf("发件箱事件 = %v，期望 [%s %s]", events, event.PaymentSucceeded, event.OrderPaid)
+	}
+
+	// 重复回调：同一nonce被拒绝；渠道重试使用新nonce时幂等处理，不再写入事件
+	if _, err := VerifyNotification(ctx, header, body); !errors.Is(err, ErrNotificationReplayed) {
+		t.Errorf("重放通知错误 = %v，期望 %v", err, ErrNotificationReplayed)
+	}
+	n, err = VerifyNotification(ctx, signedHeader(body, time.Now(), "nonce-2"), body)
+	if err != nil {
+		t.Fatalf("校验重试通知失败: %v", err)
+	}
+	if _, err := MarkSucceeded(ctx, dal.DB, n); err != nil {
+		t.Fatalf("重复通知处理失败: %v", err)
+	}
+	var count int64
+	dal.DB.Model(&dal.OutboxEvent{}).Where("type = ?", event.PaymentSucceeded).Count(&count)
+	if count != 1 {
+		t.Errorf("PaymentSucceeded事件数 = %d，期望 1", count)
+	}
+}
+
+func TestNotificationAmountMismatch(t *testing.T) {
+	setupStore(t)
+	ctx := context.Background()
+
+	amount := money.FromCents(1999)
+	o := &dal.Order{UserID: 7, OrderNo: "T1002", Amount: amount, Items: "{}", Status: dal.OrderStatusUnpaid}
+	if err := dal.DB.Create(o).Error; err != nil {
+		t.Fatalf("创建订单失败: %v", err)
+	}
+	record, err := Create(ctx, o.OrderNo, amount)
+	if err != nil {
+		t.Fatalf("创建支付记录失败: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		notify Notification
+		want   error
+	}{
+		{"金额不一致", Notification{PaymentID: record.PaymentID, OrderNo: o.OrderNo, Amount: money.FromCents(1)}, ErrNotificationMismatch},
+		{"币种不一致", Notification{PaymentID: record.PaymentID, OrderNo: o.OrderNo, Amount: money.New(1999, "USD")}, ErrNotificationMismatch},
+		{"支付单号不一致", Notification{PaymentID: "other", OrderNo: o.OrderNo, Amount: amount}, ErrNotificationMismatch},
+		{"支付记录不存在", Notification{PaymentID: record.PaymentID, OrderNo: "missing", Amount: amount}, ErrPaymentNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MarkSucceeded(ctx, dal.DB, &tt.notify); !errors.Is(err, tt.want) {
+				t.Errorf("MarkSucceeded() 错误 = %v，期望 %v", err, tt.want)
+			}
+		})
+	}
+
+	var saved dal.PaymentRecord
+	dal.DB.Where("order_id = ?", o.OrderNo).First(&saved)
+	if saved.Status != StatusPending {
+		t.Errorf("支付记录状态 = %s，期望保持 %s", saved.Status, StatusPending)
+	}
+}
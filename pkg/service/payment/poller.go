@@ -0,0 +1,143 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	"go.uber.org/zap"
+)
+
+const (
+	pollInterval  = time.Minute
+	pollBatchSize = 100
+	pollLockKey   = "payment:poller:lock"
+)
+
+// NotificationHandler 处理支付结果，主动查询到的结果与异步通知走同一处理流程
+type NotificationHandler func(ctx context.Context, n *Notification) error
+
+// PollAfter 待支付记录超过该时间未收到通知时主动查询渠道，默认5分钟
+func PollAfter() time.Duration {
+	if m := config.Conf.Payment.PollAfterMinutes; m > 0 {
+		return time.Duration(m) * time.Minute
+	}
+	return 5 * time.Minute
+}
+
+// ExpireAfter 待支付记录的关闭时间，默认30分钟
+func ExpireAfter() time.Duration {
+	if m := config.Conf.Payment.ExpireAfterMinutes; m > 0 {
+		return time.Duration(m) * time.Minute
+	}
+	return 30 * time.Minute
+}
+
+// StartPoller 启动待支付记录轮询任务，ctx结束时退出
+// 异步通知丢失时按渠道查询结果补做支付成功/失败处理；
+// 超过关闭时间仍未支付的交易在渠道侧关闭，支付记录置为失败，用户可重新发起支付。
+// 多个支付服务副本同时运行时，每轮只由抢到锁的副本执行。
+func StartPoller(ctx context.Context, handle NotificationHandler) {
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pollPending(ctx, handle)
+			}
+		}
+	}()
+}
+
+func pollPending(ctx context.Context, handle NotificationHandler) {
+	locked, err := redis.Client.SetNX(ctx, pollLockKey, 1, pollInterval).Result()
+	if err != nil {
+		zap.L().Error("支付轮询锁获取失败", zap.Error(err))
+		return
+	}
+	if !locked {
+		return
+	}
+
+	// 重新发起支付会更新updated_at，按最近一次发起时间计算
+	var records []dal.PaymentRecord
+	err = dal.DB.WithContext(ctx).
+		Where("status = ? AND updated_at < ?", StatusPending, time.Now().Add(-PollAfter())).
+		Order("id").
+		Limit(pollBatchSize).
+		Find(&records).Error
+	if err != nil {
+		zap.L().Error("待支付记录查询失败", zap.Error(err))
+		return
+	}
+
+	for i := range records {
+		if err := pollOne(ctx, &records[i], handle); err != nil {
+			zap.L().Error("待支付记录处理失败",
+				zap.String("order_id", records[i].OrderID),
+				zap.String("payment_id", records[i].PaymentID),
+				zap.Error(err))
+		}
+	}
+}
+
+func pollOne(ctx context.Context, record *dal.PaymentRecord, handle NotificationHandler) error {
+	trade, err := DefaultProvider.Query(ctx, record.PaymentID)
+	if err != nil && !errors.Is(err, ErrTradeNotFound) {
+		return err
+	}
+
+	if trade != nil {
+		switch trade.Status {
+		case TradeSuccess, TradeFailed:
+			status := NotifySuccess
+			if trade.Status == TradeFailed {
+				status = NotifyFailed
+			}
+			zap.L().Warn("未收到支付通知，按渠道查询结果处理",
+				zap.String("order_id", record.OrderID),
+				zap.String("payment_id", record.PaymentID),
+				zap.String("status", trade.Status))
+			return handle(ctx, &Notification{
+				PaymentID: record.PaymentID,
+				OrderNo:   record.OrderID,
+				Status:    status,
+				Amount:    trade.Amount,
+				TradeNo:   trade.TradeNo,
+			})
+		case TradeClosed:
+			return expire(ctx, record)
+		}
+	}
+
+	if time.Since(record.UpdatedAt) < ExpireAfter() {
+		return nil
+	}
+	// 先关闭渠道交易，避免记录关闭后用户仍能完成支付；关闭时已支付的交易下一轮按成功处理
+	if err := DefaultProvider.Close(ctx, record.PaymentID); err != nil {
+		if errors.Is(err, ErrTradeFinished) {
+			return nil
+		}
+		return err
+	}
+	return expire(ctx, record)
+}
+
+func expire(ctx context.Context, record *dal.PaymentRecord) error {
+	closed, err := Expire(ctx, record)
+	if err != nil {
+		return err
+	}
+	if closed {
+		zap.L().Info("待支付记录已超时关闭",
+			zap.String("order_id", record.OrderID),
+			zap.String("payment_id", record.PaymentID))
+	}
+	return nil
+}
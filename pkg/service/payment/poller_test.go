@@ -0,0 +1,113 @@
+package payment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+func TestPollPending(t *testing.T) {
+	tests := []struct {
+		name        string
+		outcome     string        // 渠道侧的交易结果，空值表示仍待支付
+		age         time.Duration // 距最近一次发起支付的时间
+		orderStatus dal.OrderStatus
+		wantNotify  string // 空值表示不回调处理函数
+		wantStatus  string
+		wantTrade   string
+	}{
+		{"渠道支付成功", MockOutcomeSuccess, 10 * time.Minute, dal.OrderStatusUnpaid, NotifySuccess, StatusPending, TradeSuccess},
+		{"渠道支付失败", MockOutcomeFail, 10 * time.Minute, dal.OrderStatusUnpaid, NotifyFailed, StatusPending, TradeFailed},
+		{"渠道已关闭", MockOutcomeTimeout, 10 * time.Minute, dal.OrderStatusUnpaid, "", StatusFailed, TradeClosed},
+		{"未到查询时间", MockOutcomeSuccess, time.Minute, dal.OrderStatusUnpaid, "", StatusPending, TradeSuccess},
+		{"未到关闭时间", "", 10 * time.Minute, dal.OrderStatusUnpaid, "", StatusPending, TradePending},
+		{"超时关闭", "", time.Hour, dal.OrderStatusUnpaid, "", StatusFailed, TradeClosed},
+		{"订单已取消", "", time.Hour, dal.OrderStatusCanceled, "", StatusCanceled, TradeClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := setupStore(t)
+			testutil.Config(t, nil)
+			ctx := context.Background()
+
+			amount := money.FromCents(1999)
+			dal.DB.Create(&dal.Order{UserID: 7, OrderNo: "T7001", Amount: amount, Items: "{}", Status: tt.orderStatus})
+			record := &dal.PaymentRecord{OrderID: "T7001", PaymentID: "pay-7001", Amount: amount, Status: StatusPending, UserID: 7}
+			dal.DB.Create(record)
+			dal.DB.Model(record).UpdateColumn("updated_at", time.Now().Add(-tt.age))
+			if _, err := provider.Pay(ctx, record); err != nil {
+				t.Fatalf("Pay() 错误 = %v", err)
+			}
+			if tt.outcome != "" {
+				if _, err := provider.Confirm(ctx, record.PaymentID, tt.outcome); err != nil {
+					t.Fatalf("Confirm() 错误 = %v", err)
+				}
+			}
+
+			var handled []*Notification
+			handle := func(ctx context.Context, n *Notification) error {
+				handled = append(handled, n)
+				return nil
+			}
+			pollPending(ctx, handle)
+
+			if tt.wantNotify == "" {
+				if len(handled) != 0 {
+					t.Errorf("处理的通知 = %+v，期望不处理", handled[0])
+				}
+			} else if len(handled) != 1 || handled[0].Status != tt.wantNotify ||
+				handled[0].PaymentID != record.PaymentID || !handled[0].Amount.Equal(amount) {
+				t.Errorf("处理的通知 = %v，期望一条 %s 结果", handled, tt.wantNotify)
+			}
+			var saved dal.PaymentRecord
+			dal.DB.Where("order_id = ?", "T7001").First(&saved)
+			if saved.Status != tt.wantStatus {
+				t.Errorf("支付记录状态 = %s，期望 %s", saved.Status, tt.wantStatus)
+			}
+			trade, err := provider.Query(ctx, record.PaymentID)
+			if err != nil {
+				t.Fatalf("Query() 错误 = %v", err)
+			}
+			if trade.Status != tt.wantTrade {
+				t.Errorf("渠道交易状态 = %s，期望 %s", trade.Status, tt.wantTrade)
+			}
+		})
+	}
+}
+
+func TestPollPendingRunsOncePerInterval(t *testing.T) {
+	provider := setupStore(t)
+	testutil.Config(t, nil)
+	ctx := context.Background()
+
+	amount := money.FromCents(1999)
+	dal.DB.Create(&dal.Order{UserID: 7, OrderNo: "T7002", Amount: amount, Items: "{}", Status: dal.OrderStatusUnpaid})
+	record := &dal.PaymentRecord{OrderID: "T7002", PaymentID: "pay-7002", Amount: amount, Status: StatusPending, UserID: 7}
+	dal.DB.Create(record)
+	dal.DB.Model(record).UpdateColumn("updated_at", time.Now().Add(-10*time.Minute))
+	provider.Pay(ctx, record)
+	provider.Confirm(ctx, record.PaymentID, MockOutcomeSuccess)
+
+	calls := 0
+	handle := func(ctx context.Context, n *Notification) error {
+		calls++
+		return nil
+	}
+	// 同一轮内其他副本（或重复触发）抢不到锁，不会重复处理
+	pollPending(ctx, handle)
+	pollPending(ctx, handle)
+	if calls != 1 {
+		t.Errorf("处理次数 = %d，期望 1", calls)
+	}
+
+	redis.Client.Del(ctx, pollLockKey)
+	pollPending(ctx, handle)
+	if calls != 2 {
+		t.Errorf("锁释放后处理次数 = %d，期望 2", calls)
+	}
+}
@@ -0,0 +1,91 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+)
+
+// 支付渠道通知中的支付结果
+const (
+	NotifySuccess = "success"
+	NotifyFailed  = "failed"
+)
+
+// 渠道侧交易状态
+const (
+	TradePending = "pending"
+	TradeSuccess = "success"
+	TradeFailed  = "failed"
+	TradeClosed  = "closed" // 超时未支付，渠道已关闭
+)
+
+var (
+	ErrInvalidSignature = errors.New("回调签名校验失败")
+	ErrTradeNotFound    = errors.New("渠道交易不存在")
+	ErrTradeFinished    = errors.New("渠道交易已完成，不能关闭")
+)
+
+// Notification 支付渠道异步通知的内容
+type Notification struct {
+	PaymentID string      `json:"payment_id"`
+	OrderNo   string      `json:"order_no"`
+	Status    string      `json:"status"` // success / failed
+	Amount    money.Money `json:"amount"`
+	TradeNo   string      `json:"trade_no"` // 渠道交易号
+	Nonce     string      `json:"-"`        // 请求头中的nonce，用于防重放
+}
+
+// Trade 渠道侧的交易信息，用于主动查询与对账
+type Trade struct {
+	PaymentID string
+	OrderNo   string
+	Status    string
+	Amount    money.Money
+	Refunded  money.Money // 已退款合计
+	TradeNo   string
+}
+
+// Provider 支付渠道
+type Provider interface {
+	// Name 渠道名称
+	Name() string
+	// Pay 在渠道侧登记待支付交易，返回用户跳转的支付链接，重复调用返回同一链接
+	Pay(ctx context.Context, record *dal.PaymentRecord) (string, error)
+	// ParseNotification 校验异步通知的签名与时间戳并解析内容，header用于读取请求头
+	ParseNotification(header func(key string) string, body []byte) (*Notification, error)
+	// Refund 发起退款，渠道按退款单号去重，重复提交返回同一结果；
+	// 返回错误表示结果未知，退款保持处理中
+	Refund(ctx context.Context, refund *dal.RefundRecord) (*RefundResult, error)
+	// Query 按支付单号查询渠道交易，交易不存在时返回 ErrTradeNotFound
+	Query(ctx context.Context, paymentID string) (*Trade, error)
+	// Close 关闭未支付的交易，交易已关闭或不存在时不做处理；
+	// 交易已支付成功或失败时返回 ErrTradeFinished
+	Close(ctx context.Context, paymentID string) error
+}
+
+// DefaultProvider 全局支付渠道，由 InitProvider 按配置创建
+var DefaultProvider Provider
+
+// InitProvider 按配置初始化支付渠道（需先初始化配置与Redis）
+func InitProvider() error {
+	conf := config.Conf.Payment
+	switch conf.Provider {
+	case "", "mock":
+		if conf.CallbackSecret == "" {
+			return fmt.Errorf("支付回调签名密钥必须配置")
+		}
+		provider, err := NewMockProvider(conf.GatewayURL, conf.CallbackURL, conf.CallbackSecret)
+		if err != nil {
+			return err
+		}
+		DefaultProvider = provider
+	default:
+		return fmt.Errorf("不支持的支付渠道: %s", conf.Provider)
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 退款记录状态
+const (
+	RefundStatusPending = "pending" // 已登记，等待渠道处理结果
+	RefundStatusSuccess = "success"
+	RefundStatusFailed  = "failed"
+)
+
+// 退款相关错误定义
+var (
+	ErrRefundAmountInvalid  = errors.New("退款金额无效")
+	ErrRefundExceeded       = errors.New("退款金额超过可退金额")
+	ErrPaymentNotRefundable = errors.New("支付记录当前状态不可退款")
+	ErrRefundNotFound       = errors.New("退款记录不存在")
+)
+
+// 可以发起退款的支付记录状态
+var refundableStatuses = map[string]bool{
+	StatusSuccess:       true,
+	StatusRefundPending: true,
+	StatusPartRefunded:  true,
+}
+
+// RefundResult 渠道退款结果
+type RefundResult struct {
+	Status  string // success / failed / pending（渠道处理中）
+	TradeNo string // 渠道退款单号
+	Reason  string // 失败原因
+}
+
+// Refund 对订单的成功支付发起退款，amount为nil时退还全部可退金额
+// 先在事务中锁定支付记录并登记退款（处理中的退款同样占用可退金额，防止并发超退），
+// 再调用渠道退款并按结果完成退款记录。渠道调用出错时退款保持pending并返回错误，
+// 可通过 RetryRefund 使用同一退款单号重新提交，渠道侧按退款单号去重。
+func Refund(ctx context.Context, orderNo string, amount *money.Money, reason string) (*dal.RefundRecord, error) {
+	refund, err := createRefund(ctx, orderNo, amount, reason)
+	if err != nil {
+		return nil, err
+	}
+	return submitRefund(ctx, refund)
+}
+
+// RetryRefund 重新提交处理中的退款，退款已完成时直接返回当前记录
+func RetryRefund(ctx context.Context, refundID string) (*dal.RefundRecord, error) {
+	var refund dal.RefundRecord
+	if err := dal.DB.WithContext(ctx).Where("refund_id = ?", refundID).First(&refund).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrRefundNotFound
+		}
+		return nil, fmt.Errorf("退款记录查询失败: %w", err)
+	}
+	if refund.Status != RefundStatusPending {
+		return &refund, nil
+	}
+	return submitRefund(ctx, &refund)
+}
+
+// ListRefunds 查询订单的退款记录，按创建时间排序
+func ListRefunds(ctx context.Context, orderNo string) ([]dal.RefundRecord, error) {
+	var refunds []dal.RefundRecord
+	if err := dal.DB.WithContext(ctx).Where("order_id = ?", orderNo).Order("id").Find(&refunds).Error; err != nil {
+		return nil, fmt.Errorf("退款记录查询失败: %w", err)
+	}
+	return refunds, nil
+}
+
+// createRefund 校验可退金额并登记退款，全额退款时写入 RefundRequested 事件
+func createRefund(ctx context.Context, orderNo string, amount *money.Money, reason string) (*dal.RefundRecord, error) {
+	var refund *dal.RefundRecord
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var payment dal.PaymentRecord
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ?", orderNo).
+			First(&payment).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrPaymentNotFound
+			}
+			return fmt.Errorf("支付记录查询失败: %w", err)
+		}
+		if !refundableStatuses[payment.Status] {
+			return fmt.Errorf("%w: %s", ErrPaymentNotRefundable, payment.Status)
+		}
+
+		used, err := refundedTotal(tx, payment.PaymentID, payment.Amount.Currency,
+			RefundStatusPending, RefundStatusSuccess)
+		if err != nil {
+			return err
+		}
+		remaining, err := payment.Amount.Sub(used)
+		if err != nil {
+			return err
+		}
+
+		refundAmount := remaining
+		if amount != nil {
+			refundAmount = *amount
+			if refundAmount.Currency == "" {
+				refundAmount.Currency = payment.Amount.Currency
+			}
+		}
+		if refundAmount.Cents <= 0 || refundAmount.Currency != payment.Amount.Currency {
+			return fmt.Errorf("%w: %s", ErrRefundAmountInvalid, refundAmount)
+		}
+		if refundAmount.Cents > remaining.Cents {
+			return fmt.Errorf("%w: 申请 %s，可退 %s", ErrRefundExceeded, refundAmount, remaining)
+		}
+
+		refund = &dal.RefundRecord{
+			RefundID:  uuid.New().String(),
+			PaymentID: payment.PaymentID,
+			OrderID:   orderNo,
+			Amount:    refundAmount,
+			Status:    RefundStatusPending,
+			Reason:    reason,
+			UserID:    payment.UserID,
+		}
+		if err := tx.Create(refund).Error; err != nil {
+			return fmt.Errorf("退款记录创建失败: %w", err)
+		}
+
+		// 部分退款不改变订单状态，全额退款时订单进入退款中
+		if !refundAmount.Equal(remaining) {
+			return nil
+		}
+		return event.Add(tx, event.RefundRequested, orderNo, newRefundEvent(refund, true))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// submitRefund 调用渠道退款并记录结果
+func submitRefund(ctx context.Context, refund *dal.RefundRecord) (*dal.RefundRecord, error) {
+	result, err := DefaultProvider.Refund(ctx, refund)
+	if err != nil {
+		zap.L().Error("渠道退款调用失败",
+			zap.String("refund_id", refund.RefundID),
+			zap.String("order_id", refund.OrderID),
+			zap.Error(err))
+		return refund, fmt.Errorf("渠道退款调用失败: %w", err)
+	}
+
+	switch result.Status {
+	case RefundStatusSuccess:
+		return completeRefund(ctx, refund.RefundID, result.TradeNo)
+	case RefundStatusFailed:
+		return failRefund(ctx, refund.RefundID, result.Reason)
+	default:
+		return refund, nil
+	}
+}
+
+// completeRefund 退款成功：更新退款记录与支付记录状态，并写入 RefundSucceeded 事件
+// 重复调用时退款记录已不是处理中状态，直接返回当前记录。
+func completeRefund(ctx context.Context, refundID, tradeNo string) (*dal.RefundRecord, error) {
+	var refund dal.RefundRecord
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		payment, err := lockRefund(tx, refundID, &refund)
+		if err != nil || refund.Status != RefundStatusPending {
+			return err
+		}
+
+		if err := tx.Model(&refund).Updates(map[string]interface{}{
+			"status":   RefundStatusSuccess,
+			"trade_no": tradeNo,
+		}).Error; err != nil {
+			return fmt.Errorf("退款记录更新失败: %w", err)
+		}
+
+		refunded, err := refundedTotal(tx, payment.PaymentID, payment.Amount.Currency, RefundStatusSuccess)
+		if err != nil {
+			return err
+		}
+		full := refunded.Cents >= payment.Amount.Cents
+		status := StatusPartRefunded
+		if full {
+			status = StatusRefunded
+		}
+		if err := tx.Model(payment).Update("status", status).Error; err != nil {
+			return fmt.Errorf("支付记录更新失败: %w", err)
+		}
+		return event.Add(tx, event.RefundSucceeded, refund.OrderID, newRefundEvent(&refund, full))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zap.L().Info("退款成功",
+		zap.String("refund_id", refund.RefundID),
+		zap.String("order_id", refund.OrderID),
+		zap.Stringer("amount", refund.Amount))
+	return &refund, nil
+}
+
+// failRefund 退款失败：释放占用的可退金额，支付记录状态不变
+func failRefund(ctx context.Context, refundID, reason string) (*dal.RefundRecord, error) {
+	var refund dal.RefundRecord
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockRefund(tx, refundID, &refund); err != nil || refund.Status != RefundStatusPending {
+			return err
+		}
+		errMsg := []rune(reason)
+		if len(errMsg) > 512 {
+			errMsg = errMsg[:512]
+		}
+		return tx.Model(&refund).Updates(map[string]interface{}{
+			"status":     RefundStatusFailed,
+			"last_error": string(errMsg),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zap.L().Warn("退款失败",
+		zap.String("refund_id", refund.RefundID),
+		zap.String("order_id", refund.OrderID),
+		zap.String("reason", reason))
+	return &refund, nil
+}
+
+// lockRefund 按支付记录、退款记录的顺序加锁（与登记退款时一致，避免死锁）
+func lockRefund(tx *gorm.DB, refundID string, refund *dal.RefundRecord) (*dal.PaymentRecord, error) {
+	if err := tx.Where("refund_id = ?", refundID).First(refund).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrRefundNotFound
+		}
+		return nil, fmt.Errorf("退款记录查询失败: %w", err)
+	}
+
+	var payment dal.PaymentRecord
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ?", refund.OrderID).
+		First(&payment).Error; err != nil {
+		return nil, fmt.Errorf("支付记录查询失败: %w", err)
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(refund, refund.ID).Error; err != nil {
+		return nil, fmt.Errorf("退款记录查询失败: %w", err)
+	}
+	return &payment, nil
+}
+
+// refundedTotal 统计支付单指定状态的退款合计
+func refundedTotal(tx *gorm.DB, paymentID, currency string, statuses ...string) (money.Money, error) {
+	var cents int64
+	if err := tx.Model(&dal.RefundRecord{}).
+		Select("COALESCE(SUM(amount_cents), 0)").
+		Where("payment_id = ? AND status IN ?", paymentID, statuses).
+		Scan(&cents).Error; err != nil {
+		return money.Money{}, fmt.Errorf("退款金额统计失败: %w", err)
+	}
+	return money.New(cents, currency), nil
+}
+
+func newRefundEvent(refund *dal.RefundRecord, full bool) event.RefundEvent {
+	return event.RefundEvent{
+		RefundID:  refund.RefundID,
+		PaymentID: refund.PaymentID,
+		OrderNo:   refund.OrderID,
+		UserID:    refund.UserID,
+		Amount:    refund.Amount,
+		Full:      full,
+		Reason:    refund.Reason,
+	}
+}
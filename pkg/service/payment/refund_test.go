@@ -0,0 +1,64 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/event"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+)
+
+func TestCreateRefundLimits(t *testing.T) {
+	setupStore(t)
+	ctx := context.Background()
+
+	paid := &dal.PaymentRecord{OrderID: "T5001", PaymentID: "pay-5001", Amount: money.FromCents(1000), Status: StatusSuccess}
+	unpaid := &dal.PaymentRecord{OrderID: "T5002", PaymentID: "pay-5002", Amount: money.FromCents(1000), Status: StatusPending}
+	dal.DB.Create(paid)
+	dal.DB.Create(unpaid)
+	// 处理中的退款占用可退金额，失败的退款不占用
+	dal.DB.Create(&dal.RefundRecord{RefundID: "r-pending", PaymentID: paid.PaymentID, OrderID: paid.OrderID,
+		Amount: money.FromCents(200), Status: RefundStatusPending})
+	dal.DB.Create(&dal.RefundRecord{RefundID: "r-failed", PaymentID: paid.PaymentID, OrderID: paid.OrderID,
+		Amount: money.FromCents(300), Status: RefundStatusFailed})
+
+	amount := func(m money.Money) *money.Money { return &m }
+	tests := []struct {
+		name    string
+		orderNo string
+		amount  *money.Money
+		want    error
+		wantAmt money.Money
+	}{
+		{"超过可退金额", paid.OrderID, amount(money.FromCents(801)), ErrRefundExceeded, money.Money{}},
+		{"金额为零", paid.OrderID, amount(money.FromCents(0)), ErrRefundAmountInvalid, money.Money{}},
+		{"金额为负", paid.OrderID, amount(money.FromCents(-1)), ErrRefundAmountInvalid, money.Money{}},
+		{"币种不一致", paid.OrderID, amount(money.New(100, "USD")), ErrRefundAmountInvalid, money.Money{}},
+		{"缺省币种", paid.OrderID, &money.Money{Cents: 500}, nil, money.FromCents(500)},
+		{"剩余金额不足", paid.OrderID, amount(money.FromCents(301)), ErrRefundExceeded, money.Money{}},
+		{"退还剩余金额", paid.OrderID, nil, nil, money.FromCents(300)},
+		{"已无可退金额", paid.OrderID, nil, ErrRefundAmountInvalid, money.Money{}},
+		{"支付未成功", unpaid.OrderID, nil, ErrPaymentNotRefundable, money.Money{}},
+		{"支付记录不存在", "missing", nil, ErrPaymentNotFound, money.Money{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refund, err := createRefund(ctx, tt.orderNo, tt.amount, "测试")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("createRefund() 错误 = %v，期望 %v", err, tt.want)
+			}
+			if err == nil && !refund.Amount.Equal(tt.wantAmt) {
+				t.Errorf("退款金额 = %v，期望 %v", refund.Amount, tt.wantAmt)
+			}
+		})
+	}
+
+	// 只有退还全部剩余金额的退款使订单进入退款中
+	var count int64
+	dal.DB.Model(&dal.OutboxEvent{}).Where("type = ?", event.RefundRequested).Count(&count)
+	if count != 1 {
+		t.Errorf("RefundRequested事件数 = %d，期望 1", count)
+	}
+}
@@ -0,0 +1,155 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	goredis "github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	detailCacheKeyPrefix = "product:detail:"
+	cacheNullValue       = "null" // 空值缓存，标记商品不存在
+	cacheJitterRatio     = 0.1
+	// 写入提交后立即删除缓存，延迟后再删除一次，
+	// 清掉删除前已读到旧数据、删除后才回填的并发请求写入的缓存
+	invalidateDelay = time.Second
+)
+
+// Detail 商品详情（商品及其SKU），商品详情页与GetProduct RPC共用
+type Detail struct {
+	Product dal.Product `json:"product"`
+	SKUs    []dal.SKU   `json:"skus"`
+}
+
+// 同一商品并发未命中时只有一个请求查询数据库
+var detailLoader singleflight.Group
+
+// CacheTTL 商品详情缓存时间，默认10分钟
+func CacheTTL() time.Duration {
+	if s := config.Conf.Product.CacheTTLSeconds; s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return 10 * time.Minute
+}
+
+// CacheNullTTL 不存在的商品的空值缓存时间，默认1分钟
+func CacheNullTTL() time.Duration {
+	if s := config.Conf.Product.CacheNullTTLSeconds; s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return time.Minute
+}
+
+// GetDetail 查询商品详情，先读缓存，未命中时查询数据库并回填
+// 不存在的商品缓存空值，避免不存在的ID反复穿透到数据库；Redis不可用时直接查询数据库。
+func GetDetail(ctx context.Context, productID uint) (*Detail, error) {
+	key := detailCacheKey(productID)
+	if d, hit, err := cachedDetail(ctx, key); hit {
+		return d, err
+	}
+
+	// 合并后的查询不随首个请求取消，避免其超时导致等待中的请求全部失败
+	v, err, _ := detailLoader.Do(key, func() (interface{}, error) {
+		return loadDetail(context.WithoutCancel(ctx), key, productID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 合并的请求共享同一结果，各自返回副本，避免调用方修改时互相影响
+	return v.(*Detail).clone(), nil
+}
+
+func (d *Detail) clone() *Detail {
+	c := *d
+	c.SKUs = append([]dal.SKU(nil), d.SKUs...)
+	return &c
+}
+
+// InvalidateDetail 删除商品详情缓存，在商品或SKU的写入事务提交后调用
+func InvalidateDetail(ctx context.Context, productID uint) {
+	key := detailCacheKey(productID)
+	client := redis.Client
+	if err := client.Del(ctx, key).Err(); err != nil {
+		zap.L().Warn("商品缓存删除失败", zap.Uint("product_id", productID), zap.Error(err))
+	}
+	time.AfterFunc(invalidateDelay, func() {
+		if err := client.Del(context.Background(), key).Err(); err != nil {
+			zap.L().Warn("商品缓存延迟删除失败", zap.Uint("product_id", productID), zap.Error(err))
+		}
+	})
+}
+
+// cachedDetail 读取缓存，hit为false表示需要查询数据库
+func cachedDetail(ctx context.Context, key string) (*Detail, bool, error) {
+	data, err := redis.Client.Get(ctx, key).Result()
+	if err != nil {
+		if err != goredis.Nil {
+			zap.L().Warn("商品缓存读取失败", zap.String("key", key), zap.Error(err))
+		}
+		return nil, false, nil
+	}
+	if data == cacheNullValue {
+		return nil, true, ErrProductNotFound
+	}
+
+	var d Detail
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		// 缓存结构变化或内容损坏，按未命中处理，回填时覆盖
+		zap.L().Warn("商品缓存解析失败", zap.String("key", key), zap.Error(err))
+		return nil, false, nil
+	}
+	return &d, true, nil
+}
+
+func loadDetail(ctx context.Context, key string, productID uint) (*Detail, error) {
+	// 等待合并期间其他副本可能已经回填
+	if d, hit, err := cachedDetail(ctx, key); hit {
+		return d, err
+	}
+
+	p, err := GetProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, ErrProductNotFound) {
+			if err := redis.Client.Set(ctx, key, cacheNullValue, CacheNullTTL()).Err(); err != nil {
+				zap.L().Warn("商品空值缓存写入失败", zap.Uint("product_id", productID), zap.Error(err))
+			}
+		}
+		return nil, err
+	}
+	d := &Detail{Product: *p}
+	if err := dal.DB.WithContext(ctx).Where("product_id = ?", productID).Order("id").Find(&d.SKUs).Error; err != nil {
+		return nil, fmt.Errorf("SKU查询失败: %w", err)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("商品缓存序列化失败: %w", err)
+	}
+	if err := redis.Client.Set(ctx, key, data, jitter(CacheTTL())).Err(); err != nil {
+		zap.L().Warn("商品缓存写入失败", zap.Uint("product_id", productID), zap.Error(err))
+	}
+	return d, nil
+}
+
+func detailCacheKey(productID uint) string {
+	return fmt.Sprintf("%s%d", detailCacheKeyPrefix, productID)
+}
+
+// jitter 过期时间随机浮动，避免同时写入的缓存集中失效
+func jitter(ttl time.Duration) time.Duration {
+	delta := int64(float64(ttl) * cacheJitterRatio)
+	if delta <= 0 {
+		return ttl
+	}
+	return ttl + time.Duration(rand.Int63n(2*delta+1)-delta)
+}
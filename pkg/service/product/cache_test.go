@@ -0,0 +1,178 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	"gorm.io/gorm"
+)
+
+// countProductQueries 统计对商品表的查询次数，delay用于拉长查询耗时以制造并发未命中
+func countProductQueries(t *testing.T, delay time.Duration) *int32 {
+	t.Helper()
+	var count int32
+	err := dal.DB.Callback().Query().Before("gorm:query").Register("test:count_products", func(db *gorm.DB) {
+		if db.Statement.Table == "products" {
+			atomic.AddInt32(&count, 1)
+			time.Sleep(delay)
+		}
+	})
+	if err != nil {
+		t.Fatalf("注册查询回调失败: %v", err)
+	}
+	return &count
+}
+
+func TestGetDetailCacheHit(t *testing.T) {
+	setupCatalog(t)
+	ctx := context.Background()
+	p := createOwned(t, merchantA)
+	queries := countProductQueries(t, 0)
+
+	d, err := GetDetail(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("GetDetail() 错误 = %v", err)
+	}
+	if d.Product.Name != p.Name || len(d.SKUs) != 1 {
+		t.Errorf("商品详情 = %+v，期望名称 %s 与一个SKU", d, p.Name)
+	}
+	if ttl := redis.Client.TTL(ctx, detailCacheKey(p.ID)).Val(); ttl < 9*time.Minute || ttl > 11*time.Minute {
+		t.Errorf("缓存过期时间 = %v，期望默认10分钟上下浮动", ttl)
+	}
+
+	// 绕过商品管理直接修改数据库，缓存未失效时仍返回缓存内容
+	dal.DB.Model(&dal.Product{}).Where("id = ?", p.ID).Update("name", "改名商品")
+	if d, err := GetDetail(ctx, p.ID); err != nil || d.Product.Name != p.Name {
+		t.Errorf("GetDetail() = %+v, %v，期望命中缓存返回原名称", d, err)
+	}
+	if n := atomic.LoadInt32(queries); n != 1 {
+		t.Errorf("商品查询次数 = %d，期望 1", n)
+	}
+}
+
+func TestGetDetailInvalidatedByUpdate(t *testing.T) {
+	setupCatalog(t)
+	ctx := context.Background()
+	p := createOwned(t, merchantA)
+
+	if _, err := GetDetail(ctx, p.ID); err != nil {
+		t.Fatalf("GetDetail() 错误 = %v", err)
+	}
+	name := "新名称"
+	if _, err := Update(ctx, merchantA, p.ID, UpdateInput{Name: &name}); err != nil {
+		t.Fatalf("Update() 错误 = %v", err)
+	}
+	if d, err := GetDetail(ctx, p.ID); err != nil || d.Product.Name != name {
+		t.Errorf("GetDetail() = %+v, %v，期望更新后返回新名称", d, err)
+	}
+
+	if err := Delete(ctx, merchantA, p.ID); err != nil {
+		t.Fatalf("Delete() 错误 = %v", err)
+	}
+	if _, err := GetDetail(ctx, p.ID); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("删除后 GetDetail() 错误 = %v，期望 %v", err, ErrProductNotFound)
+	}
+}
+
+func TestGetDetailNullCache(t *testing.T) {
+	setupCatalog(t)
+	ctx := context.Background()
+	queries := countProductQueries(t, 0)
+
+	// 商品尚未创建，ID 1 不存在
+	for i := 0; i < 3; i++ {
+		if _, err := GetDetail(ctx, 1); !errors.Is(err, ErrProductNotFound) {
+			t.Fatalf("GetDetail() 错误 = %v，期望 %v", err, ErrProductNotFound)
+		}
+	}
+	if n := atomic.LoadInt32(queries); n != 1 {
+		t.Errorf("商品查询次数 = %d，期望空值缓存后不再查询数据库", n)
+	}
+	if v := redis.Client.Get(ctx, detailCacheKey(1)).Val(); v != cacheNullValue {
+		t.Errorf("缓存内容 = %q，期望空值标记", v)
+	}
+	if ttl := redis.Client.TTL(ctx, detailCacheKey(1)).Val(); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("空值缓存过期时间 = %v，期望不超过1分钟", ttl)
+	}
+
+	// 创建商品时清除该ID可能存在的空值缓存
+	p := createOwned(t, merchantA)
+	if _, err := GetDetail(ctx, p.ID); err != nil {
+		t.Errorf("创建后 GetDetail() 错误 = %v", err)
+	}
+}
+
+func TestGetDetailSingleflight(t *testing.T) {
+	setupCatalog(t)
+	ctx := context.Background()
+	p := createOwned(t, merchantA)
+	queries := countProductQueries(t, 100*time.Millisecond)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make(chan error, callers)
+	results := make([]*Detail, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			d, err := GetDetail(ctx, p.ID)
+			if err != nil {
+				errs <- err
+				return
+			}
+			results[i] = d
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("GetDetail() 错误 = %v", err)
+	}
+	if n := atomic.LoadInt32(queries); n != 1 {
+		t.Errorf("商品查询次数 = %d，期望并发未命中合并为 1 次", n)
+	}
+
+	// 合并的请求各自拿到副本，修改一个结果不影响其他调用方
+	results[0].Product.Name = "被调用方修改"
+	results[0].SKUs[0].Stock = -1
+	for i, d := range results[1:] {
+		if d.Product.Name != p.Name || d.SKUs[0].Stock != p.Stock {
+			t.Errorf("第%d个结果 = %s/%d，期望不受其他调用方修改影响", i+1, d.Product.Name, d.SKUs[0].Stock)
+		}
+	}
+}
+
+func TestJitter(t *testing.T) {
+	tests := []struct {
+		ttl      time.Duration
+		min, max time.Duration
+	}{
+		{10 * time.Minute, 9 * time.Minute, 11 * time.Minute},
+		{time.Minute, 54 * time.Second, 66 * time.Second},
+		{5 * time.Nanosecond, 5 * time.Nanosecond, 5 * time.Nanosecond}, // 浮动不足1纳秒时不浮动
+	}
+	for _, tt := range tests {
+		seen := map[time.Duration]bool{}
+		for i := 0; i < 1000; i++ {
+			got := jitter(tt.ttl)
+			if got < tt.min || got > tt.max {
+				t.Fatalf("jitter(%v) = %v，期望在 [%v, %v] 之间", tt.ttl, got, tt.min, tt.max)
+			}
+			seen[got] = true
+		}
+		if tt.min != tt.max && len(seen) < 2 {
+			t.Errorf("jitter(%v) 没有随机浮动", tt.ttl)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 商品管理相关错误定义
+var (
+	ErrForbidden = errors.New("无权操作该商品")
+	ErrNoChanges = errors.New("没有需要修改的字段")
+)
+
+// 审计记录中的操作类型
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+const (
+	maxNameLen        = 100
+	maxDescriptionLen = 5000
+)
+
+// Operator 商品管理的操作人
+type Operator struct {
+	UserID uint
+	Role   string
+}
+
+// CreateInput 创建商品参数
+// SKUs为空时按Price、Stock创建一个单规格SKU，否则商品的价格与库存由SKU汇总得出。
+type CreateInput struct {
+	Name        string
+	Description string
+	Price       money.Money
+	Stock       int
+	Status      *int // 为空时默认上架
+	SKUs        []SKUInput
+}
+
+// UpdateInput 部分更新参数，为空的字段保持不变
+// Price、Stock只能用于单SKU商品，多SKU商品需修改各SKU。
+type UpdateInput struct {
+	Name        *string
+	Description *string
+	Price       *money.Money
+	Stock       *int
+	Status      *int
+}
+
+// fieldChange 审计记录中单个字段的变更
+type fieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// Create 创建商品并记录审计，商家创建的商品归属该商家
+func Create(ctx context.Context, op Operator, in CreateInput) (*dal.Product, error) {
+	in.Name = strings.TrimSpace(in.Name)
+	skus := in.SKUs
+	if len(skus) == 0 {
+		skus = []SKUInput{{Price: in.Price, Stock: in.Stock}}
+	}
+	if len(skus) > maxSKUsPerProduct {
+		return nil, fmt.Errorf("%w: 每个商品最多%d个SKU", ErrInvalidParams, maxSKUsPerProduct)
+	}
+	// 商品价格与库存先按SKU估算用于校验，写入SKU后由refreshAggregate准确计算
+	in.Price, in.Stock = skus[0].Price, 0
+	for i := range skus {
+		if skus[i].Price.Currency == "" {
+			skus[i].Price.Currency = money.DefaultCurrency
+		}
+		if skus[i].Price.Cents < in.Price.Cents {
+			in.Price = skus[i].Price
+		}
+		in.Stock += skus[i].Stock
+	}
+	if in.Price.Currency == "" {
+		in.Price.Currency = money.DefaultCurrency
+	}
+	status := dal.ProductStatusOnShelf
+	if in.Status != nil {
+		status = *in.Status
+	}
+	if err := validate(in.Name, in.Description, in.Price, in.Stock, status); err != nil {
+		return nil, err
+	}
+
+	p := &dal.Product{
+		Name:        in.Name,
+		Description: in.Description,
+		Price:       in.Price,
+		Stock:       in.Stock,
+		Status:      status,
+	}
+	if op.Role == dal.RoleMerchant {
+		p.MerchantID = op.UserID
+	}
+
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(p).Error; err != nil {
+			return fmt.Errorf("商品创建失败: %w", err)
+		}
+		// Status为零值时Create会使用列默认值（上架），需要单独更新
+		if status == dal.ProductStatusOffShelf {
+			if err := tx.Model(p).Update("status", status).Error; err != nil {
+				return fmt.Errorf("商品创建失败: %w", err)
+			}
+		}
+		created := make([]map[string]interface{}, 0, len(skus))
+		for _, in := range skus {
+			sku, err := insertSKU(tx, p, in)
+			if err != nil {
+				return err
+			}
+			created = append(created, skuSnapshot(sku))
+		}
+		if err := refreshAggregate(tx, p); err != nil {
+			return err
+		}
+		return audit(tx, p.ID, op, AuditCreate, map[string]fieldChange{
+			"name":        {New: p.Name},
+			"description": {New: p.Description},
+			"price":       {New: p.Price},
+			"stock":       {New: p.Stock},
+			"status":      {New: p.Status},
+			"skus":        {New: created},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 清除创建前可能写入的空值缓存
+	InvalidateDetail(ctx, p.ID)
+	reindex(ctx, p)
+	zap.L().Info("商品已创建", zap.Uint("product_id", p.ID), zap.Uint("operator", op.UserID))
+	return p, nil
+}
+
+// Update 部分更新商品，只记录实际发生变化的字段
+// 在事务中锁定商品行，库存修改与并发扣减互斥。
+func Update(ctx context.Context, op Operator, productID uint, in UpdateInput) (*dal.Product, error) {
+	var p dal.Product
+	var textChanged bool
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockOwned(tx, op, productID, &p); err != nil {
+			return err
+		}
+
+		updated := p
+		if in.Name != nil {
+			updated.Name = strings.TrimSpace(*in.Name)
+		}
+		if in.Description != nil {
+			updated.Description = *in.Description
+		}
+		if in.Price != nil {
+			updated.Price = *in.Price
+			if updated.Price.Currency == "" {
+				updated.Price.Currency = p.Price.Currency
+			}
+		}
+		if in.Stock != nil {
+			updated.Stock = *in.Stock
+		}
+		if in.Status != nil {
+			updated.Status = *in.Status
+		}
+		if err := validate(updated.Name, updated.Description, updated.Price, updated.Stock, updated.Status); err != nil {
+			return err
+		}
+
+		columns := map[string]interface{}{}
+		changes := map[string]fieldChange{}
+		diff := func(column string, before, after interface{}) {
+			if before != after {
+				columns[column] = after
+				changes[column] = fieldChange{Old: before, New: after}
+			}
+		}
+		diff("name", p.Name, updated.Name)
+		diff("description", p.Description, updated.Description)
+		diff("stock", p.Stock, updated.Stock)
+		diff("status", p.Status, updated.Status)
+		if !p.Price.Equal(updated.Price) {
+			columns["price_cents"] = updated.Price.Cents
+			columns["price_currency"] = updated.Price.Currency
+			changes["price"] = fieldChange{Old: p.Price, New: updated.Price}
+		}
+		if len(columns) == 0 {
+			return ErrNoChanges
+		}
+
+		// 价格与库存实际维护在SKU上，单SKU商品同步修改该SKU
+		_, priceChanged := changes["price"]
+		_, stockChanged := changes["stock"]
+		if priceChanged || stockChanged {
+			var skus []dal.SKU
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("product_id = ?", productID).
+				Find(&skus).Error; err != nil {
+				return fmt.Errorf("SKU查询失败: %w", err)
+			}
+			if len(skus) != 1 {
+				return fmt.Errorf("%w: 多SKU商品请分别修改各SKU的价格与库存", ErrInvalidParams)
+			}
+			if err := tx.Model(&skus[0]).Updates(map[string]interface{}{
+				"price_cents":    updated.Price.Cents,
+				"price_currency": updated.Price.Currency,
+				"stock":          updated.Stock,
+			}).Error; err != nil {
+				return fmt.Errorf("SKU更新失败: %w", err)
+			}
+		}
+
+		if err := tx.Model(&p).Updates(columns).Error; err != nil {
+			return fmt.Errorf("商品更新失败: %w", err)
+		}
+		_, nameChanged := changes["name"]
+		_, descChanged := changes["description"]
+		textChanged = nameChanged || descChanged
+		p = updated
+		return audit(tx, productID, op, AuditUpdate, changes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateDetail(ctx, productID)
+	if textChanged {
+		reindex(ctx, &p)
+	}
+	zap.L().Info("商品已更新", zap.Uint("product_id", productID), zap.Uint("operator", op.UserID))
+	return &p, nil
+}
+
+// Delete 软删除商品，删除后查询、下单与加购均视为商品不存在
+func Delete(ctx context.Context, op Operator, productID uint) error {
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var p dal.Product
+		if err := lockOwned(tx, op, productID, &p); err != nil {
+			return err
+		}
+		if err := tx.Delete(&p).Error; err != nil {
+			return fmt.Errorf("商品删除失败: %w", err)
+		}
+		return audit(tx, productID, op, AuditDelete, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	InvalidateDetail(ctx, productID)
+	if err := DefaultSearchBackend.Remove(ctx, productID); err != nil {
+		zap.L().Warn("商品索引移除失败", zap.Uint("product_id", productID), zap.Error(err))
+	}
+	zap.L().Info("商品已删除", zap.Uint("product_id", productID), zap.Uint("operator", op.UserID))
+	return nil
+}
+
+// reindex 更新检索索引，失败只记录日志（商品数据已提交）
+func reindex(ctx context.Context, p *dal.Product) {
+	if err := DefaultSearchBackend.Index(ctx, p); err != nil {
+		zap.L().Warn("商品索引更新失败", zap.Uint("product_id", p.ID), zap.Error(err))
+	}
+}
+
+// ListAudits 查询商品的变更记录（含已删除的商品），按时间倒序
+func ListAudits(ctx context.Context, op Operator, productID uint) ([]dal.ProductAudit, error) {
+	var p dal.Product
+	if err := dal.DB.WithContext(ctx).Unscoped().First(&p, productID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("商品查询失败: %w", err)
+	}
+	if !canManage(op, &p) {
+		return nil, ErrForbidden
+	}
+
+	var audits []dal.ProductAudit
+	if err := dal.DB.WithContext(ctx).
+		Where("product_id = ?", productID).
+		Order("id DESC").
+		Find(&audits).Error; err != nil {
+		return nil, fmt.Errorf("审计记录查询失败: %w", err)
+	}
+	return audits, nil
+}
+
+// lockOwned 锁定商品行并校验操作权限
+func lockOwned(tx *gorm.DB, op Operator, productID uint, p *dal.Product) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(p, productID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrProductNotFound
+		}
+		return fmt.Errorf("商品查询失败: %w", err)
+	}
+	if !canManage(op, p) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// canManage 管理员可以管理所有商品，商家只能管理自己创建的商品
+func canManage(op Operator, p *dal.Product) bool {
+	switch op.Role {
+	case dal.RoleAdmin:
+		return true
+	case dal.RoleMerchant:
+		return p.MerchantID != 0 && p.MerchantID == op.UserID
+	default:
+		return false
+	}
+}
+
+func validate(name, description string, price money.Money, stock, status int) error {
+	switch {
+	case name == "" || utf8.RuneCountInString(name) > maxNameLen:
+		return fmt.Errorf("%w: 商品名称需1-%d字符", ErrInvalidParams, maxNameLen)
+	case utf8.RuneCountInString(description) > maxDescriptionLen:
+		return fmt.Errorf("%w: 商品描述不能超过%d字符", ErrInvalidParams, maxDescriptionLen)
+	case price.Cents <= 0:
+		return fmt.Errorf("%w: 价格必须大于0", ErrInvalidParams)
+	case len(price.Currency) != 3:
+		return fmt.Errorf("%w: 无效的币种 %s", ErrInvalidParams, price.Currency)
+	case stock < 0:
+		return fmt.Errorf("%w: 库存不能为负数", ErrInvalidParams)
+	case status != dal.ProductStatusOnShelf && status != dal.ProductStatusOffShelf:
+		return fmt.Errorf("%w: 状态只能为0（下架）或1（上架）", ErrInvalidParams)
+	}
+	return nil
+}
+
+func audit(tx *gorm.DB, productID uint, op Operator, action string, changes map[string]fieldChange) error {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("审计记录序列化失败: %w", err)
+	}
+	if err := tx.Create(&dal.ProductAudit{
+		ProductID: productID,
+		Actor:     op.UserID,
+		Action:    action,
+		Changes:   string(data),
+	}).Error; err != nil {
+		return fmt.Errorf("审计记录写入失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+var (
+	merchantA = Operator{UserID: 1, Role: dal.RoleMerchant}
+	merchantB = Operator{UserID: 2, Role: dal.RoleMerchant}
+	admin     = Operator{UserID: 9, Role: dal.RoleAdmin}
+)
+
+func setupCatalog(t *testing.T) {
+	t.Helper()
+	testutil.DB(t, &dal.Product{}, &dal.SKU{}, &dal.ProductAudit{})
+	testutil.Redis(t)
+	testutil.Config(t, nil)
+}
+
+func createOwned(t *testing.T, op Operator) *dal.Product {
+	t.Helper()
+	p, err := Create(context.Background(), op, CreateInput{Name: " 测试商品 ", Price: money.Money{Cents: 1999}, Stock: 10})
+	if err != nil {
+		t.Fatalf("创建商品失败: %v", err)
+	}
+	return p
+}
+
+// auditChanges 返回商品的审计记录（按时间正序）及每条记录变更的字段
+func auditChanges(t *testing.T, productID uint) ([]string, []map[string]fieldChange) {
+	t.Helper()
+	var audits []dal.ProductAudit
+	dal.DB.Where("product_id = ?", productID).Order("id").Find(&audits)
+	actions := make([]string, len(audits))
+	changes := make([]map[string]fieldChange, len(audits))
+	for i, a := range audits {
+		actions[i] = a.Action
+		if err := json.Unmarshal([]byte(a.Changes), &changes[i]); err != nil {
+			t.Fatalf("审计记录解析失败: %v", err)
+		}
+	}
+	return actions, changes
+}
+
+func TestCanManage(t *testing.T) {
+	owned := &dal.Product{MerchantID: 1}
+	platform := &dal.Product{}
+	tests := []struct {
+		name string
+		op   Operator
+		p    *dal.Product
+		want bool
+	}{
+		{"管理员管理商家商品", admin, owned, true},
+		{"管理员管理平台商品", admin, platform, true},
+		{"商家管理自己的商品", merchantA, owned, true},
+		{"商家管理其他商家的商品", merchantB, owned, false},
+		{"商家管理平台商品", Operator{UserID: 0, Role: dal.RoleMerchant}, platform, false},
+		{"普通用户", Operator{UserID: 1, Role: dal.RoleCustomer}, owned, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canManage(tt.op, tt.p); got != tt.want {
+				t.Errorf("canManage() = %v，期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	setupCatalog(t)
+	ctx := context.Background()
+
+	p := createOwned(t, merchantA)
+	if p.MerchantID != merchantA.UserID || p.Name != "测试商品" || p.Price.Currency != money.DefaultCurrency {
+		t.Errorf("商品 = %+v，期望归属商家 %d、名称去除空白、缺省币种", p, merchantA.UserID)
+	}
+	if p := createOwned(t, admin); p.MerchantID != 0 {
+		t.Errorf("管理员创建的商品归属 = %d，期望平台商品", p.MerchantID)
+	}
+
+	offShelf := dal.ProductStatusOffShelf
+	p, err := Create(ctx, admin, CreateInput{Name: "下架商品", Price: money.FromCents(100), Status: &offShelf})
+	if err != nil {
+		t.Fatalf("创建商品失败: %v", err)
+	}
+	var saved dal.Product
+	dal.DB.First(&saved, p.ID)
+	if saved.Status != dal.ProductStatusOffShelf {
+		t.Errorf("商品状态 = %d，期望下架", saved.Status)
+	}
+
+	invalid := 2
+	tests := []struct {
+		name string
+		in   CreateInput
+	}{
+		{"名称为空", CreateInput{Name: "  ", Price: money.FromCents(100)}},
+		{"名称过长", CreateInput{Name: strings.Repeat("长", maxNameLen+1), Price: money.FromCents(100)}},
+		{"价格为零", CreateInput{Name: "商品"}},
+		{"无效币种", CreateInput{Name: "商品", Price: money.New(100, "RMB1")}},
+		{"库存为负", CreateInput{Name: "商品", Price: money.FromCents(100), Stock: -1}},
+		{"无效状态", CreateInput{Name: "商品", Price: money.FromCents(100), Status: &invalid}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Create(ctx, admin, tt.in); !errors.Is(err, ErrInvalidParams) {
+				t.Errorf("Create() 错误 = %v，期望 %v", err, ErrInvalidParams)
+			}
+		})
+	}
+}
+
+func TestUpdateOwnership(t *testing.T) {
+	setupCatalog(t)
+	ctx := context.Background()
+	p := createOwned(t, merchantA)
+	stock := 20
+
+	if _, err := Update(ctx, merchantB, p.ID, UpdateInput{Stock: &stock}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("其他商家 Update() 错误 = %v，期望 %v", err, ErrForbidden)
+	}
+	if err := Delete(ctx, merchantB, p.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("其他商家 Delete() 错误 = %v，期望 %v", err, ErrForbidden)
+	}
+	if _, err := ListAudits(ctx, merchantB, p.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("其他商家 ListAudits() 错误 = %v，期望 %v", err, ErrForbidden)
+	}
+	if _, err := Update(ctx, merchantA, 999, UpdateInput{Stock: &stock}); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("商品不存在 Update() 错误 = %v，期望 %v", err, ErrProductNotFound)
+	}
+
+	if _, err := Update(ctx, admin, p.ID, UpdateInput{Stock: &stock}); err != nil {
+		t.Fatalf("管理员 Update() 错误 = %v", err)
+	}
+	if err := Delete(ctx, merchantA, p.ID); err != nil {
+		t.Fatalf("商家 Delete() 错误 = %v", err)
+	}
+	if err := dal.DB.First(&dal.Product{}, p.ID).Error; err == nil {
+		t.Error("删除后仍能查询到商品")
+	}
+
+	// 已删除商品的审计记录仍可查询
+	audits, err := ListAudits(ctx, merchantA, p.ID)
+	if err != nil {
+		t.Fatalf("ListAudits() 错误 = %v", err)
+	}
+	if len(audits) != 3 || audits[0].Action != AuditDelete || audits[0].Actor != merchantA.UserID || audits[1].Actor != admin.UserID {
+		t.Errorf("审计记录 = %+v，期望按时间倒序的删除、更新与创建", audits)
+	}
+}
+
+func TestUpdateAuditDiff(t *testing.T) {
+	setupCatalog(t)
+	ctx := context.Background()
+	p := createOwned(t, merchantA)
+
+	name, stock := "测试商品", 5
+	price := money.Money{Cents: 2999} // 缺省币种时沿用原币种
+	updated, err := Update(ctx, merchantA, p.ID, UpdateInput{Name: &name, Stock: &stock, Price: &price})
+	if err != nil {
+		t.Fatalf("Update() 错误 = %v", err)
+	}
+	if updated.Stock != stock || !updated.Price.Equal(money.FromCents(2999)) {
+		t.Errorf("更新后商品 = %+v，期望库存 %d、价格 29.99", updated, stock)
+	}
+
+	actions, changes := auditChanges(t, p.ID)
+	if len(actions) != 2 || actions[0] != AuditCreate || actions[1] != AuditUpdate {
+		t.Fatalf("审计操作 = %v，期望 [create update]", actions)
+	}
+	diff := changes[1]
+	if _, ok := diff["name"]; ok || len(diff) != 2 {
+		t.Errorf("变更字段 = %v，期望只记录 stock 与 price", diff)
+	}
+	if c := diff["stock"]; c.Old != float64(10) || c.New != float64(5) {
+		t.Errorf("库存变更 = %+v，期望 10 -> 5", c)
+	}
+
+	// 与当前值相同的更新不写入审计记录
+	if _, err := Update(ctx, merchantA, p.ID, UpdateInput{Name: &name, Stock: &stock}); !errors.Is(err, ErrNoChanges) {
+		t.Errorf("无变化 Update() 错误 = %v，期望 %v", err, ErrNoChanges)
+	}
+	if _, err := Update(ctx, merchantA, p.ID, UpdateInput{}); !errors.Is(err, ErrNoChanges) {
+		t.Errorf("空参数 Update() 错误 = %v，期望 %v", err, ErrNoChanges)
+	}
+	negative := -1
+	if _, err := Update(ctx, merchantA, p.ID, UpdateInput{Stock: &negative}); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("库存为负 Update() 错误 = %v，期望 %v", err, ErrInvalidParams)
+	}
+	if actions, _ := auditChanges(t, p.ID); len(actions) != 2 {
+		t.Errorf("审计记录数 = %d，期望 2", len(actions))
+	}
+}
@@ -0,0 +1,615 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 分类与属性相关错误定义
+var (
+	ErrCategoryNotFound = errors.New("分类不存在")
+	ErrCategoryInUse    = errors.New("分类下仍有子分类或商品")
+)
+
+const (
+	maxCategoryLevel   = 5
+	maxCategoryNameLen = 50
+	maxAttrValueLen    = 255
+)
+
+var attrCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// CategoryNode 分类树节点
+type CategoryNode struct {
+	ID       uint            `json:"id"`
+	ParentID uint            `json:"parent_id"`
+	Name     string          `json:"name"`
+	Level    int             `json:"level"`
+	Sort     int             `json:"sort"`
+	Children []*CategoryNode `json:"children,omitempty"`
+}
+
+// AttributeInput 属性定义参数
+type AttributeInput struct {
+	Code       string
+	Name       string
+	Type       string
+	Options    []string // enum类型的可选值
+	Unit       string
+	Required   bool
+	Filterable bool
+}
+
+// AttributeValue 商品的属性值（附带属性定义）
+type AttributeValue struct {
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Unit  string `json:"unit,omitempty"`
+}
+
+// AttributeFilter 按属性筛选商品：Values为可选值（命中任一即可），Min/Max为number类型的范围
+type AttributeFilter struct {
+	Code   string
+	Values []string
+	Min    *float64
+	Max    *float64
+}
+
+// CreateCategory 创建分类，parentID为0时创建顶级分类
+func CreateCategory(ctx context.Context, parentID uint, name string, sortNo int) (*dal.Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxCategoryNameLen {
+		return nil, fmt.Errorf("%w: 分类名称需1-%d字符", ErrInvalidParams, maxCategoryNameLen)
+	}
+
+	c := &dal.Category{ParentID: parentID, Name: name, Level: 1, Sort: sortNo}
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		parentPath := "/"
+		if parentID != 0 {
+			parent, err := getCategory(tx, parentID)
+			if err != nil {
+				return err
+			}
+			if parent.Level >= maxCategoryLevel {
+				return fmt.Errorf("%w: 分类最多%d级", ErrInvalidParams, maxCategoryLevel)
+			}
+			parentPath = parent.Path
+			c.Level = parent.Level + 1
+		}
+		if err := tx.Create(c).Error; err != nil {
+			return fmt.Errorf("分类创建失败: %w", err)
+		}
+		// 路径包含自身ID，插入后才能确定
+		c.Path = fmt.Sprintf("%s%d/", parentPath, c.ID)
+		if err := tx.Model(c).Update("path", c.Path).Error; err != nil {
+			return fmt.Errorf("分类创建失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zap.L().Info("分类已创建", zap.Uint("category_id", c.ID), zap.String("path", c.Path))
+	return c, nil
+}
+
+// UpdateCategory 修改分类名称或排序，为空的字段保持不变
+func UpdateCategory(ctx context.Context, id uint, name *string, sortNo *int) (*dal.Category, error) {
+	c, err := getCategory(dal.DB.WithContext(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]interface{}{}
+	if name != nil {
+		n := strings.TrimSpace(*name)
+		if n == "" || utf8.RuneCountInString(n) > maxCategoryNameLen {
+			return nil, fmt.Errorf("%w: 分类名称需1-%d字符", ErrInvalidParams, maxCategoryNameLen)
+		}
+		if n != c.Name {
+			columns["name"] = n
+			c.Name = n
+		}
+	}
+	if sortNo != nil && *sortNo != c.Sort {
+		columns["sort"] = *sortNo
+		c.Sort = *sortNo
+	}
+	if len(columns) == 0 {
+		return nil, ErrNoChanges
+	}
+
+	if err := dal.DB.WithContext(ctx).Model(c).Updates(columns).Error; err != nil {
+		return nil, fmt.Errorf("分类更新失败: %w", err)
+	}
+	return c, nil
+}
+
+// DeleteCategory 删除没有子分类和商品的分类，分类下的属性定义一并删除
+func DeleteCategory(ctx context.Context, id uint) error {
+	return dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		c, err := getCategory(tx, id)
+		if err != nil {
+			return err
+		}
+
+		var children, products int64
+		if err := tx.Model(&dal.Category{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+			return fmt.Errorf("子分类查询失败: %w", err)
+		}
+		if err := tx.Model(&dal.Product{}).Where("category_id = ?", id).Count(&products).Error; err != nil {
+			return fmt.Errorf("分类商品查询失败: %w", err)
+		}
+		if children > 0 || products > 0 {
+			return ErrCategoryInUse
+		}
+
+		if err := tx.Where("category_id = ?", id).Delete(&dal.AttributeDefinition{}).Error; err != nil {
+			return fmt.Errorf("属性定义删除失败: %w", err)
+		}
+		if err := tx.Delete(c).Error; err != nil {
+			return fmt.Errorf("分类删除失败: %w", err)
+		}
+		zap.L().Info("分类已删除", zap.Uint("category_id", id))
+		return nil
+	})
+}
+
+// GetCategory 查询单个分类
+func GetCategory(ctx context.Context, id uint) (*dal.Category, error) {
+	return getCategory(dal.DB.WithContext(ctx), id)
+}
+
+// CategoryTree 查询分类树，rootID为0时返回全部顶级分类及其子树，否则返回该分类的子树
+func CategoryTree(ctx context.Context, rootID uint) ([]*CategoryNode, error) {
+	db := dal.DB.WithContext(ctx)
+	if rootID != 0 {
+		root, err := getCategory(db, rootID)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("path LIKE ?", root.Path+"%")
+	}
+
+	var categories []dal.Category
+	if err := db.Order("level, sort, id").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("分类查询失败: %w", err)
+	}
+
+	// 按层级顺序处理，父节点总是先于子节点加入
+	nodes := make(map[uint]*CategoryNode, len(categories))
+	roots := []*CategoryNode{}
+	for _, c := range categories {
+		node := &CategoryNode{ID: c.ID, ParentID: c.ParentID, Name: c.Name, Level: c.Level, Sort: c.Sort}
+		nodes[c.ID] = node
+		if parent, ok := nodes[c.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots, nil
+}
+
+// DefineAttribute 在分类下定义属性，子分类自动继承
+// 属性编码在分类的上级链与整棵子树中都不能重复，保证任一分类看到的属性编码唯一。
+func DefineAttribute(ctx context.Context, categoryID uint, in AttributeInput) (*dal.AttributeDefinition, error) {
+	in.Code = strings.TrimSpace(in.Code)
+	in.Name = strings.TrimSpace(in.Name)
+	if !attrCodePattern.MatchString(in.Code) {
+		return nil, fmt.Errorf("%w: 属性编码只能包含小写字母、数字和下划线，且以字母开头", ErrInvalidParams)
+	}
+	if in.Name == "" || utf8.RuneCountInString(in.Name) > maxCategoryNameLen {
+		return nil, fmt.Errorf("%w: 属性名称需1-%d字符", ErrInvalidParams, maxCategoryNameLen)
+	}
+	def := &dal.AttributeDefinition{
+		CategoryID: categoryID,
+		Code:       in.Code,
+		Name:       in.Name,
+		Type:       in.Type,
+		Unit:       in.Unit,
+		Required:   in.Required,
+		Filterable: in.Filterable,
+	}
+	switch in.Type {
+	case dal.AttrTypeString, dal.AttrTypeNumber, dal.AttrTypeBool:
+		if len(in.Options) > 0 {
+			return nil, fmt.Errorf("%w: 只有enum类型可以设置可选值", ErrInvalidParams)
+		}
+	case dal.AttrTypeEnum:
+		options, err := normalizeOptions(in.Options)
+		if err != nil {
+			return nil, err
+		}
+		data, _ := json.Marshal(options)
+		def.Options = string(data)
+	default:
+		return nil, fmt.Errorf("%w: 属性类型只能为string/number/bool/enum", ErrInvalidParams)
+	}
+
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		c, err := getCategory(tx, categoryID)
+		if err != nil {
+			return err
+		}
+		var count int64
+		err = tx.Model(&dal.AttributeDefinition{}).
+			Where("code = ?", in.Code).
+			Where("(category_id IN ? OR category_id IN (?))", ancestorIDs(c),
+				tx.Model(&dal.Category{}).Select("id").Where("path LIKE ?", c.Path+"%")).
+			Count(&count).Error
+		if err != nil {
+			return fmt.Errorf("属性定义查询失败: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: 属性编码 %s 已在上级或下级分类中定义", ErrInvalidParams, in.Code)
+		}
+		if err := tx.Create(def).Error; err != nil {
+			return fmt.Errorf("属性定义创建失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// CategoryAttributes 查询分类可用的属性定义（含从上级分类继承的），上级分类的属性在前
+func CategoryAttributes(ctx context.Context, categoryID uint) ([]dal.AttributeDefinition, error) {
+	db := dal.DB.WithContext(ctx)
+	c, err := getCategory(db, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	return attributesOf(db, c)
+}
+
+// AssignCategory 设置商品分类与属性值，values按属性编码提供，会整体替换商品原有的属性值
+// categoryID为0时取消分类，此时不能再设置属性。
+func AssignCategory(ctx context.Context, op Operator, productID, categoryID uint, values map[string]string) ([]AttributeValue, error) {
+	var result []AttributeValue
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var p dal.Product
+		if err := lockOwned(tx, op, productID, &p); err != nil {
+			return err
+		}
+
+		var defs []dal.AttributeDefinition
+		if categoryID != 0 {
+			c, err := getCategory(tx, categoryID)
+			if err != nil {
+				return err
+			}
+			if defs, err = attributesOf(tx, c); err != nil {
+				return err
+			}
+		}
+
+		rows, views, err := buildAttributeValues(productID, defs, values)
+		if err != nil {
+			return err
+		}
+		before, err := productAttributeValues(tx, productID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("product_id = ?", productID).Delete(&dal.ProductAttribute{}).Error; err != nil {
+			return fmt.Errorf("商品属性更新失败: %w", err)
+		}
+		if len(rows) > 0 {
+			if err := tx.Create(&rows).Error; err != nil {
+				return fmt.Errorf("商品属性更新失败: %w", err)
+			}
+		}
+		if p.CategoryID != categoryID {
+			if err := tx.Model(&p).Update("category_id", categoryID).Error; err != nil {
+				return fmt.Errorf("商品分类更新失败: %w", err)
+			}
+		}
+
+		result = views
+		return audit(tx, productID, op, AuditUpdate, map[string]fieldChange{
+			"category_id": {Old: p.CategoryID, New: categoryID},
+			"attributes":  {Old: valueMap(before), New: valueMap(views)},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateDetail(ctx, productID)
+	zap.L().Info("商品分类已设置",
+		zap.Uint("product_id", productID),
+		zap.Uint("category_id", categoryID),
+		zap.Uint("operator", op.UserID))
+	return result, nil
+}
+
+// ProductAttributes 查询商品的属性值
+func ProductAttributes(ctx context.Context, productID uint) ([]AttributeValue, error) {
+	if _, err := GetProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	return productAttributeValues(dal.DB.WithContext(ctx), productID)
+}
+
+// ParseAttributeFilters 解析属性筛选条件，键为属性编码
+// 值为逗号分隔的可选值（如 red,blue），或 min..max 形式的数值范围（任一端可省略，如 40.. ）。
+func ParseAttributeFilters(raw map[string]string) ([]AttributeFilter, error) {
+	codes := make([]string, 0, len(raw))
+	for code := range raw {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	filters := make([]AttributeFilter, 0, len(codes))
+	for _, code := range codes {
+		if !attrCodePattern.MatchString(code) {
+			return nil, fmt.Errorf("%w: 无效的属性编码 %s", ErrInvalidParams, code)
+		}
+		f := AttributeFilter{Code: code}
+		v := strings.TrimSpace(raw[code])
+		if lo, hi, ok := strings.Cut(v, ".."); ok {
+			var err error
+			if f.Min, err = parseBound(lo); err != nil {
+				return nil, fmt.Errorf("%w: 属性 %s 的范围格式错误", ErrInvalidParams, code)
+			}
+			if f.Max, err = parseBound(hi); err != nil {
+				return nil, fmt.Errorf("%w: 属性 %s 的范围格式错误", ErrInvalidParams, code)
+			}
+			if f.Min == nil && f.Max == nil {
+				return nil, fmt.Errorf("%w: 属性 %s 的范围格式错误", ErrInvalidParams, code)
+			}
+		} else {
+			for _, value := range strings.Split(v, ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					f.Values = append(f.Values, value)
+				}
+			}
+			if len(f.Values) == 0 {
+				return nil, fmt.Errorf("%w: 属性 %s 的筛选值不能为空", ErrInvalidParams, code)
+			}
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// applyAttributeFilters 在商品查询上追加属性筛选条件，只允许按可筛选的属性过滤
+func applyAttributeFilters(ctx context.Context, db *gorm.DB, filters []AttributeFilter) (*gorm.DB, error) {
+	for _, f := range filters {
+		var def dal.AttributeDefinition
+		err := dal.DB.WithContext(ctx).Where("code = ? AND filterable = ?", f.Code, true).First(&def).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, fmt.Errorf("%w: 属性 %s 不支持筛选", ErrInvalidParams, f.Code)
+			}
+			return nil, fmt.Errorf("属性定义查询失败: %w", err)
+		}
+
+		sub := dal.DB.Table("product_attributes AS pa").Select("1").
+			Joins("JOIN attribute_definitions AS ad ON ad.id = pa.attribute_id AND ad.deleted_at IS NULL").
+			Where("pa.product_id = products.id AND ad.code = ?", f.Code)
+		if len(f.Values) > 0 {
+			// 与写入时相同方式规范化，如数值 42.0 与 42 视为相同
+			values := make([]string, 0, len(f.Values))
+			for _, v := range f.Values {
+				if normalized, err := normalizeValue(&def, v, false); err == nil {
+					values = append(values, normalized)
+				} else {
+					values = append(values, v)
+				}
+			}
+			sub = sub.Where("pa.value IN ?", values)
+		}
+		if f.Min != nil || f.Max != nil {
+			if def.Type != dal.AttrTypeNumber {
+				return nil, fmt.Errorf("%w: 属性 %s 不是数值类型，不能按范围筛选", ErrInvalidParams, f.Code)
+			}
+			if f.Min != nil {
+				sub = sub.Where("pa.number_value >= ?", *f.Min)
+			}
+			if f.Max != nil {
+				sub = sub.Where("pa.number_value <= ?", *f.Max)
+			}
+		}
+		db = db.Where("EXISTS (?)", sub)
+	}
+	return db, nil
+}
+
+// categorySubtree 返回分类及其所有下级分类ID的子查询
+func categorySubtree(ctx context.Context, categoryID uint) (*gorm.DB, error) {
+	db := dal.DB.WithContext(ctx)
+	c, err := getCategory(db, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	return dal.DB.Model(&dal.Category{}).Select("id").Where("path LIKE ?", c.Path+"%"), nil
+}
+
+func getCategory(db *gorm.DB, id uint) (*dal.Category, error) {
+	var c dal.Category
+	if err := db.First(&c, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("分类查询失败: %w", err)
+	}
+	return &c, nil
+}
+
+// ancestorIDs 从路径解析出根到自身的分类ID
+func ancestorIDs(c *dal.Category) []uint {
+	var ids []uint
+	for _, part := range strings.Split(strings.Trim(c.Path, "/"), "/") {
+		if id, err := strconv.ParseUint(part, 10, 64); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
+
+func attributesOf(db *gorm.DB, c *dal.Category) ([]dal.AttributeDefinition, error) {
+	ids := ancestorIDs(c)
+	var defs []dal.AttributeDefinition
+	if err := db.Where("category_id IN ?", ids).Order("id").Find(&defs).Error; err != nil {
+		return nil, fmt.Errorf("属性定义查询失败: %w", err)
+	}
+	// 按路径顺序排列，上级分类的属性在前
+	depth := make(map[uint]int, len(ids))
+	for i, id := range ids {
+		depth[id] = i
+	}
+	sort.SliceStable(defs, func(i, j int) bool {
+		return depth[defs[i].CategoryID] < depth[defs[j].CategoryID]
+	})
+	return defs, nil
+}
+
+// buildAttributeValues 按属性定义校验并规范化属性值
+func buildAttributeValues(productID uint, defs []dal.AttributeDefinition, values map[string]string) ([]dal.ProductAttribute, []AttributeValue, error) {
+	byCode := make(map[string]*dal.AttributeDefinition, len(defs))
+	for i := range defs {
+		byCode[defs[i].Code] = &defs[i]
+	}
+	for code := range values {
+		if byCode[code] == nil {
+			return nil, nil, fmt.Errorf("%w: 分类下没有属性 %s", ErrInvalidParams, code)
+		}
+	}
+
+	var rows []dal.ProductAttribute
+	var views []AttributeValue
+	for i := range defs {
+		def := &defs[i]
+		raw, ok := values[def.Code]
+		if !ok || strings.TrimSpace(raw) == "" {
+			if def.Required {
+				return nil, nil, fmt.Errorf("%w: 属性 %s 必须填写", ErrInvalidParams, def.Name)
+			}
+			continue
+		}
+		value, err := normalizeValue(def, raw, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		row := dal.ProductAttribute{ProductID: productID, AttributeID: def.ID, Value: value}
+		if def.Type == dal.AttrTypeNumber {
+			n, _ := strconv.ParseFloat(value, 64)
+			row.NumberValue = &n
+		}
+		rows = append(rows, row)
+		views = append(views, AttributeValue{Code: def.Code, Name: def.Name, Type: def.Type, Value: value, Unit: def.Unit})
+	}
+	return rows, views, nil
+}
+
+// normalizeValue 按属性类型校验并规范化取值，checkOptions为false时不校验enum可选值（用于筛选）
+func normalizeValue(def *dal.AttributeDefinition, raw string, checkOptions bool) (string, error) {
+	raw = strings.TrimSpace(raw)
+	switch def.Type {
+	case dal.AttrTypeNumber:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: 属性 %s 必须为数值", ErrInvalidParams, def.Name)
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case dal.AttrTypeBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "", fmt.Errorf("%w: 属性 %s 必须为true或false", ErrInvalidParams, def.Name)
+		}
+		return strconv.FormatBool(b), nil
+	case dal.AttrTypeEnum:
+		if checkOptions {
+			var options []string
+			_ = json.Unmarshal([]byte(def.Options), &options)
+			found := false
+			for _, o := range options {
+				if o == raw {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return "", fmt.Errorf("%w: 属性 %s 的取值只能为 %s", ErrInvalidParams, def.Name, strings.Join(options, "/"))
+			}
+		}
+		return raw, nil
+	default:
+		if utf8.RuneCountInString(raw) > maxAttrValueLen {
+			return "", fmt.Errorf("%w: 属性 %s 不能超过%d字符", ErrInvalidParams, def.Name, maxAttrValueLen)
+		}
+		return raw, nil
+	}
+}
+
+func normalizeOptions(options []string) ([]string, error) {
+	seen := make(map[string]bool, len(options))
+	var result []string
+	for _, o := range options {
+		o = strings.TrimSpace(o)
+		if o == "" || seen[o] {
+			continue
+		}
+		if utf8.RuneCountInString(o) > maxAttrValueLen {
+			return nil, fmt.Errorf("%w: 可选值不能超过%d字符", ErrInvalidParams, maxAttrValueLen)
+		}
+		seen[o] = true
+		result = append(result, o)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%w: enum类型必须设置可选值", ErrInvalidParams)
+	}
+	return result, nil
+}
+
+func productAttributeValues(db *gorm.DB, productID uint) ([]AttributeValue, error) {
+	views := []AttributeValue{}
+	err := db.Table("product_attributes AS pa").
+		Select("ad.code, ad.name, ad.type, pa.value, ad.unit").
+		Joins("JOIN attribute_definitions AS ad ON ad.id = pa.attribute_id AND ad.deleted_at IS NULL").
+		Where("pa.product_id = ?", productID).
+		Order("ad.id").
+		Scan(&views).Error
+	if err != nil {
+		return nil, fmt.Errorf("商品属性查询失败: %w", err)
+	}
+	return views, nil
+}
+
+func valueMap(values []AttributeValue) map[string]string {
+	m := make(map[string]string, len(values))
+	for _, v := range values {
+		m[v.Code] = v.Value
+	}
+	return m
+}
+
+func parseBound(s string) (*float64, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
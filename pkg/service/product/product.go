@@ -0,0 +1,226 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 商品相关错误定义（HTTP与RPC共用）
+var (
+	ErrInvalidParams     = errors.New("参数错误")
+	ErrProductNotFound   = errors.New("商品不存在")
+	ErrStockInsufficient = errors.New("库存不足")
+	ErrStockReleased     = errors.New("该请求的库存扣减已归还")
+	ErrRequestConflict   = errors.New("请求ID已用于其他库存扣减")
+	ErrProductOffShelf   = errors.New("商品已下架")
+)
+
+// GetProduct 按ID查询商品
+func GetProduct(ctx context.Context, productID uint) (*dal.Product, error) {
+	var p dal.Product
+	if err := dal.DB.WithContext(ctx).First(&p, productID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("商品查询失败: %w", err)
+	}
+	return &p, nil
+}
+
+// DecreaseStock 幂等扣减SKU库存，skuID为0时扣减商品唯一的SKU
+// 同一requestID只会扣减一次：流水表唯一索引挡住重复请求，
+// 条件更新（stock >= ?）保证并发下不会超卖。商品的总库存与销量同步变化。
+func DecreaseStock(ctx context.Context, requestID string, productID, skuID uint, quantity int) error {
+	if requestID == "" || (productID == 0 && skuID == 0) || quantity <= 0 {
+		return ErrInvalidParams
+	}
+
+	var owner uint
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sku, err := resolveSKU(tx, productID, skuID)
+		if err != nil {
+			return err
+		}
+		owner = sku.ProductID
+
+		op := &dal.StockOperation{
+			RequestID: requestID,
+			ProductID: sku.ProductID,
+			SkuID:     sku.ID,
+			Quantity:  quantity,
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(op)
+		if result.Error != nil {
+			return fmt.Errorf("库存流水写入失败: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return duplicateDecrease(tx, requestID, sku, quantity)
+		}
+
+		// 先更新商品再更新SKU，与商品管理的加锁顺序一致；
+		// 条件更新同时校验上架状态，商品或SKU已下架、SKU库存不足时事务回滚
+		result = tx.Model(&dal.Product{}).
+			Where("id = ? AND status = ?", sku.ProductID, dal.ProductStatusOnShelf).
+			Updates(map[string]interface{}{
+				"stock": gorm.Expr("stock - ?", quantity),
+				"sales": gorm.Expr("sales + ?", quantity),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("库存更新失败: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: productID: %d", ErrProductOffShelf, sku.ProductID)
+		}
+		result = tx.Model(&dal.SKU{}).
+			Where("id = ? AND status = ? AND stock >= ?", sku.ID, dal.ProductStatusOnShelf, quantity).
+			Updates(map[string]interface{}{
+				"stock": gorm.Expr("stock - ?", quantity),
+				"sales": gorm.Expr("sales + ?", quantity),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("库存更新失败: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return skuUnavailable(tx, sku.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	InvalidateDetail(ctx, owner)
+	return nil
+}
+
+// skuUnavailable 区分SKU条件扣减失败的原因：已下架或库存不足
+func skuUnavailable(tx *gorm.DB, skuID uint) error {
+	var sku dal.SKU
+	if err := tx.Select("id", "status").First(&sku, skuID).Error; err != nil {
+		return fmt.Errorf("SKU查询失败: %w", err)
+	}
+	if sku.Status != dal.ProductStatusOnShelf {
+		return fmt.Errorf("%w: skuID: %d", ErrProductOffShelf, skuID)
+	}
+	return ErrStockInsufficient
+}
+
+// duplicateDecrease 处理requestID已存在的扣减请求
+// 只有尚未归还、且商品/SKU/数量与本次请求一致的扣减视为重复请求成功：
+// 已归还的扣减（或归还先到达时写入的占位流水）不再占用库存，返回 ErrStockReleased，
+// 避免迟到或重试的扣减在没有库存的情况下报告成功；参数不一致说明请求ID被误用，返回 ErrRequestConflict。
+func duplicateDecrease(tx *gorm.DB, requestID string, sku *dal.SKU, quantity int) error {
+	var existing dal.StockOperation
+	if err := tx.Where("request_id = ?", requestID).First(&existing).Error; err != nil {
+		return fmt.Errorf("库存流水查询失败: %w", err)
+	}
+	if existing.ReleasedAt != nil {
+		return fmt.Errorf("%w: %s", ErrStockReleased, requestID)
+	}
+	// 引入SKU前的流水没有SKU，对应商品的默认SKU（ID与商品ID相同）
+	skuID := existing.SkuID
+	if skuID == 0 {
+		skuID = existing.ProductID
+	}
+	if existing.ProductID != sku.ProductID || skuID != sku.ID || existing.Quantity != quantity {
+		return fmt.Errorf("%w: %s 已扣减商品%d（SKU %d）数量%d",
+			ErrRequestConflict, requestID, existing.ProductID, skuID, existing.Quantity)
+	}
+
+	zap.L().Info("库存扣减重复请求",
+		zap.String("request_id", requestID),
+		zap.Uint("sku_id", sku.ID))
+	return nil
+}
+
+// ReleaseStock 归还requestID对应的那次扣减，可重复调用
+// 若扣减记录不存在（扣减请求尚未到达或已失败），写入一条已归还的占位流水，
+// 使迟到的同requestID扣减请求被视为重复请求而不再扣减。
+func ReleaseStock(ctx context.Context, requestID string, productID uint) error {
+	if requestID == "" || productID == 0 {
+		return ErrInvalidParams
+	}
+
+	// 缓存失效以流水记录的商品为准，调用方传入的productID可能与实际扣减的商品不一致
+	var owner uint
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		var op dal.StockOperation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("request_id = ?", requestID).
+			First(&op).Error
+		if err == gorm.ErrRecordNotFound {
+			placeholder := &dal.StockOperation{
+				RequestID:  requestID,
+				ProductID:  productID,
+				ReleasedAt: &now,
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(placeholder).Error; err != nil {
+				return fmt.Errorf("库存流水写入失败: %w", err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("库存流水查询失败: %w", err)
+		}
+		if op.ReleasedAt != nil {
+			return nil // 已归还
+		}
+
+		// 引入SKU前的流水没有SKU，对应商品的默认SKU（ID与商品ID相同）
+		skuID := op.SkuID
+		if skuID == 0 {
+			skuID = op.ProductID
+		}
+		var sku dal.SKU
+		if err := tx.Unscoped().Select("id", "deleted_at").First(&sku, skuID).Error; err != nil {
+			return fmt.Errorf("SKU查询失败: %w", err)
+		}
+		// 商品总库存只统计未删除的SKU，SKU已删除时只归还到该SKU
+		productUpdates := map[string]interface{}{
+			"sales": gorm.Expr("GREATEST(sales - ?, 0)", op.Quantity),
+		}
+		if !sku.DeletedAt.Valid {
+			productUpdates["stock"] = gorm.Expr("stock + ?", op.Quantity)
+		}
+		if err := tx.Model(&dal.Product{}).
+			Where("id = ?", op.ProductID).
+			Updates(productUpdates).Error; err != nil {
+			return fmt.Errorf("库存更新失败: %w", err)
+		}
+		if err := tx.Unscoped().Model(&dal.SKU{}).
+			Where("id = ?", skuID).
+			Updates(map[string]interface{}{
+				"stock": gorm.Expr("stock + ?", op.Quantity),
+				"sales": gorm.Expr("GREATEST(sales - ?, 0)", op.Quantity),
+			}).Error; err != nil {
+			return fmt.Errorf("库存更新失败: %w", err)
+		}
+		if err := tx.Model(&op).Update("released_at", &now).Error; err != nil {
+			return fmt.Errorf("库存流水更新失败: %w", err)
+		}
+
+		owner = op.ProductID
+		zap.L().Info("库存已归还",
+			zap.String("request_id", requestID),
+			zap.Uint("sku_id", skuID),
+			zap.Int("quantity", op.Quantity))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if owner != 0 {
+		InvalidateDetail(ctx, owner)
+	}
+	return nil
+}
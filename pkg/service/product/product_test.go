@@ -0,0 +1,157 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/redis"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+	"gorm.io/gorm"
+)
+
+// setupStore 使用测试库与内存Redis替换全局实例
+func setupStore(t *testing.T) {
+	t.Helper()
+	testutil.DB(t, &dal.Product{}, &dal.SKU{}, &dal.StockOperation{})
+	testutil.Redis(t)
+}
+
+// createProduct 创建单SKU商品，SKU ID与商品ID相同
+func createProduct(t *testing.T, stock int) *dal.Product {
+	t.Helper()
+	p := &dal.Product{Name: "测试商品", Price: money.FromCents(100), Stock: stock, Status: dal.ProductStatusOnShelf}
+	if err := dal.DB.Create(p).Error; err != nil {
+		t.Fatalf("创建商品失败: %v", err)
+	}
+	sku := &dal.SKU{Model: gorm.Model{ID: p.ID}, ProductID: p.ID, Price: p.Price, Stock: stock, Status: dal.ProductStatusOnShelf}
+	if err := dal.DB.Create(sku).Error; err != nil {
+		t.Fatalf("创建SKU失败: %v", err)
+	}
+	return p
+}
+
+func stockOf(t *testing.T, p *dal.Product) (productStock, skuStock int) {
+	t.Helper()
+	var product dal.Product
+	var sku dal.SKU
+	dal.DB.First(&product, p.ID)
+	dal.DB.First(&sku, p.ID)
+	return product.Stock, sku.Stock
+}
+
+func TestDecreaseStockIdempotent(t *testing.T) {
+	setupStore(t)
+	ctx := context.Background()
+	p := createProduct(t, 10)
+	other := createProduct(t, 10)
+
+	now := time.Now()
+	// 归还先于扣减到达时写入的占位流水
+	dal.DB.Create(&dal.StockOperation{RequestID: "placeholder", ProductID: p.ID, ReleasedAt: &now})
+	// 已扣减并归还的流水
+	dal.DB.Create(&dal.StockOperation{RequestID: "released", ProductID: p.ID, SkuID: p.ID, Quantity: 2, ReleasedAt: &now})
+
+	tests := []struct {
+		name      string
+		requestID string
+		quantity  int
+		want      error
+		wantStock int
+	}{
+		{"首次扣减", "r1", 3, nil, 7},
+		{"重复请求不再扣减", "r1", 3, nil, 7},
+		{"库存不足", "r2", 8, ErrStockInsufficient, 7},
+		{"归还后迟到的扣减", "placeholder", 1, ErrStockReleased, 7},
+		{"已归还的扣减重试", "released", 2, ErrStockReleased, 7},
+		{"请求ID复用于不同数量", "r1", 4, ErrRequestConflict, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DecreaseStock(ctx, tt.requestID, p.ID, 0, tt.quantity)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("DecreaseStock() 错误 = %v，期望 %v", err, tt.want)
+			}
+			productStock, skuStock := stockOf(t, p)
+			if productStock != tt.wantStock || skuStock != tt.wantStock {
+				t.Errorf("库存 = 商品%d/SKU%d，期望 %d", productStock, skuStock, tt.wantStock)
+			}
+		})
+	}
+
+	// 请求ID复用于其他商品
+	if err := DecreaseStock(ctx, "r1", other.ID, 0, 3); !errors.Is(err, ErrRequestConflict) {
+		t.Errorf("DecreaseStock() 错误 = %v，期望 %v", err, ErrRequestConflict)
+	}
+	if productStock, skuStock := stockOf(t, other); productStock != 10 || skuStock != 10 {
+		t.Errorf("其他商品库存 = 商品%d/SKU%d，期望 10", productStock, skuStock)
+	}
+}
+
+func TestDecreaseStockOffShelf(t *testing.T) {
+	setupStore(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		update func(p *dal.Product)
+	}{
+		{"商品已下架", func(p *dal.Product) {
+			dal.DB.Model(&dal.Product{}).Where("id = ?", p.ID).Update("status", dal.ProductStatusOffShelf)
+		}},
+		{"SKU已下架", func(p *dal.Product) {
+			dal.DB.Model(&dal.SKU{}).Where("id = ?", p.ID).Update("status", dal.ProductStatusOffShelf)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := createProduct(t, 10)
+			tt.update(p)
+
+			if err := DecreaseStock(ctx, "off-"+tt.name, p.ID, 0, 1); !errors.Is(err, ErrProductOffShelf) {
+				t.Fatalf("DecreaseStock() 错误 = %v，期望 %v", err, ErrProductOffShelf)
+			}
+			if productStock, skuStock := stockOf(t, p); productStock != 10 || skuStock != 10 {
+				t.Errorf("库存 = 商品%d/SKU%d，期望 10", productStock, skuStock)
+			}
+			var count int64
+			dal.DB.Model(&dal.StockOperation{}).Where("request_id = ?", "off-"+tt.name).Count(&count)
+			if count != 0 {
+				t.Errorf("下架商品写入了%d条库存流水，期望 0", count)
+			}
+		})
+	}
+}
+
+func TestReleaseStockInvalidatesDeductedProduct(t *testing.T) {
+	setupStore(t)
+	testutil.Config(t, nil)
+	ctx := context.Background()
+	p := createProduct(t, 10)
+	other := createProduct(t, 10)
+
+	if err := DecreaseStock(ctx, "r1", p.ID, 0, 3); err != nil {
+		t.Fatalf("DecreaseStock() 错误 = %v", err)
+	}
+	if _, err := GetDetail(ctx, p.ID); err != nil {
+		t.Fatalf("GetDetail() 错误 = %v", err)
+	}
+
+	// 调用方传入的商品ID与流水不一致时，仍以流水中实际扣减的商品为准归还并清除缓存
+	if err := ReleaseStock(ctx, "r1", other.ID); err != nil {
+		t.Fatalf("ReleaseStock() 错误 = %v", err)
+	}
+	if n := redis.Client.Exists(ctx, detailCacheKey(p.ID)).Val(); n != 0 {
+		t.Error("归还后商品详情缓存未失效")
+	}
+	d, err := GetDetail(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("GetDetail() 错误 = %v", err)
+	}
+	if d.Product.Stock != 10 {
+		t.Errorf("商品详情库存 = %d，期望 10", d.Product.Stock)
+	}
+}
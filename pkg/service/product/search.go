@@ -0,0 +1,198 @@
+package product
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+	maxKeywordLen   = 50
+)
+
+// 排序方式
+const (
+	SortCreatedDesc = "created_desc"
+	SortPriceAsc    = "price_asc"
+	SortPriceDesc   = "price_desc"
+	SortSalesDesc   = "sales_desc"
+)
+
+var (
+	ErrInvalidCursor = errors.New("无效的分页游标")
+	ErrInvalidSort   = errors.New("不支持的排序方式")
+)
+
+// 排序列与方向，相同值按ID同向排序保证顺序稳定
+var sortColumns = map[string]struct {
+	column string
+	desc   bool
+}{
+	SortCreatedDesc: {"id", true}, // 自增ID与创建时间同序
+	SortPriceAsc:    {"price_cents", false},
+	SortPriceDesc:   {"price_cents", true},
+	SortSalesDesc:   {"sales", true},
+}
+
+// SearchQuery 商品搜索条件，零值字段表示不过滤
+type SearchQuery struct {
+	Keyword         string
+	MinPriceCents   *int64
+	MaxPriceCents   *int64
+	IncludeOffShelf bool // 默认只返回上架商品
+	CategoryID      uint // 包含所有下级分类的商品
+	Attributes      []AttributeFilter
+	Sort            string // 默认按创建时间倒序
+	Cursor          string // 上一页返回的NextCursor
+	Limit           int
+}
+
+// SearchResult 搜索分页结果，NextCursor为空表示没有更多数据
+type SearchResult struct {
+	Products   []dal.Product
+	NextCursor string
+}
+
+// Search 按关键词、价格、分类、属性与上下架状态搜索商品，游标分页
+// 关键词匹配由 DefaultSearchBackend 完成，过滤、排序与分页在数据库中执行；
+// 游标记录上一页最后一条的排序值与ID，翻页期间数据变化不会导致重复。
+func Search(ctx context.Context, q SearchQuery) (*SearchResult, error) {
+	if q.Sort == "" {
+		q.Sort = SortCreatedDesc
+	}
+	order, ok := sortColumns[q.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSort, q.Sort)
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+	q.Keyword = strings.TrimSpace(q.Keyword)
+	if utf8.RuneCountInString(q.Keyword) > maxKeywordLen {
+		return nil, fmt.Errorf("%w: 关键词不能超过%d字符", ErrInvalidParams, maxKeywordLen)
+	}
+
+	db := dal.DB.WithContext(ctx).Model(&dal.Product{})
+	if q.Keyword != "" {
+		var err error
+		if db, err = DefaultSearchBackend.Match(ctx, db, q.Keyword); err != nil {
+			return nil, err
+		}
+	}
+	if q.MinPriceCents != nil {
+		db = db.Where("price_cents >= ?", *q.MinPriceCents)
+	}
+	if q.MaxPriceCents != nil {
+		db = db.Where("price_cents <= ?", *q.MaxPriceCents)
+	}
+	if !q.IncludeOffShelf {
+		db = db.Where("status = ?", dal.ProductStatusOnShelf)
+	}
+	if q.CategoryID != 0 {
+		subtree, err := categorySubtree(ctx, q.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("category_id IN (?)", subtree)
+	}
+	if len(q.Attributes) > 0 {
+		var err error
+		if db, err = applyAttributeFilters(ctx, db, q.Attributes); err != nil {
+			return nil, err
+		}
+	}
+
+	op, dir := ">", "ASC"
+	if order.desc {
+		op, dir = "<", "DESC"
+	}
+	if q.Cursor != "" {
+		value, lastID, err := decodeCursor(q.Cursor, q.Sort)
+		if err != nil {
+			return nil, err
+		}
+		if order.column == "id" {
+			db = db.Where("id "+op+" ?", lastID)
+		} else {
+			db = db.Where("("+order.column+" "+op+" ? OR ("+order.column+" = ? AND id "+op+" ?))", value, value, lastID)
+		}
+	}
+	if order.column != "id" {
+		db = db.Order(order.column + " " + dir)
+	}
+	db = db.Order("id " + dir)
+
+	// 多查一条用于判断是否还有下一页
+	var products []dal.Product
+	if err := db.Limit(limit + 1).Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("商品查询失败: %w", err)
+	}
+
+	result := &SearchResult{Products: products}
+	if len(products) > limit {
+		result.Products = products[:limit]
+		result.NextCursor = encodeCursor(q.Sort, &products[limit-1])
+	}
+	return result, nil
+}
+
+// ListByIDs 按ID批量查询商品，不存在或已删除的ID不返回
+func ListByIDs(ctx context.Context, ids []uint) ([]dal.Product, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if len(ids) > MaxPageSize {
+		return nil, fmt.Errorf("%w: 一次最多查询%d个商品", ErrInvalidParams, MaxPageSize)
+	}
+	var products []dal.Product
+	if err := dal.DB.WithContext(ctx).Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("商品查询失败: %w", err)
+	}
+	return products, nil
+}
+
+// 游标格式：排序方式:排序值:ID，base64编码
+func encodeCursor(sort string, p *dal.Product) string {
+	var value int64
+	switch sortColumns[sort].column {
+	case "price_cents":
+		value = p.Price.Cents
+	case "sales":
+		value = int64(p.Sales)
+	}
+	raw := fmt.Sprintf("%s:%d:%d", sort, value, p.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor, sort string) (int64, uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 || parts[0] != sort {
+		// 游标与当前排序方式不一致
+		return 0, 0, ErrInvalidCursor
+	}
+	value, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, ErrInvalidCursor
+	}
+	id, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil || id == 0 {
+		return 0, 0, ErrInvalidCursor
+	}
+	return value, uint(id), nil
+}
@@ -0,0 +1,237 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/config"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const fulltextIndex = "idx_products_fulltext"
+
+// SearchBackend 商品关键词检索后端
+// 只负责关键词匹配，价格、上下架过滤与排序分页由 Search 在数据库中统一处理。
+type SearchBackend interface {
+	// Name 后端名称
+	Name() string
+	// Match 在商品查询上追加关键词匹配条件（名称与描述）
+	Match(ctx context.Context, db *gorm.DB, keyword string) (*gorm.DB, error)
+	// Index 商品创建或名称、描述变更后更新索引
+	Index(ctx context.Context, p *dal.Product) error
+	// Remove 商品删除后移除索引
+	Remove(ctx context.Context, productID uint) error
+}
+
+// DefaultSearchBackend 全局检索后端，由 InitSearch 按配置创建
+var DefaultSearchBackend SearchBackend = &MySQLSearchBackend{}
+
+// InitSearch 按配置初始化检索后端（需先初始化配置与数据库）
+func InitSearch(ctx context.Context) error {
+	switch backend := config.Conf.Product.SearchBackend; backend {
+	case "", "mysql":
+		b := &MySQLSearchBackend{}
+		if err := b.EnsureIndex(dal.DB); err != nil {
+			return err
+		}
+		DefaultSearchBackend = b
+	case "memory":
+		b := NewMemorySearchBackend()
+		if err := b.Rebuild(ctx, dal.DB); err != nil {
+			return err
+		}
+		DefaultSearchBackend = b
+	default:
+		return fmt.Errorf("不支持的商品检索后端: %s", backend)
+	}
+	return nil
+}
+
+// MySQLSearchBackend 基于MySQL FULLTEXT索引（ngram分词，支持中文）的检索
+// 索引由MySQL随数据写入自动维护，Index/Remove无需处理。
+type MySQLSearchBackend struct{}
+
+func (b *MySQLSearchBackend) Name() string { return "mysql" }
+
+// EnsureIndex 创建名称与描述上的全文索引
+func (b *MySQLSearchBackend) EnsureIndex(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&dal.Product{}, fulltextIndex) {
+		return nil
+	}
+	if err := db.Exec("CREATE FULLTEXT INDEX " + fulltextIndex +
+		" ON products (name, description) WITH PARSER ngram").Error; err != nil {
+		return fmt.Errorf("商品全文索引创建失败: %w", err)
+	}
+	return nil
+}
+
+// Match 使用布尔模式，每个词都必须出现
+func (b *MySQLSearchBackend) Match(ctx context.Context, db *gorm.DB, keyword string) (*gorm.DB, error) {
+	var terms []string
+	for _, term := range strings.Fields(keyword) {
+		// 去掉布尔模式的运算符，避免用户输入改变查询语义
+		term = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`+-<>()~*"@`, r) {
+				return -1
+			}
+			return r
+		}, term)
+		if term != "" {
+			terms = append(terms, `+"`+term+`"`)
+		}
+	}
+	if len(terms) == 0 {
+		return db, nil
+	}
+	return db.Where("MATCH(name, description) AGAINST (? IN BOOLEAN MODE)", strings.Join(terms, " ")), nil
+}
+
+func (b *MySQLSearchBackend) Index(ctx context.Context, p *dal.Product) error { return nil }
+
+func (b *MySQLSearchBackend) Remove(ctx context.Context, productID uint) error { return nil }
+
+// MemorySearchBackend 进程内倒排索引，用于测试与单机开发
+// 英文与数字按词、中文按单字与相邻两字切分，关键词的所有词项都命中才算匹配。
+// 索引只在当前进程内维护，多副本部署时各副本的索引不会同步。
+type MemorySearchBackend struct {
+	mu       sync.RWMutex
+	postings map[string]map[uint]struct{}
+	docs     map[uint][]string
+}
+
+func NewMemorySearchBackend() *MemorySearchBackend {
+	return &MemorySearchBackend{
+		postings: make(map[string]map[uint]struct{}),
+		docs:     make(map[uint][]string),
+	}
+}
+
+func (b *MemorySearchBackend) Name() string { return "memory" }
+
+// Rebuild 从数据库全量重建索引
+func (b *MemorySearchBackend) Rebuild(ctx context.Context, db *gorm.DB) error {
+	var products []dal.Product
+	err := db.WithContext(ctx).Select("id", "name", "description").
+		FindInBatches(&products, 500, func(tx *gorm.DB, batch int) error {
+			for i := range products {
+				b.Index(ctx, &products[i])
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return fmt.Errorf("商品索引重建失败: %w", err)
+	}
+	zap.L().Info("商品内存索引已重建", zap.Int("count", len(b.docs)))
+	return nil
+}
+
+func (b *MemorySearchBackend) Match(ctx context.Context, db *gorm.DB, keyword string) (*gorm.DB, error) {
+	tokens := tokenize(keyword)
+	if len(tokens) == 0 {
+		return db, nil
+	}
+
+	b.mu.RLock()
+	var ids []uint
+	for id := range b.postings[tokens[0]] {
+		matched := true
+		for _, token := range tokens[1:] {
+			if _, ok := b.postings[token][id]; !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			ids = append(ids, id)
+		}
+	}
+	b.mu.RUnlock()
+
+	if len(ids) == 0 {
+		return db.Where("1 = 0"), nil
+	}
+	return db.Where("id IN ?", ids), nil
+}
+
+func (b *MemorySearchBackend) Index(ctx context.Context, p *dal.Product) error {
+	tokens := tokenize(p.Name + " " + p.Description)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeLocked(p.ID)
+	for _, token := range tokens {
+		if b.postings[token] == nil {
+			b.postings[token] = make(map[uint]struct{})
+		}
+		b.postings[token][p.ID] = struct{}{}
+	}
+	b.docs[p.ID] = tokens
+	return nil
+}
+
+func (b *MemorySearchBackend) Remove(ctx context.Context, productID uint) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeLocked(productID)
+	return nil
+}
+
+func (b *MemorySearchBackend) removeLocked(productID uint) {
+	for _, token := range b.docs[productID] {
+		delete(b.postings[token], productID)
+		if len(b.postings[token]) == 0 {
+			delete(b.postings, token)
+		}
+	}
+	delete(b.docs, productID)
+}
+
+// tokenize 切分词项并去重：连续的字母数字为一个词（转小写），中文切分为单字与相邻两字
+func tokenize(text string) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	add := func(token string) {
+		if token != "" && !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	var word []rune
+	var han []rune
+	flushWord := func() {
+		add(strings.ToLower(string(word)))
+		word = word[:0]
+	}
+	flushHan := func() {
+		for _, r := range han {
+			add(string(r))
+		}
+		for i := 0; i+1 < len(han); i++ {
+			add(string(han[i : i+2]))
+		}
+		han = han[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushHan()
+		}
+	}
+	flushWord()
+	flushHan()
+	return tokens
+}
@@ -0,0 +1,57 @@
+package product
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"gorm.io/gorm"
+)
+
+func TestCursor(t *testing.T) {
+	p := &dal.Product{Model: gorm.Model{ID: 7}, Price: money.FromCents(1999), Sales: 30}
+
+	tests := []struct {
+		sort      string
+		wantValue int64
+	}{
+		{SortCreatedDesc, 0},
+		{SortPriceAsc, 1999},
+		{SortPriceDesc, 1999},
+		{SortSalesDesc, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			value, id, err := decodeCursor(encodeCursor(tt.sort, p), tt.sort)
+			if err != nil || value != tt.wantValue || id != p.ID {
+				t.Errorf("decodeCursor() = %d, %d, %v，期望 %d, %d", value, id, err, tt.wantValue, p.ID)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"不是base64", "!!!"},
+		{"排序方式不一致", encodeCursor(SortPriceAsc, &dal.Product{Model: gorm.Model{ID: 1}})},
+		{"段数不足", encode(SortSalesDesc + ":1")},
+		{"段数过多", encode(SortSalesDesc + ":1:2:3")},
+		{"排序值不是数字", encode(SortSalesDesc + ":x:1")},
+		{"ID不是数字", encode(SortSalesDesc + ":1:x")},
+		{"ID为零", encode(SortSalesDesc + ":1:0")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := decodeCursor(tt.cursor, SortSalesDesc); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeCursor(%q) 错误 = %v，期望 %v", tt.cursor, err, ErrInvalidCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,470 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxSKUsPerProduct = 200
+	maxSpecValueLen   = 50
+	maxBarcodeLen     = 64
+)
+
+// SKUInput 创建SKU参数
+type SKUInput struct {
+	Specs   map[string]string // 规格组合，键为属性编码；单规格商品为空
+	Price   money.Money
+	Stock   int
+	Barcode string
+	Status  *int // 为空时默认上架
+}
+
+// SKUUpdate SKU部分更新参数，为空的字段保持不变
+type SKUUpdate struct {
+	Specs   map[string]string
+	Price   *money.Money
+	Stock   *int
+	Barcode *string
+	Status  *int
+}
+
+// SKUSpecs 解析SKU的规格组合
+func SKUSpecs(s *dal.SKU) map[string]string {
+	specs := map[string]string{}
+	if s.Specs != "" {
+		_ = json.Unmarshal([]byte(s.Specs), &specs)
+	}
+	return specs
+}
+
+// ListSKUs 查询商品的全部SKU
+func ListSKUs(ctx context.Context, productID uint) ([]dal.SKU, error) {
+	if _, err := GetProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	var skus []dal.SKU
+	if err := dal.DB.WithContext(ctx).Where("product_id = ?", productID).Order("id").Find(&skus).Error; err != nil {
+		return nil, fmt.Errorf("SKU查询失败: %w", err)
+	}
+	return skus, nil
+}
+
+// GetSKUs 按ID批量查询SKU，不存在或已删除的ID不返回
+func GetSKUs(ctx context.Context, ids []uint) ([]dal.SKU, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if len(ids) > MaxPageSize {
+		return nil, fmt.Errorf("%w: 一次最多查询%d个SKU", ErrInvalidParams, MaxPageSize)
+	}
+	var skus []dal.SKU
+	if err := dal.DB.WithContext(ctx).Where("id IN ?", ids).Find(&skus).Error; err != nil {
+		return nil, fmt.Errorf("SKU查询失败: %w", err)
+	}
+	return skus, nil
+}
+
+// CreateSKU 为商品新增SKU
+func CreateSKU(ctx context.Context, op Operator, productID uint, in SKUInput) (*dal.SKU, error) {
+	var sku *dal.SKU
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var p dal.Product
+		if err := lockOwned(tx, op, productID, &p); err != nil {
+			return err
+		}
+		var err error
+		if sku, err = insertSKU(tx, &p, in); err != nil {
+			return err
+		}
+		if err := refreshAggregate(tx, &p); err != nil {
+			return err
+		}
+		return audit(tx, productID, op, AuditUpdate, map[string]fieldChange{
+			fmt.Sprintf("sku:%d", sku.ID): {New: skuSnapshot(sku)},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateDetail(ctx, productID)
+	zap.L().Info("SKU已创建", zap.Uint("product_id", productID), zap.Uint("sku_id", sku.ID), zap.Uint("operator", op.UserID))
+	return sku, nil
+}
+
+// UpdateSKU 部分更新SKU，商品的起售价与总库存同步更新
+func UpdateSKU(ctx context.Context, op Operator, skuID uint, in SKUUpdate) (*dal.SKU, error) {
+	var sku dal.SKU
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var p dal.Product
+		if err := lockSKU(tx, op, skuID, &p, &sku); err != nil {
+			return err
+		}
+
+		updated := sku
+		if in.Specs != nil {
+			specs, err := normalizeSpecs(tx, &p, in.Specs, sku.ID)
+			if err != nil {
+				return err
+			}
+			updated.Specs = specs
+		}
+		if in.Price != nil {
+			updated.Price = *in.Price
+			if updated.Price.Currency == "" {
+				updated.Price.Currency = sku.Price.Currency
+			}
+		}
+		if in.Stock != nil {
+			updated.Stock = *in.Stock
+		}
+		if in.Barcode != nil {
+			updated.Barcode = strings.TrimSpace(*in.Barcode)
+		}
+		if in.Status != nil {
+			updated.Status = *in.Status
+		}
+		if err := validateSKU(&p, &updated); err != nil {
+			return err
+		}
+
+		columns := map[string]interface{}{}
+		if updated.Specs != sku.Specs {
+			columns["specs"] = updated.Specs
+		}
+		if !updated.Price.Equal(sku.Price) {
+			columns["price_cents"] = updated.Price.Cents
+			columns["price_currency"] = updated.Price.Currency
+		}
+		if updated.Stock != sku.Stock {
+			columns["stock"] = updated.Stock
+		}
+		if updated.Barcode != sku.Barcode {
+			columns["barcode"] = updated.Barcode
+		}
+		if updated.Status != sku.Status {
+			columns["status"] = updated.Status
+		}
+		if len(columns) == 0 {
+			return ErrNoChanges
+		}
+
+		if err := tx.Model(&sku).Updates(columns).Error; err != nil {
+			return fmt.Errorf("SKU更新失败: %w", err)
+		}
+		before := skuSnapshot(&sku)
+		sku = updated
+		if err := refreshAggregate(tx, &p); err != nil {
+			return err
+		}
+		return audit(tx, p.ID, op, AuditUpdate, map[string]fieldChange{
+			fmt.Sprintf("sku:%d", sku.ID): {Old: before, New: skuSnapshot(&sku)},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	InvalidateDetail(ctx, sku.ProductID)
+	zap.L().Info("SKU已更新", zap.Uint("sku_id", skuID), zap.Uint("operator", op.UserID))
+	return &sku, nil
+}
+
+// DeleteSKU 软删除SKU，商品至少保留一个SKU
+func DeleteSKU(ctx context.Context, op Operator, skuID uint) error {
+	var p dal.Product
+	err := dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sku dal.SKU
+		if err := lockSKU(tx, op, skuID, &p, &sku); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&dal.SKU{}).Where("product_id = ?", p.ID).Count(&count).Error; err != nil {
+			return fmt.Errorf("SKU查询失败: %w", err)
+		}
+		if count <= 1 {
+			return fmt.Errorf("%w: 商品至少保留一个SKU，可删除商品或将SKU下架", ErrInvalidParams)
+		}
+
+		if err := tx.Delete(&sku).Error; err != nil {
+			return fmt.Errorf("SKU删除失败: %w", err)
+		}
+		if err := refreshAggregate(tx, &p); err != nil {
+			return err
+		}
+		return audit(tx, p.ID, op, AuditUpdate, map[string]fieldChange{
+			fmt.Sprintf("sku:%d", sku.ID): {Old: skuSnapshot(&sku)},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	InvalidateDetail(ctx, p.ID)
+	zap.L().Info("SKU已删除", zap.Uint("sku_id", skuID), zap.Uint("operator", op.UserID))
+	return nil
+}
+
+// resolveSKU 查询库存操作的SKU，skuID为0时使用商品唯一的SKU
+func resolveSKU(tx *gorm.DB, productID, skuID uint) (*dal.SKU, error) {
+	if skuID != 0 {
+		var sku dal.SKU
+		db := tx.Where("id = ?", skuID)
+		if productID != 0 {
+			db = db.Where("product_id = ?", productID)
+		}
+		if err := db.First(&sku).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, fmt.Errorf("%w: skuID: %d", ErrProductNotFound, skuID)
+			}
+			return nil, fmt.Errorf("SKU查询失败: %w", err)
+		}
+		return &sku, nil
+	}
+
+	var skus []dal.SKU
+	if err := tx.Where("product_id = ?", productID).Limit(2).Find(&skus).Error; err != nil {
+		return nil, fmt.Errorf("SKU查询失败: %w", err)
+	}
+	switch len(skus) {
+	case 0:
+		return nil, ErrProductNotFound
+	case 1:
+		return &skus[0], nil
+	default:
+		return nil, fmt.Errorf("%w: 商品%d有多个SKU，需指定SKU", ErrInvalidParams, productID)
+	}
+}
+
+// lockSKU 锁定SKU所属商品与SKU行并校验操作权限
+func lockSKU(tx *gorm.DB, op Operator, skuID uint, p *dal.Product, sku *dal.SKU) error {
+	if err := tx.Select("product_id").First(sku, skuID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("%w: skuID: %d", ErrProductNotFound, skuID)
+		}
+		return fmt.Errorf("SKU查询失败: %w", err)
+	}
+	if err := lockOwned(tx, op, sku.ProductID, p); err != nil {
+		return err
+	}
+	// 先锁商品再锁SKU，与库存扣减的加锁顺序一致
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(sku, skuID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("%w: skuID: %d", ErrProductNotFound, skuID)
+		}
+		return fmt.Errorf("SKU查询失败: %w", err)
+	}
+	return nil
+}
+
+// insertSKU 校验并写入SKU（调用方需已锁定商品行）
+func insertSKU(tx *gorm.DB, p *dal.Product, in SKUInput) (*dal.SKU, error) {
+	var count int64
+	if err := tx.Model(&dal.SKU{}).Where("product_id = ?", p.ID).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("SKU查询失败: %w", err)
+	}
+	if count >= maxSKUsPerProduct {
+		return nil, fmt.Errorf("%w: 每个商品最多%d个SKU", ErrInvalidParams, maxSKUsPerProduct)
+	}
+
+	specs, err := normalizeSpecs(tx, p, in.Specs, 0)
+	if err != nil {
+		return nil, err
+	}
+	sku := &dal.SKU{
+		ProductID: p.ID,
+		Specs:     specs,
+		Price:     in.Price,
+		Stock:     in.Stock,
+		Barcode:   strings.TrimSpace(in.Barcode),
+		Status:    dal.ProductStatusOnShelf,
+	}
+	if sku.Price.Currency == "" {
+		sku.Price.Currency = money.DefaultCurrency
+	}
+	if in.Status != nil {
+		sku.Status = *in.Status
+	}
+	if err := validateSKU(p, sku); err != nil {
+		return nil, err
+	}
+
+	status := sku.Status
+	if err := tx.Create(sku).Error; err != nil {
+		return nil, fmt.Errorf("SKU创建失败: %w", err)
+	}
+	// Status为零值时Create会使用列默认值（上架），需要单独更新
+	if status == dal.ProductStatusOffShelf {
+		if err := tx.Model(sku).Update("status", status).Error; err != nil {
+			return nil, fmt.Errorf("SKU创建失败: %w", err)
+		}
+		sku.Status = status
+	}
+	return sku, nil
+}
+
+// normalizeSpecs 校验规格组合并序列化为按编码排序的JSON
+// 同一商品的SKU必须使用相同的规格编码，且组合不能重复；商品有分类且编码为分类属性时按属性类型校验取值。
+func normalizeSpecs(tx *gorm.DB, p *dal.Product, specs map[string]string, excludeSKU uint) (string, error) {
+	var defs map[string]*dal.AttributeDefinition
+	if p.CategoryID != 0 && len(specs) > 0 {
+		c, err := getCategory(tx, p.CategoryID)
+		if err != nil && err != ErrCategoryNotFound {
+			return "", err
+		}
+		if c != nil {
+			list, err := attributesOf(tx, c)
+			if err != nil {
+				return "", err
+			}
+			defs = make(map[string]*dal.AttributeDefinition, len(list))
+			for i := range list {
+				defs[list[i].Code] = &list[i]
+			}
+		}
+	}
+
+	normalized := make(map[string]string, len(specs))
+	for code, value := range specs {
+		if !attrCodePattern.MatchString(code) {
+			return "", fmt.Errorf("%w: 无效的规格编码 %s", ErrInvalidParams, code)
+		}
+		value = strings.TrimSpace(value)
+		if value == "" || utf8.RuneCountInString(value) > maxSpecValueLen {
+			return "", fmt.Errorf("%w: 规格 %s 的取值需1-%d字符", ErrInvalidParams, code, maxSpecValueLen)
+		}
+		if def := defs[code]; def != nil {
+			var err error
+			if value, err = normalizeValue(def, value, true); err != nil {
+				return "", err
+			}
+		}
+		normalized[code] = value
+	}
+	// json.Marshal按键排序输出，相同组合的序列化结果一致
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return "", fmt.Errorf("规格序列化失败: %w", err)
+	}
+
+	var others []dal.SKU
+	db := tx.Select("id", "specs").Where("product_id = ?", p.ID)
+	if excludeSKU != 0 {
+		db = db.Where("id <> ?", excludeSKU)
+	}
+	if err := db.Find(&others).Error; err != nil {
+		return "", fmt.Errorf("SKU查询失败: %w", err)
+	}
+	for i := range others {
+		if others[i].Specs == string(data) {
+			return "", fmt.Errorf("%w: 规格组合 %s 已存在", ErrInvalidParams, data)
+		}
+		if existing := SKUSpecs(&others[i]); !sameSpecCodes(existing, normalized) {
+			codes := strings.Join(specCodes(existing), "/")
+			if codes == "" {
+				codes = "无规格"
+			}
+			return "", fmt.Errorf("%w: 同一商品的SKU需使用相同的规格（%s）", ErrInvalidParams, codes)
+		}
+	}
+	return string(data), nil
+}
+
+func validateSKU(p *dal.Product, sku *dal.SKU) error {
+	switch {
+	case sku.Price.Cents <= 0:
+		return fmt.Errorf("%w: 价格必须大于0", ErrInvalidParams)
+	case p.Price.Currency != "" && sku.Price.Currency != p.Price.Currency:
+		return fmt.Errorf("%w: SKU币种需与商品一致（%s）", ErrInvalidParams, p.Price.Currency)
+	case sku.Stock < 0:
+		return fmt.Errorf("%w: 库存不能为负数", ErrInvalidParams)
+	case len(sku.Barcode) > maxBarcodeLen:
+		return fmt.Errorf("%w: 条码不能超过%d字符", ErrInvalidParams, maxBarcodeLen)
+	case sku.Status != dal.ProductStatusOnShelf && sku.Status != dal.ProductStatusOffShelf:
+		return fmt.Errorf("%w: 状态只能为0（下架）或1（上架）", ErrInvalidParams)
+	}
+	return nil
+}
+
+// refreshAggregate 按SKU重新计算商品的起售价与总库存
+// 起售价取上架SKU的最低价，全部下架时取所有SKU的最低价。
+func refreshAggregate(tx *gorm.DB, p *dal.Product) error {
+	var skus []dal.SKU
+	if err := tx.Where("product_id = ?", p.ID).Find(&skus).Error; err != nil {
+		return fmt.Errorf("SKU查询失败: %w", err)
+	}
+	if len(skus) == 0 {
+		return nil
+	}
+
+	stock := 0
+	var lowest, lowestOnShelf *money.Money
+	for i := range skus {
+		stock += skus[i].Stock
+		price := skus[i].Price
+		if lowest == nil || price.Cents < lowest.Cents {
+			lowest = &price
+		}
+		if skus[i].Status == dal.ProductStatusOnShelf && (lowestOnShelf == nil || price.Cents < lowestOnShelf.Cents) {
+			lowestOnShelf = &price
+		}
+	}
+	if lowestOnShelf != nil {
+		lowest = lowestOnShelf
+	}
+
+	if err := tx.Model(p).Updates(map[string]interface{}{
+		"stock":          stock,
+		"price_cents":    lowest.Cents,
+		"price_currency": lowest.Currency,
+	}).Error; err != nil {
+		return fmt.Errorf("商品库存更新失败: %w", err)
+	}
+	p.Stock = stock
+	p.Price = *lowest
+	return nil
+}
+
+// skuSnapshot 审计记录中的SKU内容
+func skuSnapshot(s *dal.SKU) map[string]interface{} {
+	return map[string]interface{}{
+		"specs":   SKUSpecs(s),
+		"price":   s.Price,
+		"stock":   s.Stock,
+		"barcode": s.Barcode,
+		"status":  s.Status,
+	}
+}
+
+func sameSpecCodes(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for code := range a {
+		if _, ok := b[code]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func specCodes(specs map[string]string) []string {
+	codes := make([]string, 0, len(specs))
+	for code := range specs {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
@@ -0,0 +1,151 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+)
+
+// createMultiSKU 创建颜色/尺码两个规格的商品，其中蓝色/M 下架
+func createMultiSKU(t *testing.T) *dal.Product {
+	t.Helper()
+	offShelf := dal.ProductStatusOffShelf
+	p, err := Create(context.Background(), merchantA, CreateInput{
+		Name: "T恤",
+		SKUs: []SKUInput{
+			{Specs: map[string]string{"size": " L ", "color": "红色"}, Price: money.Money{Cents: 2999}, Stock: 3},
+			{Specs: map[string]string{"color": "红色", "size": "M"}, Price: money.FromCents(2499), Stock: 5},
+			{Specs: map[string]string{"color": "蓝色", "size": "M"}, Price: money.FromCents(1999), Stock: 4, Status: &offShelf},
+		},
+	})
+	if err != nil {
+		t.Fatalf("创建多SKU商品失败: %v", err)
+	}
+	return p
+}
+
+func skusOf(t *testing.T, productID uint) []dal.SKU {
+	t.Helper()
+	var skus []dal.SKU
+	dal.DB.Where("product_id = ?", productID).Order("id").Find(&skus)
+	return skus
+}
+
+func TestCreateMultiSKU(t *testing.T) {
+	setupCatalog(t)
+	p := createMultiSKU(t)
+
+	// 起售价取上架SKU的最低价，下架SKU的库存仍计入总库存
+	if !p.Price.Equal(money.FromCents(2499)) || p.Stock != 12 {
+		t.Errorf("商品价格 = %v，库存 = %d，期望 24.99 与 12", p.Price, p.Stock)
+	}
+	var saved dal.Product
+	dal.DB.First(&saved, p.ID)
+	if !saved.Price.Equal(p.Price) || saved.Stock != p.Stock {
+		t.Errorf("保存的商品价格 = %v，库存 = %d，期望与返回值一致", saved.Price, saved.Stock)
+	}
+
+	skus := skusOf(t, p.ID)
+	if len(skus) != 3 {
+		t.Fatalf("SKU数 = %d，期望 3", len(skus))
+	}
+	if want := `{"color":"红色","size":"L"}`; skus[0].Specs != want {
+		t.Errorf("规格 = %s，期望按编码排序并去除空白 %s", skus[0].Specs, want)
+	}
+	if skus[0].Price.Currency != money.DefaultCurrency || skus[2].Status != dal.ProductStatusOffShelf {
+		t.Errorf("SKU = %+v，期望缺省币种且蓝色/M下架", skus)
+	}
+}
+
+func TestCreateSKUSpecValidation(t *testing.T) {
+	setupCatalog(t)
+	ctx := context.Background()
+	p := createMultiSKU(t)
+
+	tests := []struct {
+		name  string
+		specs map[string]string
+	}{
+		{"规格组合重复", map[string]string{"color": "红色", "size": "L"}},
+		{"规格编码不一致", map[string]string{"color": "黑色"}},
+		{"缺少规格", nil},
+		{"无效的规格编码", map[string]string{"Color": "黑色", "size": "L"}},
+		{"规格取值为空", map[string]string{"color": " ", "size": "L"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateSKU(ctx, merchantA, p.ID, SKUInput{Specs: tt.specs, Price: money.FromCents(100)})
+			if !errors.Is(err, ErrInvalidParams) {
+				t.Errorf("CreateSKU() 错误 = %v，期望 %v", err, ErrInvalidParams)
+			}
+		})
+	}
+
+	if _, err := CreateSKU(ctx, merchantB, p.ID, SKUInput{Specs: map[string]string{"color": "黑色", "size": "L"}, Price: money.FromCents(100)}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("其他商家 CreateSKU() 错误 = %v，期望 %v", err, ErrForbidden)
+	}
+	sku, err := CreateSKU(ctx, merchantA, p.ID, SKUInput{Specs: map[string]string{"color": "黑色", "size": "L"}, Price: money.FromCents(1599), Stock: 1})
+	if err != nil {
+		t.Fatalf("CreateSKU() 错误 = %v", err)
+	}
+	var saved dal.Product
+	dal.DB.First(&saved, p.ID)
+	if sku.ProductID != p.ID || !saved.Price.Equal(money.FromCents(1599)) || saved.Stock != 13 {
+		t.Errorf("新增SKU后商品价格 = %v，库存 = %d，期望 15.99 与 13", saved.Price, saved.Stock)
+	}
+}
+
+func TestUpdateMultiSKUProduct(t *testing.T) {
+	setupCatalog(t)
+	ctx := context.Background()
+	p := createMultiSKU(t)
+
+	price, stock, name := money.FromCents(999), 100, "纯棉T恤"
+	if _, err := Update(ctx, merchantA, p.ID, UpdateInput{Price: &price}); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("修改多SKU商品价格 错误 = %v，期望 %v", err, ErrInvalidParams)
+	}
+	if _, err := Update(ctx, merchantA, p.ID, UpdateInput{Stock: &stock}); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("修改多SKU商品库存 错误 = %v，期望 %v", err, ErrInvalidParams)
+	}
+	if _, err := Update(ctx, merchantA, p.ID, UpdateInput{Name: &name}); err != nil {
+		t.Errorf("修改多SKU商品名称 错误 = %v", err)
+	}
+
+	// 修改SKU后商品的起售价与总库存同步更新
+	blue := skusOf(t, p.ID)[2]
+	onShelf := dal.ProductStatusOnShelf
+	if _, err := UpdateSKU(ctx, merchantA, blue.ID, SKUUpdate{Status: &onShelf, Stock: &stock}); err != nil {
+		t.Fatalf("UpdateSKU() 错误 = %v", err)
+	}
+	var saved dal.Product
+	dal.DB.First(&saved, p.ID)
+	if !saved.Price.Equal(money.FromCents(1999)) || saved.Stock != 108 || saved.Name != name {
+		t.Errorf("商品 = %+v，期望价格 19.99、库存 108", saved)
+	}
+	if _, err := UpdateSKU(ctx, merchantA, blue.ID, SKUUpdate{Stock: &stock}); !errors.Is(err, ErrNoChanges) {
+		t.Errorf("无变化 UpdateSKU() 错误 = %v，期望 %v", err, ErrNoChanges)
+	}
+}
+
+func TestUpdateSingleSKUProduct(t *testing.T) {
+	setupCatalog(t)
+	ctx := context.Background()
+	p := createOwned(t, merchantA)
+
+	// 单SKU商品修改价格与库存时同步修改该SKU
+	price, stock := money.FromCents(2999), 7
+	if _, err := Update(ctx, merchantA, p.ID, UpdateInput{Price: &price, Stock: &stock}); err != nil {
+		t.Fatalf("Update() 错误 = %v", err)
+	}
+	skus := skusOf(t, p.ID)
+	if len(skus) != 1 || !skus[0].Price.Equal(price) || skus[0].Stock != stock || skus[0].Specs != "{}" {
+		t.Errorf("SKU = %+v，期望价格 29.99、库存 7 且无规格", skus)
+	}
+
+	if err := DeleteSKU(ctx, merchantA, skus[0].ID); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("删除唯一SKU 错误 = %v，期望 %v", err, ErrInvalidParams)
+	}
+}
@@ -0,0 +1,336 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	orderService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/order"
+	paymentService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/payment"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 差异类型
+const (
+	KindMissingLocal    = "missing_local"    // 渠道支付成功，本地无支付记录
+	KindMissingProvider = "missing_provider" // 本地已支付，渠道无交易
+	KindAmount          = "amount_mismatch"  // 金额不一致
+	KindStatus          = "status_mismatch"  // 支付状态不一致
+	KindOrderStatus     = "order_mismatch"   // 支付成功但订单状态不符
+	KindRefund          = "refund_mismatch"  // 退款金额不一致
+)
+
+const repairActor = "reconcile"
+
+// 本地视为已收款的支付记录状态
+var paidStatuses = map[string]bool{
+	paymentService.StatusSuccess:       true,
+	paymentService.StatusRefundPending: true,
+	paymentService.StatusPartRefunded:  true,
+	paymentService.StatusRefunded:      true,
+}
+
+// Options 对账参数
+type Options struct {
+	From   time.Time // 对账时间段（按支付记录创建时间），左闭右开
+	To     time.Time
+	Repair bool // 自动修复安全的差异
+}
+
+// Mismatch 一条对账差异
+type Mismatch struct {
+	Kind      string
+	PaymentID string
+	OrderNo   string
+	Local     string // 本地状态与金额
+	Provider  string // 渠道状态与金额
+	Detail    string
+	Repaired  bool
+	RepairErr string
+}
+
+// Report 对账结果
+type Report struct {
+	From       time.Time
+	To         time.Time
+	Checked    int // 核对的支付单数
+	Mismatches []Mismatch
+}
+
+// Unresolved 未修复的差异数
+func (r *Report) Unresolved() int {
+	n := 0
+	for _, m := range r.Mismatches {
+		if !m.Repaired {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteCSV 以CSV输出差异明细
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"kind", "payment_id", "order_no", "local", "provider", "detail", "repaired", "repair_error"})
+	for _, m := range r.Mismatches {
+		writer.Write([]string{m.Kind, m.PaymentID, m.OrderNo, m.Local, m.Provider, m.Detail,
+			strconv.FormatBool(m.Repaired), m.RepairErr})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Reconcile 按支付单号核对渠道交易、本地支付记录与订单状态
+// 核对范围为时间段内创建的支付记录，以及对账单中出现的支付单。
+// 开启修复时只处理可以确定的差异：渠道已支付而本地待支付/已关闭的记录补记为成功，
+// 已支付但订单仍未支付的订单补记为已支付，订单已取消的支付记录标记为待退款；
+// 金额不一致、渠道缺失等其余差异只报告，由人工处理。
+func Reconcile(ctx context.Context, entries []Entry, opts Options) (*Report, error) {
+	records, err := loadRecords(ctx, entries, opts)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := loadOrders(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{From: opts.From, To: opts.To}
+	byPayment := make(map[string]*Entry, len(entries))
+	for i := range entries {
+		byPayment[entries[i].PaymentID] = &entries[i]
+	}
+	seen := make(map[string]bool, len(records))
+
+	for i := range records {
+		record := &records[i]
+		seen[record.PaymentID] = true
+		report.Checked++
+		if m := check(ctx, record, byPayment[record.PaymentID], orders[record.OrderID], opts.Repair); m != nil {
+			report.Mismatches = append(report.Mismatches, *m)
+		}
+	}
+
+	for _, entry := range entries {
+		if seen[entry.PaymentID] {
+			continue
+		}
+		report.Checked++
+		if entry.Status == paymentService.TradeSuccess {
+			report.Mismatches = append(report.Mismatches, Mismatch{
+				Kind:      KindMissingLocal,
+				PaymentID: entry.PaymentID,
+				OrderNo:   entry.OrderNo,
+				Provider:  describeEntry(&entry),
+				Detail:    "渠道已收款，本地无对应支付记录",
+			})
+		}
+	}
+
+	zap.L().Info("对账完成",
+		zap.Time("from", opts.From),
+		zap.Time("to", opts.To),
+		zap.Int("checked", report.Checked),
+		zap.Int("mismatches", len(report.Mismatches)),
+		zap.Int("unresolved", report.Unresolved()))
+	return report, nil
+}
+
+// check 核对一笔支付记录，无差异时返回nil
+func check(ctx context.Context, record *dal.PaymentRecord, entry *Entry, o *dal.Order, repair bool) *Mismatch {
+	m := &Mismatch{
+		PaymentID: record.PaymentID,
+		OrderNo:   record.OrderID,
+		Local:     fmt.Sprintf("%s %s", record.Status, record.Amount),
+	}
+
+	if entry == nil {
+		if !paidStatuses[record.Status] {
+			return nil
+		}
+		m.Kind = KindMissingProvider
+		m.Detail = "本地已支付，渠道无对应交易"
+		return m
+	}
+	m.Provider = describeEntry(entry)
+
+	// 先核对状态：未成功的交易（已关闭、待支付）金额可能与重新下单后的支付记录不同，不比较金额
+	paidAtProvider := entry.Status == paymentService.TradeSuccess
+	switch {
+	case paidAtProvider && !paidStatuses[record.Status]:
+		m.Kind = KindStatus
+		m.Detail = "渠道已支付，本地未记为成功"
+		// 失败的记录可能已换新支付单号重新发起，只修复待支付与已关闭的记录
+		if repair && (record.Status == paymentService.StatusPending || record.Status == paymentService.StatusCanceled) {
+			m.Repaired, m.RepairErr = result(markPaid(ctx, record, entry))
+		}
+		return m
+	case !paidAtProvider && paidStatuses[record.Status]:
+		m.Kind = KindStatus
+		m.Detail = "本地已支付，渠道未支付成功"
+		return m
+	case !paidAtProvider:
+		return nil
+	}
+
+	if !entry.Amount.Equal(record.Amount) {
+		m.Kind = KindAmount
+		m.Detail = "渠道交易金额与支付记录不一致"
+		return m
+	}
+
+	if o != nil && (o.Status == dal.OrderStatusUnpaid || o.Status == dal.OrderStatusCanceled) {
+		m.Kind = KindOrderStatus
+		m.Local = fmt.Sprintf("%s %s, 订单 %s", record.Status, record.Amount, o.Status)
+		if o.Status == dal.OrderStatusCanceled {
+			if record.Status == paymentService.StatusSuccess {
+				m.Detail = "支付成功但订单已取消，需退款"
+				if repair {
+					m.Repaired, m.RepairErr = result(paymentService.MarkRefundPending(ctx, dal.DB, record.OrderID))
+				}
+				return m
+			}
+			// 已标记待退款或已退款的取消订单属于正常情况
+			return nil
+		}
+		m.Detail = "支付成功但订单未支付"
+		if repair {
+			m.Repaired, m.RepairErr = result(markOrderPaid(ctx, dal.DB, record.OrderID))
+		}
+		return m
+	}
+
+	refunded, err := refundedTotal(ctx, record)
+	if err != nil {
+		m.Kind = KindRefund
+		m.Detail = err.Error()
+		return m
+	}
+	if !entry.Refunded.Equal(refunded) {
+		m.Kind = KindRefund
+		m.Local = fmt.Sprintf("%s %s, 已退 %s", record.Status, record.Amount, refunded)
+		m.Detail = "渠道退款金额与本地退款记录不一致"
+		return m
+	}
+	return nil
+}
+
+// markPaid 按渠道交易补记支付成功，并在同一事务中补齐订单状态
+func markPaid(ctx context.Context, record *dal.PaymentRecord, entry *Entry) error {
+	return dal.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := paymentService.MarkSucceeded(ctx, tx, &paymentService.Notification{
+			PaymentID: entry.PaymentID,
+			OrderNo:   record.OrderID,
+			Status:    paymentService.NotifySuccess,
+			Amount:    entry.Amount,
+			TradeNo:   entry.TradeNo,
+		})
+		if err != nil {
+			return err
+		}
+
+		var o dal.Order
+		if err := tx.Select("status").Where("order_no = ?", record.OrderID).First(&o).Error; err != nil {
+			return fmt.Errorf("订单查询失败: %w", err)
+		}
+		switch o.Status {
+		case dal.OrderStatusUnpaid:
+			return markOrderPaid(ctx, tx, record.OrderID)
+		case dal.OrderStatusCanceled:
+			return paymentService.MarkRefundPending(ctx, tx, record.OrderID)
+		}
+		return nil
+	})
+}
+
+func markOrderPaid(ctx context.Context, db *gorm.DB, orderNo string) error {
+	_, err := orderService.Transition(ctx, db, orderNo, dal.OrderStatusPaid, repairActor, "对账补记支付成功")
+	return err
+}
+
+// loadRecords 查询时间段内创建的支付记录，以及对账单中出现的支付单
+func loadRecords(ctx context.Context, entries []Entry, opts Options) ([]dal.PaymentRecord, error) {
+	var records []dal.PaymentRecord
+	if err := dal.DB.WithContext(ctx).
+		Where("created_at >= ? AND created_at < ?", opts.From, opts.To).
+		Order("id").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("支付记录查询失败: %w", err)
+	}
+
+	loaded := make(map[string]bool, len(records))
+	for _, r := range records {
+		loaded[r.PaymentID] = true
+	}
+	var missing []string
+	for _, e := range entries {
+		if !loaded[e.PaymentID] {
+			missing = append(missing, e.PaymentID)
+		}
+	}
+	// 分批查询，避免IN列表过长
+	const batch = 500
+	for start := 0; start < len(missing); start += batch {
+		end := start + batch
+		if end > len(missing) {
+			end = len(missing)
+		}
+		var extra []dal.PaymentRecord
+		if err := dal.DB.WithContext(ctx).Where("payment_id IN ?", missing[start:end]).Find(&extra).Error; err != nil {
+			return nil, fmt.Errorf("支付记录查询失败: %w", err)
+		}
+		records = append(records, extra...)
+	}
+	return records, nil
+}
+
+func loadOrders(ctx context.Context, records []dal.PaymentRecord) (map[string]*dal.Order, error) {
+	orderNos := make([]string, 0, len(records))
+	for _, r := range records {
+		orderNos = append(orderNos, r.OrderID)
+	}
+
+	orders := make(map[string]*dal.Order, len(records))
+	const batch = 500
+	for start := 0; start < len(orderNos); start += batch {
+		end := start + batch
+		if end > len(orderNos) {
+			end = len(orderNos)
+		}
+		var list []dal.Order
+		if err := dal.DB.WithContext(ctx).Where("order_no IN ?", orderNos[start:end]).Find(&list).Error; err != nil {
+			return nil, fmt.Errorf("订单查询失败: %w", err)
+		}
+		for i := range list {
+			orders[list[i].OrderNo] = &list[i]
+		}
+	}
+	return orders, nil
+}
+
+func refundedTotal(ctx context.Context, record *dal.PaymentRecord) (money.Money, error) {
+	var cents int64
+	if err := dal.DB.WithContext(ctx).Model(&dal.RefundRecord{}).
+		Select("COALESCE(SUM(amount_cents), 0)").
+		Where("payment_id = ? AND status = ?", record.PaymentID, paymentService.RefundStatusSuccess).
+		Scan(&cents).Error; err != nil {
+		return money.Money{}, fmt.Errorf("退款记录查询失败: %w", err)
+	}
+	return money.New(cents, record.Amount.Currency), nil
+}
+
+func describeEntry(e *Entry) string {
+	return fmt.Sprintf("%s %s", e.Status, e.Amount)
+}
+
+func result(err error) (bool, string) {
+	if err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
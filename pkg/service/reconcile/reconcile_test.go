@@ -0,0 +1,101 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	paymentService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/payment"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/testutil"
+)
+
+// setupDB 使用测试库替换全局数据库连接
+func setupDB(t *testing.T) {
+	t.Helper()
+	testutil.DB(t, &dal.Order{}, &dal.OrderStatusHistory{}, &dal.PaymentRecord{},
+		&dal.RefundRecord{}, &dal.OutboxEvent{})
+}
+
+func TestCheck(t *testing.T) {
+	setupDB(t)
+	amount := money.FromCents(1000)
+	other := money.FromCents(1200)
+
+	tests := []struct {
+		name        string
+		localStatus string
+		localAmount money.Money
+		tradeStatus string
+		tradeAmount money.Money
+		wantKind    string // 空表示无差异
+	}{
+		{"一致", paymentService.StatusSuccess, amount, paymentService.TradeSuccess, amount, ""},
+		{"已关闭的交易不比较金额", paymentService.StatusCanceled, amount, paymentService.TradeClosed, other, ""},
+		{"待支付的交易不比较金额", paymentService.StatusPending, amount, paymentService.TradePending, other, ""},
+		{"成功的交易金额不一致", paymentService.StatusSuccess, amount, paymentService.TradeSuccess, other, KindAmount},
+		{"本地已支付渠道未成功", paymentService.StatusSuccess, amount, paymentService.TradeClosed, other, KindStatus},
+		{"渠道已支付本地未成功", paymentService.StatusFailed, amount, paymentService.TradeSuccess, other, KindStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &dal.PaymentRecord{OrderID: "T1001", PaymentID: "pay-1001", Amount: tt.localAmount, Status: tt.localStatus}
+			entry := &Entry{PaymentID: record.PaymentID, OrderNo: record.OrderID, Status: tt.tradeStatus,
+				Amount: tt.tradeAmount, Refunded: money.Zero(money.DefaultCurrency)}
+			o := &dal.Order{OrderNo: record.OrderID, Status: dal.OrderStatusPaid}
+
+			m := check(context.Background(), record, entry, o, false)
+			switch {
+			case tt.wantKind == "" && m != nil:
+				t.Errorf("check() = %+v，期望无差异", m)
+			case tt.wantKind != "" && (m == nil || m.Kind != tt.wantKind):
+				t.Errorf("check() = %+v，期望 %s", m, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestRepairMarksPaymentAndOrderTogether(t *testing.T) {
+	tests := []struct {
+		name            string
+		breakHistory    bool // 模拟订单状态更新失败
+		wantRepaired    bool
+		wantPayment     string
+		wantOrderStatus dal.OrderStatus
+	}{
+		{"补记成功", false, true, paymentService.StatusSuccess, dal.OrderStatusPaid},
+		{"订单更新失败时支付记录回滚", true, false, paymentService.StatusPending, dal.OrderStatusUnpaid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupDB(t)
+			ctx := context.Background()
+
+			amount := money.FromCents(1000)
+			o := &dal.Order{UserID: 7, OrderNo: "T2001", Amount: amount, Items: "{}", Status: dal.OrderStatusUnpaid}
+			record := &dal.PaymentRecord{OrderID: o.OrderNo, PaymentID: "pay-2001", Amount: amount,
+				Status: paymentService.StatusPending, UserID: 7}
+			dal.DB.Create(o)
+			dal.DB.Create(record)
+			if tt.breakHistory {
+				dal.DB.Migrator().DropTable(&dal.OrderStatusHistory{})
+			}
+
+			entry := &Entry{PaymentID: record.PaymentID, OrderNo: o.OrderNo, Status: paymentService.TradeSuccess,
+				Amount: amount, Refunded: money.Zero(money.DefaultCurrency), TradeNo: "trade-2001"}
+			m := check(ctx, record, entry, o, true)
+			if m == nil || m.Kind != KindStatus || m.Repaired != tt.wantRepaired {
+				t.Fatalf("check() = %+v，期望 %s 且修复结果为 %v", m, KindStatus, tt.wantRepaired)
+			}
+
+			var savedRecord dal.PaymentRecord
+			var savedOrder dal.Order
+			dal.DB.First(&savedRecord, record.ID)
+			dal.DB.First(&savedOrder, o.ID)
+			if savedRecord.Status != tt.wantPayment || savedOrder.Status != tt.wantOrderStatus {
+				t.Errorf("支付记录/订单 = %s/%s，期望 %s/%s",
+					savedRecord.Status, savedOrder.Status, tt.wantPayment, tt.wantOrderStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+	paymentService "github.com/daheishandemao/Tiktok-E-commerce/pkg/service/payment"
+)
+
+var ErrInvalidStatement = errors.New("对账单格式错误")
+
+// 对账单必须包含的列，其余列（order_no、refunded_cents、trade_no）可选
+var requiredColumns = []string{"payment_id", "status", "amount_cents", "currency"}
+
+// Entry 渠道对账单中的一笔交易
+type Entry struct {
+	PaymentID string
+	OrderNo   string
+	Status    string // 渠道交易状态，见 payment.Trade*
+	Amount    money.Money
+	Refunded  money.Money
+	TradeNo   string
+}
+
+// ParseStatement 解析渠道结算文件（CSV，首行为列名）
+//
+//	payment_id,order_no,status,amount_cents,currency,refunded_cents,trade_no
+func ParseStatement(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: 读取列名失败: %v", ErrInvalidStatement, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: 缺少列 %s", ErrInvalidStatement, name)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var entries []Entry
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: 第%d行: %v", ErrInvalidStatement, line, err)
+		}
+
+		currency := strings.ToUpper(field(record, "currency"))
+		amount, err := strconv.ParseInt(field(record, "amount_cents"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: 第%d行金额无效", ErrInvalidStatement, line)
+		}
+		var refunded int64
+		if v := field(record, "refunded_cents"); v != "" {
+			if refunded, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: 第%d行退款金额无效", ErrInvalidStatement, line)
+			}
+		}
+
+		entry := Entry{
+			PaymentID: field(record, "payment_id"),
+			OrderNo:   field(record, "order_no"),
+			Status:    strings.ToLower(field(record, "status")),
+			Amount:    money.New(amount, currency),
+			Refunded:  money.New(refunded, currency),
+			TradeNo:   field(record, "trade_no"),
+		}
+		if entry.PaymentID == "" {
+			return nil, fmt.Errorf("%w: 第%d行缺少支付单号", ErrInvalidStatement, line)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// FetchFromProvider 没有结算文件时，逐笔查询对账时间段内本地支付记录在渠道侧的交易
+// 渠道侧不存在的交易不会出现在结果中（对账时报告为渠道缺失）。
+func FetchFromProvider(ctx context.Context, from, to time.Time) ([]Entry, error) {
+	var paymentIDs []string
+	if err := dal.DB.WithContext(ctx).Model(&dal.PaymentRecord{}).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Pluck("payment_id", &paymentIDs).Error; err != nil {
+		return nil, fmt.Errorf("支付记录查询失败: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(paymentIDs))
+	for _, id := range paymentIDs {
+		trade, err := paymentService.DefaultProvider.Query(ctx, id)
+		if errors.Is(err, paymentService.ErrTradeNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("渠道交易查询失败(%s): %w", id, err)
+		}
+		entries = append(entries, Entry{
+			PaymentID: trade.PaymentID,
+			OrderNo:   trade.OrderNo,
+			Status:    trade.Status,
+			Amount:    trade.Amount,
+			Refunded:  trade.Refunded,
+			TradeNo:   trade.TradeNo,
+		})
+	}
+	return entries, nil
+}
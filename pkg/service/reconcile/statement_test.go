@@ -0,0 +1,57 @@
+package reconcile
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/money"
+)
+
+func TestParseStatement(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			name: "完整列",
+			in: "payment_id,order_no,status,amount_cents,currency,refunded_cents,trade_no\n" +
+				"pay-1,T1,SUCCESS,1999,cny,500,MOCK1\n",
+			want: []Entry{{PaymentID: "pay-1", OrderNo: "T1", Status: "success",
+				Amount: money.FromCents(1999), Refunded: money.FromCents(500), TradeNo: "MOCK1"}},
+		},
+		{
+			name: "列顺序与空白",
+			in:   " Currency , STATUS,amount_cents,payment_id\nUSD, closed ,100, pay-2\n",
+			want: []Entry{{PaymentID: "pay-2", Status: "closed",
+				Amount: money.New(100, "USD"), Refunded: money.New(0, "USD")}},
+		},
+		{name: "只有列名", in: "payment_id,status,amount_cents,currency\n", want: nil},
+		{name: "空文件", in: "", wantErr: true},
+		{name: "缺少必需列", in: "payment_id,status,amount_cents\npay-1,success,100\n", wantErr: true},
+		{name: "金额不是整数", in: "payment_id,status,amount_cents,currency\npay-1,success,19.99,CNY\n", wantErr: true},
+		{name: "退款金额无效", in: "payment_id,status,amount_cents,currency,refunded_cents\npay-1,success,100,CNY,x\n", wantErr: true},
+		{name: "缺少支付单号", in: "payment_id,status,amount_cents,currency\n,success,100,CNY\n", wantErr: true},
+		{name: "列数不一致", in: "payment_id,status,amount_cents,currency\npay-1,success,100\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStatement(strings.NewReader(tt.in))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidStatement) {
+					t.Fatalf("ParseStatement() 错误 = %v，期望 %v", err, ErrInvalidStatement)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseStatement() 错误 = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseStatement() = %+v，期望 %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/dal"
+	"github.com/daheishandemao/Tiktok-E-commerce/pkg/middleware"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// 用户相关错误定义（HTTP与RPC共用）
+var (
+	ErrInvalidParams = errors.New("用户名需4-20字符，密码需6-32字符")
+	ErrUserExists    = errors.New("用户名已存在")
+	ErrUserNotFound  = errors.New("用户不存在")
+	ErrWrongPassword = errors.New("用户名或密码错误")
+)
+
+// Register 校验参数并创建用户，密码使用bcrypt加密存储
+func Register(ctx context.Context, username, password string) (*dal.User, error) {
+	if len(username) < 4 || len(username) > 20 || len(password) < 6 || len(password) > 32 {
+		return nil, ErrInvalidParams
+	}
+
+	// 检查用户名是否存在
+	var existUser dal.User
+	err := dal.DB.WithContext(ctx).Where("username = ?", username).First(&existUser).Error
+	if err == nil {
+		return nil, ErrUserExists
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("数据库查询失败: %w", err)
+	}
+
+	// 默认cost=10，约100ms计算时间
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("密码加密失败: %w", err)
+	}
+
+	now := time.Now()
+	newUser := &dal.User{
+		Username:  username,
+		Password:  string(hashedPassword),
+		LastLogin: &now,
+	}
+	if err := dal.DB.WithContext(ctx).Create(newUser).Error; err != nil {
+		return nil, fmt.Errorf("用户创建失败: %w", err)
+	}
+	return newUser, nil
+}
+
+// Login 校验用户名密码，成功后刷新最后登录时间
+func Login(ctx context.Context, username, password string) (*dal.User, error) {
+	var u dal.User
+	if err := dal.DB.WithContext(ctx).Where("username = ?", username).First(&u).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrWrongPassword
+		}
+		return nil, fmt.Errorf("数据库查询失败: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)); err != nil {
+		return nil, ErrWrongPassword
+	}
+
+	now := time.Now()
+	if err := dal.DB.WithContext(ctx).Model(&u).Update("last_login", &now).Error; err != nil {
+		return nil, fmt.Errorf("登录时间更新失败: %w", err)
+	}
+	return &u, nil
+}
+
+// GetUser 按ID查询用户
+func GetUser(ctx context.Context, userID uint) (*dal.User, error) {
+	var u dal.User
+	if err := dal.DB.WithContext(ctx).First(&u, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("数据库查询失败: %w", err)
+	}
+	return &u, nil
+}
+
+// CheckToken 校验令牌并确认其对应的用户仍然存在，返回用户ID
+func CheckToken(ctx context.Context, token string) (uint, error) {
+	userID, err := middleware.ParseToken(token)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := GetUser(ctx, userID); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}